@@ -0,0 +1,19 @@
+// Command sample is a sample apt plugin. Build it into the plugin directory
+// to add the sample module to the dashboard:
+//
+//	go build -o ~/.kubeapt/plugins/sample ./examples/plugins/sample
+package main
+
+import (
+	"fmt"
+	"github.com/twosson/kubeapt/pkg/plugin"
+	"github.com/twosson/kubeapt/pkg/plugin/sample"
+	"os"
+)
+
+func main() {
+	if err := plugin.Serve(sample.New()); err != nil {
+		fmt.Fprintf(os.Stderr, "sample plugin: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -1,7 +1,6 @@
 package api
 
 import (
-	"encoding/json"
 	"github.com/gorilla/mux"
 	"github.com/heptio/go-telemetry/pkg/telemetry"
 	"github.com/pkg/errors"
@@ -11,6 +10,8 @@ import (
 	"github.com/twosson/kubeapt/internal/module"
 	"net/http"
 	"path"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -20,44 +21,42 @@ type Service interface {
 	Handler() *mux.Router
 }
 
-type errorMessage struct {
-	Code    int    `json:"code,omitempty"`
-	Message string `json:"message,omitempty"`
-}
-
-type errorResponse struct {
-	Error errorMessage `json:"error,omitempty"`
-}
-
+// respondWithError responds with an error in the format the dashboard
+// expects.
 func respondWithError(w http.ResponseWriter, code int, message string) error {
-	r := &errorResponse{
-		Error: errorMessage{
-			Code:    code,
-			Message: message,
-		},
-	}
-
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-
-	w.WriteHeader(code)
-
-	if err := json.NewEncoder(w).Encode(r); err != nil {
-		return errors.Errorf("encoding response: %v", err)
-	}
-	return nil
+	return apt.RespondWithError(w, code, message)
 }
 
 // API is the API for the dashboard client
 type API struct {
-	nsClient        cluster.NamespaceInterface
-	infoClient      cluster.InfoInterface
 	moduleManager   module.ManagerInterface
-	sections        []*apt.Navigation
 	prefix          string
 	logger          log.Logger
 	telemetryClient telemetry.Interface
+	execCommands    []string
+	exec            *execService
+	portForwarder   cluster.PortForwardInterface
+	contexts        cluster.ContextInterface
+
+	// mu guards the clients and modules, which are replaced when the
+	// cluster context is switched.
+	mu         sync.Mutex
+	nsClient   cluster.NamespaceInterface
+	infoClient cluster.InfoInterface
+	navModules []module.Module
+	modules    map[string]http.Handler
+	clusters   map[string]*apiCluster
+}
+
+// Option is an option for configuring API.
+type Option func(a *API)
 
-	modules map[string]http.Handler
+// WithExecCommands limits the commands which can be run in containers with
+// the exec endpoint. If no commands are supplied, any command can be run.
+func WithExecCommands(commands ...string) Option {
+	return func(a *API) {
+		a.execCommands = commands
+	}
 }
 
 func (a *API) telemetryMiddleware(next http.Handler) http.Handler {
@@ -71,64 +70,292 @@ func (a *API) telemetryMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// WithPortForwarder enables the port forward endpoints.
+func WithPortForwarder(portForwarder cluster.PortForwardInterface) Option {
+	return func(a *API) {
+		a.portForwarder = portForwarder
+	}
+}
+
+// WithContexts enables listing and switching kubeconfig contexts.
+func WithContexts(contexts cluster.ContextInterface) Option {
+	return func(a *API) {
+		a.contexts = contexts
+	}
+}
+
 // New creates an instance of API.
-func New(prefix string, nsClient cluster.NamespaceInterface, infoClient cluster.InfoInterface, moduleManager module.ManagerInterface, logger log.Logger, telemetryClient telemetry.Interface) *API {
-	return &API{
+// Exec sessions are closed when the module manager unloads its modules.
+func New(prefix string, nsClient cluster.NamespaceInterface, infoClient cluster.InfoInterface, moduleManager module.ManagerInterface, logger log.Logger, telemetryClient telemetry.Interface, opts ...Option) *API {
+	a := &API{
 		prefix:          prefix,
 		nsClient:        nsClient,
 		infoClient:      infoClient,
 		moduleManager:   moduleManager,
 		modules:         make(map[string]http.Handler),
+		clusters:        make(map[string]*apiCluster),
 		logger:          logger,
 		telemetryClient: telemetryClient,
 	}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	a.exec = newExecService(moduleManager, a.execCommands, logger.With("component", "exec"))
+	moduleManager.OnUnload(a.exec.close)
+
+	for _, c := range a.clusters {
+		c.exec = newExecService(c.manager, a.execCommands, logger.With("component", "exec", "cluster", c.name))
+		c.manager.OnUnload(c.exec.close)
+	}
+
+	return a
 }
 
 // Handler returns a HTTP handler for the service.
 func (a *API) Handler() *mux.Router {
 	router := mux.NewRouter()
 	router.Use(a.telemetryMiddleware)
+	router.Use(sameOriginMiddleware)
 	s := router.PathPrefix(a.prefix).Subrouter()
 
-	namespacesService := newNamespaces(a.nsClient, a.logger)
-	s.Handle("/namespaces", namespacesService).Methods(http.MethodGet)
+	s.HandleFunc("/namespaces", func(w http.ResponseWriter, r *http.Request) {
+		newNamespaces(a.namespaceClient(), a.logger).ServeHTTP(w, r)
+	}).Methods(http.MethodGet)
 
-	navigationService := newNavigation(a.sections, a.logger)
+	navigationService := newNavigation(a.navigationSections, a.logger)
 	s.Handle("/navigation", navigationService).Methods(http.MethodGet)
 
 	namespaceUpdateService := newNamespace(a.moduleManager, a.logger)
 	s.HandleFunc("/namespace", namespaceUpdateService.update).Methods(http.MethodPost)
 	s.HandleFunc("/namespace", namespaceUpdateService.read).Methods(http.MethodGet)
 
-	infoService := newClusterInfo(a.infoClient, a.logger)
-	s.Handle("/cluster-info", infoService)
+	s.Handle("/events", newEvents(a.moduleManager, a.logger)).Methods(http.MethodGet)
 
-	for p, h := range a.modules {
-		s.PathPrefix(p).Handler(h)
-	}
+	s.Handle("/search", newSearch(a.prefix, a.moduleManager, a.logger)).Methods(http.MethodGet)
 
-	s.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		a.logger.Errorf("api handler not found: %s", r.URL.String())
-		if err := respondWithError(w, http.StatusNotFound, "not found"); err != nil {
-			a.logger.Errorf("responding: %v", err)
-		}
+	s.HandleFunc("/cluster-info", func(w http.ResponseWriter, r *http.Request) {
+		newClusterInfo(a.clusterInfoClient(), a.logger).ServeHTTP(w, r)
 	})
 
+	contextsService := newContexts(a.contexts, a.clusterInfoClient, a.switchContext, a.logger)
+	s.HandleFunc("/contexts", contextsService.list).Methods(http.MethodGet)
+	s.HandleFunc("/contexts", contextsService.update).Methods(http.MethodPost)
+
+	s.Handle(execPath, a.exec).Methods(http.MethodGet)
+
+	portForwardsService := newPortForwards(a.portForwarder, a.moduleManager.GetNamespace, a.logger)
+	s.HandleFunc("/port-forwards", portForwardsService.list).Methods(http.MethodGet)
+	s.HandleFunc("/port-forwards", portForwardsService.create).Methods(http.MethodPost)
+	s.HandleFunc("/port-forwards/{id}", portForwardsService.read).Methods(http.MethodGet)
+	s.HandleFunc("/port-forwards/{id}", portForwardsService.delete).Methods(http.MethodDelete)
+
+	s.PathPrefix("/content").HandlerFunc(a.serveModule)
+
+	s.HandleFunc("/clusters", a.listClusters).Methods(http.MethodGet)
+	s.HandleFunc("/clusters/{cluster}/namespaces", a.clusterHandler(a.serveClusterNamespaces)).Methods(http.MethodGet)
+	s.HandleFunc("/clusters/{cluster}/namespace", a.clusterHandler(a.updateClusterNamespace)).Methods(http.MethodPost)
+	s.HandleFunc("/clusters/{cluster}/namespace", a.clusterHandler(a.readClusterNamespace)).Methods(http.MethodGet)
+	s.HandleFunc("/clusters/{cluster}/events", a.clusterHandler(a.serveClusterEvents)).Methods(http.MethodGet)
+	s.HandleFunc("/clusters/{cluster}/search", a.clusterHandler(a.serveClusterSearch)).Methods(http.MethodGet)
+	s.HandleFunc("/clusters/{cluster}"+execPath, a.clusterHandler(a.serveClusterExec)).Methods(http.MethodGet)
+	s.HandleFunc("/clusters/{cluster}/port-forwards", a.clusterPortForwards((*portForwards).list)).Methods(http.MethodGet)
+	s.HandleFunc("/clusters/{cluster}/port-forwards", a.clusterPortForwards((*portForwards).create)).Methods(http.MethodPost)
+	s.HandleFunc("/clusters/{cluster}/port-forwards/{id}", a.clusterPortForwards((*portForwards).read)).Methods(http.MethodGet)
+	s.HandleFunc("/clusters/{cluster}/port-forwards/{id}", a.clusterPortForwards((*portForwards).delete)).Methods(http.MethodDelete)
+	s.PathPrefix("/clusters/{cluster}/content").HandlerFunc(a.clusterHandler(a.serveClusterModule))
+
+	s.NotFoundHandler = http.HandlerFunc(a.notFound)
+
 	return router
 }
 
 // RegisterModule registers a module with the API service.
 func (a *API) RegisterModule(m module.Module) error {
-	contentPath := path.Join("/content", m.ContentPath())
+	contentPath, handler, err := a.moduleHandler(m)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.modules[contentPath] = handler
+	a.navModules = append(a.navModules, m)
+
+	return nil
+}
+
+// moduleContentPath returns the path a module's content is served from,
+// relative to the API prefix.
+func moduleContentPath(m module.Module) string {
+	return path.Join("/content", m.ContentPath())
+}
+
+// moduleHandler creates the content handler for a module.
+func (a *API) moduleHandler(m module.Module) (string, http.Handler, error) {
+	contentPath := moduleContentPath(m)
 	a.logger.Debugf("registering content path %s", contentPath)
-	a.modules[contentPath] = m.Handler(path.Join(a.prefix, contentPath))
 
-	nav, err := m.Navigation(contentPath)
+	if _, err := m.Navigation(contentPath); err != nil {
+		return "", nil, err
+	}
+
+	return contentPath, m.Handler(path.Join(a.prefix, contentPath)), nil
+}
+
+// serveModule routes content requests to the registered module with the
+// matching content path. Modules are looked up for each request because
+// they are replaced when the cluster context is switched.
+func (a *API) serveModule(w http.ResponseWriter, r *http.Request) {
+	p := strings.TrimPrefix(r.URL.Path, strings.TrimSuffix(a.prefix, "/"))
+
+	a.mu.Lock()
+	var handler http.Handler
+	for contentPath, h := range a.modules {
+		if p == contentPath || strings.HasPrefix(p, contentPath+"/") {
+			handler = h
+			break
+		}
+	}
+	a.mu.Unlock()
+
+	if handler == nil {
+		a.notFound(w, r)
+		return
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+func (a *API) namespaceClient() cluster.NamespaceInterface {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.nsClient
+}
+
+func (a *API) clusterInfoClient() cluster.InfoInterface {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.infoClient
+}
+
+// switchContext switches the dashboard to a kubeconfig context. The modules
+// are reloaded for the new cluster and registered again. Exec sessions and
+// port forwards to the previous cluster are stopped. If the reloaded modules
+// can't be registered, the manager is switched back to the previous cluster.
+func (a *API) switchContext(name string) error {
+	clusterClient, err := a.contexts.ClientForContext(name)
+	if err != nil {
+		return err
+	}
+
+	nsClient, err := clusterClient.NamespaceClient()
 	if err != nil {
+		return errors.Wrap(err, "creating namespace client")
+	}
+
+	infoClient, err := clusterClient.InfoClient()
+	if err != nil {
+		return errors.Wrap(err, "creating info client")
+	}
+
+	previousClient := a.moduleManager.ClusterClient()
+	previousNamespace := a.moduleManager.GetNamespace()
+
+	if err := a.moduleManager.SwitchCluster(clusterClient, nsClient.InitialNamespace()); err != nil {
+		// The manager restores the previous cluster with new module
+		// instances, so they have to be registered again.
+		a.reregisterModules()
+		return err
+	}
+
+	modules, navModules, err := a.moduleHandlers()
+	if err != nil {
+		if rollbackErr := a.moduleManager.SwitchCluster(previousClient, previousNamespace); rollbackErr != nil {
+			a.logger.Errorf("restoring previous cluster: %v", rollbackErr)
+		}
+		a.reregisterModules()
 		return err
 	}
 
-	a.sections = append(a.sections, nav)
+	a.mu.Lock()
+	a.nsClient = nsClient
+	a.infoClient = infoClient
+	a.modules = modules
+	a.navModules = navModules
+	a.mu.Unlock()
+
+	a.exec.closeSessions()
+
+	if a.portForwarder != nil {
+		if err := a.portForwarder.SetClient(clusterClient); err != nil {
+			a.logger.Errorf("switching port forwarder cluster: %v", err)
+		}
+	}
+
+	a.logger.With("context", name).Infof("switched context")
 
 	return nil
 }
+
+// moduleHandlers creates the content handlers for the manager's modules.
+func (a *API) moduleHandlers() (map[string]http.Handler, []module.Module, error) {
+	modules := make(map[string]http.Handler)
+	var navModules []module.Module
+	for _, m := range a.moduleManager.Modules() {
+		contentPath, handler, err := a.moduleHandler(m)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "registering module %s", m.Name())
+		}
+		modules[contentPath] = handler
+		navModules = append(navModules, m)
+	}
+
+	return modules, navModules, nil
+}
+
+// reregisterModules replaces the registered modules with the manager's
+// current modules. The registered modules are kept if that fails.
+func (a *API) reregisterModules() {
+	modules, navModules, err := a.moduleHandlers()
+	if err != nil {
+		a.logger.Errorf("registering restored modules: %v", err)
+		return
+	}
+
+	a.mu.Lock()
+	a.modules = modules
+	a.navModules = navModules
+	a.mu.Unlock()
+}
+
+// navigationSections generates navigation sections for registered modules.
+// Additional clusters follow the current cluster's modules.
+func (a *API) navigationSections() ([]*apt.Navigation, error) {
+	a.mu.Lock()
+	navModules := a.navModules
+	a.mu.Unlock()
+
+	var sections []*apt.Navigation
+
+	for _, m := range navModules {
+		contentPath := path.Join("/content", m.ContentPath())
+		nav, err := m.Navigation(contentPath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "generating navigation for module %s", m.Name())
+		}
+
+		sections = append(sections, nav)
+	}
+
+	clusterSections, err := a.clusterNavigationSections()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(sections, clusterSections...), nil
+}
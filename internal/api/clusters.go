@@ -0,0 +1,274 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/twosson/kubeapt/internal/apt"
+	"github.com/twosson/kubeapt/internal/cluster"
+	"github.com/twosson/kubeapt/internal/module"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+)
+
+// clusterPath returns the path for content from a cluster, relative to the
+// API prefix.
+func clusterPath(id string) string {
+	return path.Join("/clusters", id)
+}
+
+// clusterID returns the path segment which identifies a cluster. Context
+// names can contain characters which aren't safe in a path, e.g. EKS
+// context names are ARNs which contain "/". Those characters are replaced,
+// and a hash of the name is appended so IDs stay unique and stable.
+func clusterID(name string) string {
+	id := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		default:
+			return '-'
+		}
+	}, name)
+
+	if id == name && name != "" {
+		return id
+	}
+
+	sum := sha256.Sum256([]byte(name))
+	return fmt.Sprintf("%s-%x", id, sum[:4])
+}
+
+// apiCluster is a cluster which is viewed alongside the current cluster.
+// Each cluster has its own module manager, so its modules use their own
+// cluster client, caches and namespace. Exec sessions and port forwards
+// are also started in the cluster.
+type apiCluster struct {
+	id            string
+	name          string
+	manager       module.ManagerInterface
+	portForwarder cluster.PortForwardInterface
+	exec          *execService
+	navModules    []module.Module
+	modules       map[string]http.Handler
+}
+
+// newAPICluster creates the content handlers for a cluster's modules. They
+// are served below /clusters/<id>/content.
+func newAPICluster(prefix, name string, manager module.ManagerInterface, portForwarder cluster.PortForwardInterface) *apiCluster {
+	c := &apiCluster{
+		id:            clusterID(name),
+		name:          name,
+		manager:       manager,
+		portForwarder: portForwarder,
+		modules:       make(map[string]http.Handler),
+	}
+
+	for _, m := range manager.Modules() {
+		contentPath := path.Join("/content", m.ContentPath())
+		c.modules[contentPath] = m.Handler(path.Join(prefix, clusterPath(c.id), contentPath))
+		c.navModules = append(c.navModules, m)
+	}
+
+	return c
+}
+
+// handler returns the module handler for a path relative to the cluster.
+func (c *apiCluster) handler(p string) http.Handler {
+	for contentPath, h := range c.modules {
+		if p == contentPath || strings.HasPrefix(p, contentPath+"/") {
+			return h
+		}
+	}
+
+	return nil
+}
+
+// navigation generates a navigation section for the cluster. Its children
+// are the navigation sections of the cluster's modules.
+func (c *apiCluster) navigation() (*apt.Navigation, error) {
+	nav := &apt.Navigation{
+		Title: c.name,
+		Path:  clusterPath(c.id) + "/",
+	}
+
+	for _, m := range c.navModules {
+		contentPath := path.Join(clusterPath(c.id), "content", m.ContentPath())
+		child, err := m.Navigation(contentPath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "generating navigation for module %s in cluster %s", m.Name(), c.name)
+		}
+
+		nav.Children = append(nav.Children, child)
+	}
+
+	return nav, nil
+}
+
+// WithCluster adds a cluster which is viewed alongside the current cluster.
+// Its content is served below /clusters/<id>/content, where id is derived
+// from name. Port forwards to the cluster are started with portForwarder,
+// which can be nil if port forwarding is not available.
+func WithCluster(name string, manager module.ManagerInterface, portForwarder cluster.PortForwardInterface) Option {
+	return func(a *API) {
+		c := newAPICluster(a.prefix, name, manager, portForwarder)
+		a.clusters[c.id] = c
+	}
+}
+
+type clusterResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+type clustersResponse struct {
+	Clusters []clusterResponse `json:"clusters"`
+}
+
+// clusterIDs returns the IDs of the additional clusters in order.
+func (a *API) clusterIDs() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var ids []string
+	for id := range a.clusters {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+func (a *API) cluster(id string) *apiCluster {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.clusters[id]
+}
+
+// listClusters lists the additional clusters.
+func (a *API) listClusters(w http.ResponseWriter, r *http.Request) {
+	cr := clustersResponse{
+		Clusters: []clusterResponse{},
+	}
+
+	for _, id := range a.clusterIDs() {
+		c := a.cluster(id)
+		if c == nil {
+			continue
+		}
+
+		cr.Clusters = append(cr.Clusters, clusterResponse{
+			ID:   c.id,
+			Name: c.name,
+			Path: clusterPath(c.id),
+		})
+	}
+
+	if err := json.NewEncoder(w).Encode(&cr); err != nil {
+		a.logger.Errorf("encoding clusters: %v", err)
+	}
+}
+
+// clusterHandler wraps handlers for a cluster's endpoints. It responds with
+// not found if the cluster in the path isn't registered.
+func (a *API) clusterHandler(fn func(c *apiCluster, w http.ResponseWriter, r *http.Request)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		c := a.cluster(mux.Vars(r)["cluster"])
+		if c == nil {
+			a.notFound(w, r)
+			return
+		}
+
+		fn(c, w, r)
+	}
+}
+
+// serveClusterModule routes content requests to a cluster's module with the
+// matching content path.
+func (a *API) serveClusterModule(c *apiCluster, w http.ResponseWriter, r *http.Request) {
+	p := strings.TrimPrefix(r.URL.Path, strings.TrimSuffix(a.prefix, "/"))
+	p = strings.TrimPrefix(p, clusterPath(c.id))
+
+	handler := c.handler(p)
+	if handler == nil {
+		a.notFound(w, r)
+		return
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+func (a *API) serveClusterNamespaces(c *apiCluster, w http.ResponseWriter, r *http.Request) {
+	nsClient, err := c.manager.ClusterClient().NamespaceClient()
+	if err != nil {
+		a.logger.With("cluster", c.name).Errorf("creating namespace client: %v", err)
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	newNamespaces(nsClient, a.logger).ServeHTTP(w, r)
+}
+
+func (a *API) readClusterNamespace(c *apiCluster, w http.ResponseWriter, r *http.Request) {
+	newNamespace(c.manager, a.logger).read(w, r)
+}
+
+func (a *API) updateClusterNamespace(c *apiCluster, w http.ResponseWriter, r *http.Request) {
+	newNamespace(c.manager, a.logger).update(w, r)
+}
+
+func (a *API) serveClusterEvents(c *apiCluster, w http.ResponseWriter, r *http.Request) {
+	newEvents(c.manager, a.logger.With("cluster", c.name)).ServeHTTP(w, r)
+}
+
+func (a *API) serveClusterSearch(c *apiCluster, w http.ResponseWriter, r *http.Request) {
+	prefix := path.Join(a.prefix, clusterPath(c.id))
+	newSearch(prefix, c.manager, a.logger.With("cluster", c.name)).ServeHTTP(w, r)
+}
+
+func (a *API) serveClusterExec(c *apiCluster, w http.ResponseWriter, r *http.Request) {
+	c.exec.ServeHTTP(w, r)
+}
+
+// clusterPortForwards wraps a port forwards handler so it is served with
+// the cluster's port forwarder.
+func (a *API) clusterPortForwards(fn func(p *portForwards, w http.ResponseWriter, r *http.Request)) http.HandlerFunc {
+	return a.clusterHandler(func(c *apiCluster, w http.ResponseWriter, r *http.Request) {
+		fn(newPortForwards(c.portForwarder, c.manager.GetNamespace, a.logger.With("cluster", c.name)), w, r)
+	})
+}
+
+// clusterNavigationSections generates a navigation section for each
+// additional cluster.
+func (a *API) clusterNavigationSections() ([]*apt.Navigation, error) {
+	var sections []*apt.Navigation
+
+	for _, id := range a.clusterIDs() {
+		c := a.cluster(id)
+		if c == nil {
+			continue
+		}
+
+		nav, err := c.navigation()
+		if err != nil {
+			return nil, err
+		}
+
+		sections = append(sections, nav)
+	}
+
+	return sections, nil
+}
+
+func (a *API) notFound(w http.ResponseWriter, r *http.Request) {
+	a.logger.Errorf("api handler not found: %s", r.URL.String())
+	if err := respondWithError(w, http.StatusNotFound, "not found"); err != nil {
+		a.logger.Errorf("responding: %v", err)
+	}
+}
@@ -0,0 +1,247 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twosson/kubeapt/internal/apt"
+	"github.com/twosson/kubeapt/internal/cluster/fake"
+	"github.com/twosson/kubeapt/internal/log"
+	"github.com/twosson/kubeapt/internal/module"
+	modulefake "github.com/twosson/kubeapt/internal/module/fake"
+	"k8s.io/apimachinery/pkg/runtime"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newClustersTestAPI(t *testing.T) (*API, *modulefake.StubManager, *fake.PortForwarder) {
+	m := modulefake.NewModule("module", log.NopLogger())
+	manager := modulefake.NewStubManager("default", []module.Module{m})
+
+	stagingClient, err := fake.NewClient(runtime.NewScheme(), nil, nil)
+	require.NoError(t, err)
+	stagingClient.FakeNamespace = fake.NewNamespaceClient([]string{"apps", "default"}, nil, "apps")
+
+	stagingModule := modulefake.NewModule("staging-module", log.NopLogger())
+	stagingManager := modulefake.NewStubManager("apps", []module.Module{stagingModule})
+	stagingManager.SetClusterClient(stagingClient)
+
+	stagingPortForwarder := fake.NewPortForwarder(nil)
+
+	nsClient := fake.NewNamespaceClient([]string{"default"}, nil, "default")
+	a := New("/", nsClient, fake.ClusterInfo{}, manager, log.NopLogger(), telemetryClient,
+		WithPortForwarder(fake.NewPortForwarder(nil)),
+		WithCluster("staging", stagingManager, stagingPortForwarder))
+	require.NoError(t, a.RegisterModule(m))
+
+	return a, stagingManager, stagingPortForwarder
+}
+
+func TestAPI_clusterRoutes(t *testing.T) {
+	cases := []struct {
+		name            string
+		method          string
+		path            string
+		body            string
+		expectedCode    int
+		expectedContent string
+	}{
+		{
+			name:            "list clusters",
+			method:          http.MethodGet,
+			path:            "/clusters",
+			expectedCode:    http.StatusOK,
+			expectedContent: `{"clusters":[{"id":"staging","name":"staging","path":"/clusters/staging"}]}`,
+		},
+		{
+			name:            "module root",
+			method:          http.MethodGet,
+			path:            "/clusters/staging/content/staging-module/",
+			expectedCode:    http.StatusOK,
+			expectedContent: "root",
+		},
+		{
+			name:            "module nested",
+			method:          http.MethodGet,
+			path:            "/clusters/staging/content/staging-module/nested",
+			expectedCode:    http.StatusOK,
+			expectedContent: "staging-module",
+		},
+		{
+			name:         "module from other cluster",
+			method:       http.MethodGet,
+			path:         "/clusters/staging/content/module/",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "unknown cluster",
+			method:       http.MethodGet,
+			path:         "/clusters/prod/content/staging-module/",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:            "namespaces",
+			method:          http.MethodGet,
+			path:            "/clusters/staging/namespaces",
+			expectedCode:    http.StatusOK,
+			expectedContent: `{"namespaces":["apps","default"]}`,
+		},
+		{
+			name:            "namespace",
+			method:          http.MethodGet,
+			path:            "/clusters/staging/namespace",
+			expectedCode:    http.StatusOK,
+			expectedContent: `{"namespace":"apps"}`,
+		},
+		{
+			name:         "unknown cluster namespace",
+			method:       http.MethodGet,
+			path:         "/clusters/prod/namespace",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:            "search",
+			method:          http.MethodGet,
+			path:            "/clusters/staging/search?q=web",
+			expectedCode:    http.StatusOK,
+			expectedContent: `{"results":[]}`,
+		},
+		{
+			name:            "port forwards",
+			method:          http.MethodGet,
+			path:            "/clusters/staging/port-forwards",
+			expectedCode:    http.StatusOK,
+			expectedContent: `{"portForwards":[]}`,
+		},
+		{
+			name:         "unknown cluster port forwards",
+			method:       http.MethodGet,
+			path:         "/clusters/prod/port-forwards",
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a, _, _ := newClustersTestAPI(t)
+
+			r := httptest.NewRequest(tc.method, tc.path, bytes.NewBufferString(tc.body))
+			w := httptest.NewRecorder()
+			a.Handler().ServeHTTP(w, r)
+
+			require.Equal(t, tc.expectedCode, w.Code, w.Body.String())
+			if tc.expectedContent == "" {
+				return
+			}
+
+			if json.Valid([]byte(tc.expectedContent)) {
+				assert.JSONEq(t, tc.expectedContent, w.Body.String())
+				return
+			}
+			assert.Equal(t, tc.expectedContent, w.Body.String())
+		})
+	}
+}
+
+func TestAPI_clusterNamespaceUpdate(t *testing.T) {
+	a, stagingManager, _ := newClustersTestAPI(t)
+
+	r := httptest.NewRequest(http.MethodPost, "/clusters/staging/namespace", bytes.NewBufferString(`{"namespace":"default"}`))
+	w := httptest.NewRecorder()
+	a.Handler().ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+	assert.Equal(t, "default", stagingManager.GetNamespace())
+	assert.Equal(t, "default", a.moduleManager.GetNamespace())
+}
+
+func TestAPI_clusterPortForwards(t *testing.T) {
+	a, _, stagingPortForwarder := newClustersTestAPI(t)
+	handler := a.Handler()
+
+	r := httptest.NewRequest(http.MethodPost, "/clusters/staging/port-forwards", bytes.NewBufferString(`{"name":"web","port":80}`))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	forwards := stagingPortForwarder.List()
+	require.Len(t, forwards, 1)
+	assert.Equal(t, "apps", forwards[0].Spec.Namespace)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/port-forwards", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"portForwards":[]}`, w.Body.String())
+}
+
+func TestAPI_clusterNavigation(t *testing.T) {
+	a, _, _ := newClustersTestAPI(t)
+
+	sections, err := a.navigationSections()
+	require.NoError(t, err)
+
+	expected := []*apt.Navigation{
+		{Title: "module", Path: "/content/module"},
+		{
+			Title: "staging",
+			Path:  "/clusters/staging/",
+			Children: []*apt.Navigation{
+				{Title: "staging-module", Path: "/clusters/staging/content/staging-module"},
+			},
+		},
+	}
+	assert.Equal(t, expected, sections)
+}
+
+func Test_clusterID(t *testing.T) {
+	cases := []struct {
+		name     string
+		context  string
+		expected string
+	}{
+		{
+			name:     "safe name",
+			context:  "staging",
+			expected: "staging",
+		},
+		{
+			name:     "eks arn",
+			context:  "arn:aws:eks:us-west-2:123456789012:cluster/prod",
+			expected: "arn-aws-eks-us-west-2-123456789012-cluster-prod-bf1d4858",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, clusterID(tc.context))
+		})
+	}
+}
+
+func TestAPI_clusterRoutes_unsafe_name(t *testing.T) {
+	name := "arn:aws:eks:us-west-2:123456789012:cluster/prod"
+
+	m := modulefake.NewModule("module", log.NopLogger())
+	manager := modulefake.NewStubManager("default", []module.Module{m})
+
+	nsClient := fake.NewNamespaceClient([]string{"default"}, nil, "default")
+	a := New("/", nsClient, fake.ClusterInfo{}, manager, log.NopLogger(), telemetryClient,
+		WithCluster(name, manager, nil))
+	handler := a.Handler()
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/clusters", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var cr clustersResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&cr))
+	require.Len(t, cr.Clusters, 1)
+	assert.Equal(t, name, cr.Clusters[0].Name)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, cr.Clusters[0].Path+"/content/module/nested", nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "module", w.Body.String())
+}
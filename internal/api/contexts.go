@@ -0,0 +1,112 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/twosson/kubeapt/internal/cluster"
+	"github.com/twosson/kubeapt/internal/log"
+	"net/http"
+)
+
+type contextsResponse struct {
+	CurrentContext string            `json:"currentContext"`
+	Contexts       []cluster.Context `json:"contexts"`
+}
+
+type contextRequest struct {
+	Context string `json:"context,omitempty"`
+}
+
+// contexts lists the contexts in the kubeconfig and switches between them.
+type contexts struct {
+	contexts   cluster.ContextInterface
+	infoClient func() cluster.InfoInterface
+	switchFn   func(name string) error
+	logger     log.Logger
+}
+
+func newContexts(contextClient cluster.ContextInterface, infoClient func() cluster.InfoInterface, switchFn func(name string) error, logger log.Logger) *contexts {
+	return &contexts{
+		contexts:   contextClient,
+		infoClient: infoClient,
+		switchFn:   switchFn,
+		logger:     logger,
+	}
+}
+
+// available responds with an error if contexts are not configured.
+func (c *contexts) available(w http.ResponseWriter) bool {
+	if c.contexts == nil {
+		respondWithError(w, http.StatusServiceUnavailable, "switching contexts is not available")
+		return false
+	}
+
+	return true
+}
+
+func (c *contexts) list(w http.ResponseWriter, r *http.Request) {
+	if !c.available(w) {
+		return
+	}
+
+	list, err := c.contexts.Contexts()
+	if err != nil {
+		c.logger.Errorf("listing contexts: %v", err)
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	cr := &contextsResponse{
+		CurrentContext: c.infoClient().Context(),
+		Contexts:       list,
+	}
+	if cr.Contexts == nil {
+		cr.Contexts = []cluster.Context{}
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if err := json.NewEncoder(w).Encode(cr); err != nil {
+		c.logger.Errorf("encoding contexts: %v", err)
+	}
+}
+
+func (c *contexts) update(w http.ResponseWriter, r *http.Request) {
+	if !c.available(w) {
+		return
+	}
+
+	var cr contextRequest
+	if err := json.NewDecoder(r.Body).Decode(&cr); err != nil || cr.Context == "" {
+		respondWithError(w, http.StatusBadRequest, "unable to decode request")
+		return
+	}
+
+	list, err := c.contexts.Contexts()
+	if err != nil {
+		c.logger.Errorf("listing contexts: %v", err)
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if !hasContext(list, cr.Context) {
+		respondWithError(w, http.StatusNotFound, "context not found")
+		return
+	}
+
+	if err := c.switchFn(cr.Context); err != nil {
+		c.logger.Errorf("switching to context %q: %v", cr.Context, err)
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func hasContext(list []cluster.Context, name string) bool {
+	for _, ktx := range list {
+		if ktx.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
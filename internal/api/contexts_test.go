@@ -0,0 +1,179 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twosson/kubeapt/internal/cluster"
+	clusterfake "github.com/twosson/kubeapt/internal/cluster/fake"
+	"github.com/twosson/kubeapt/internal/log"
+	"github.com/twosson/kubeapt/internal/module"
+	modulefake "github.com/twosson/kubeapt/internal/module/fake"
+	"k8s.io/apimachinery/pkg/runtime"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newContextsTestAPI(t *testing.T, opts ...Option) (*API, *modulefake.StubManager, *clusterfake.Client) {
+	return newContextsTestAPIWithModule(t, modulefake.NewModule("module", log.NopLogger()), opts...)
+}
+
+func newContextsTestAPIWithModule(t *testing.T, m *modulefake.Module, opts ...Option) (*API, *modulefake.StubManager, *clusterfake.Client) {
+	manager := modulefake.NewStubManager("default", []module.Module{m})
+
+	prodClient, err := clusterfake.NewClient(runtime.NewScheme(), nil, nil)
+	require.NoError(t, err)
+	prodClient.FakeNamespace = clusterfake.NewNamespaceClient([]string{"apps"}, nil, "apps")
+	prodClient.FakeInfo = clusterfake.ClusterInfo{ContextVal: "prod", ClusterVal: "prod-cluster"}
+
+	contexts := &clusterfake.Contexts{
+		ContextList: []cluster.Context{
+			{Name: "prod", Cluster: "prod-cluster"},
+			{Name: "staging", Cluster: "staging-cluster"},
+		},
+		Clients: map[string]cluster.ClientInterface{
+			"prod": prodClient,
+		},
+	}
+
+	nsClient := clusterfake.NewNamespaceClient([]string{"default"}, nil, "default")
+	infoClient := clusterfake.ClusterInfo{ContextVal: "staging", ClusterVal: "staging-cluster"}
+
+	opts = append([]Option{WithContexts(contexts)}, opts...)
+	a := New("/api", nsClient, infoClient, manager, log.NopLogger(), telemetryClient, opts...)
+	require.NoError(t, a.RegisterModule(m))
+
+	return a, manager, prodClient
+}
+
+func TestAPI_contexts(t *testing.T) {
+	portForwarder := clusterfake.NewPortForwarder(nil)
+	a, manager, prodClient := newContextsTestAPI(t, WithPortForwarder(portForwarder))
+	handler := a.Handler()
+
+	_, err := portForwarder.Start(cluster.PortForwardSpec{Name: "pod", Port: 80})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/contexts", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var cr contextsResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&cr))
+	assert.Equal(t, "staging", cr.CurrentContext)
+	assert.Len(t, cr.Contexts, 2)
+
+	body := bytes.NewBufferString(`{"context":"prod"}`)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/contexts", body))
+	require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+
+	assert.Equal(t, prodClient, manager.ClusterClient())
+	assert.Equal(t, "apps", manager.GetNamespace())
+	assert.Empty(t, portForwarder.List())
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/cluster-info", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var info clusterInfoResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&info))
+	assert.Equal(t, "prod", info.Context)
+	assert.Equal(t, "prod-cluster", info.Cluster)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/namespaces", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var nr namespacesResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&nr))
+	assert.Equal(t, []string{"apps"}, nr.Namespaces)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/content/module/", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestAPI_contexts_errors(t *testing.T) {
+	cases := []struct {
+		name         string
+		body         string
+		switchErr    error
+		expectedCode int
+	}{
+		{
+			name:         "invalid request",
+			body:         `{`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "missing context",
+			body:         `{}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "unknown context",
+			body:         `{"context":"dev"}`,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "no client for context",
+			body:         `{"context":"staging"}`,
+			expectedCode: http.StatusInternalServerError,
+		},
+		{
+			name:         "switch failed",
+			body:         `{"context":"prod"}`,
+			switchErr:    errors.New("failed"),
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a, manager, _ := newContextsTestAPI(t)
+			manager.SetSwitchClusterErr(tc.switchErr)
+
+			w := httptest.NewRecorder()
+			a.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/contexts", bytes.NewBufferString(tc.body)))
+			assert.Equal(t, tc.expectedCode, w.Code)
+			assert.Equal(t, "default", manager.GetNamespace())
+		})
+	}
+}
+
+func TestAPI_contexts_register_failed(t *testing.T) {
+	m := modulefake.NewModule("module", log.NopLogger())
+	a, manager, _ := newContextsTestAPIWithModule(t, m)
+	handler := a.Handler()
+
+	m.SetNavigationErr(errors.New("failed"))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/contexts", bytes.NewBufferString(`{"context":"prod"}`)))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	assert.Nil(t, manager.ClusterClient())
+	assert.Equal(t, "default", manager.GetNamespace())
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/cluster-info", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var info clusterInfoResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&info))
+	assert.Equal(t, "staging", info.Context)
+}
+
+func TestAPI_contexts_unavailable(t *testing.T) {
+	manager := modulefake.NewStubManager("default", nil)
+	nsClient := clusterfake.NewNamespaceClient([]string{"default"}, nil, "default")
+	a := New("/api", nsClient, clusterfake.ClusterInfo{}, manager, log.NopLogger(), telemetryClient)
+
+	w := httptest.NewRecorder()
+	a.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/contexts", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/twosson/kubeapt/internal/log"
+	"github.com/twosson/kubeapt/internal/module"
+	"net/http"
+)
+
+// events streams module manager events to clients as server sent events.
+// The event name is the event type, and the data is the event as JSON.
+type events struct {
+	moduleManager module.ManagerInterface
+	logger        log.Logger
+}
+
+var _ http.Handler = (*events)(nil)
+
+func newEvents(moduleManager module.ManagerInterface, logger log.Logger) *events {
+	return &events{
+		moduleManager: moduleManager,
+		logger:        logger,
+	}
+}
+
+func (e *events) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		respondWithError(w, http.StatusInternalServerError, "server sent events are unsupported")
+		return
+	}
+
+	// Subscribe before responding, so clients receive every event which
+	// happens after the response starts.
+	ch := e.moduleManager.Events(r.Context())
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for event := range ch {
+		data, err := json.Marshal(&event)
+		if err != nil {
+			e.logger.Errorf("encoding event: %v", err)
+			continue
+		}
+
+		if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data); err != nil {
+			e.logger.Errorf("writing event: %v", err)
+			return
+		}
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"bufio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twosson/kubeapt/internal/log"
+	"github.com/twosson/kubeapt/internal/module"
+	modulefake "github.com/twosson/kubeapt/internal/module/fake"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_events(t *testing.T) {
+	manager := modulefake.NewStubManager("default", []module.Module{})
+
+	ts := httptest.NewServer(newEvents(manager, log.NopLogger()))
+	defer ts.Close()
+
+	res, err := http.Get(ts.URL)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+
+	manager.SetNamespace("other")
+
+	reader := bufio.NewReader(res.Body)
+
+	line, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "event: namespaceChanged\n", line)
+
+	line, err = reader.ReadString('\n')
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"namespaceChanged","namespace":"other","previousNamespace":"default"}`, line[len("data: "):])
+}
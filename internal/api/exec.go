@@ -0,0 +1,360 @@
+package api
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/twosson/kubeapt/internal/log"
+	"github.com/twosson/kubeapt/internal/module"
+	"golang.org/x/net/websocket"
+	"io"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	execPath = "/exec/namespaces/{namespace}/pods/{pod}"
+
+	// execTeardownTimeout is how long closing the exec service waits for
+	// sessions to exit.
+	execTeardownTimeout = 5 * time.Second
+)
+
+var defaultExecCommand = []string{"/bin/sh"}
+
+// Exec message types.
+const (
+	execMessageStdin  = "stdin"
+	execMessageResize = "resize"
+	execMessageStdout = "stdout"
+	execMessageStderr = "stderr"
+	execMessageExit   = "exit"
+	execMessageError  = "error"
+)
+
+// execMessage is a message sent over an exec websocket. Clients send stdin
+// and resize messages. The server sends stdout, stderr, and a final exit or
+// error message before closing the connection.
+type execMessage struct {
+	Type   string `json:"type"`
+	Data   string `json:"data,omitempty"`
+	Width  uint16 `json:"width,omitempty"`
+	Height uint16 `json:"height,omitempty"`
+}
+
+// executorFactory creates an executor for an exec request.
+type executorFactory func(config *rest.Config, method string, u *url.URL) (remotecommand.Executor, error)
+
+// execService bridges websocket connections to the pods/exec subresource.
+type execService struct {
+	moduleManager   module.ManagerInterface
+	allowedCommands []string
+	newExecutor     executorFactory
+	logger          log.Logger
+
+	mu       sync.Mutex
+	closed   bool
+	sessions map[*execSession]bool
+	wg       sync.WaitGroup
+}
+
+var _ http.Handler = (*execService)(nil)
+
+func newExecService(moduleManager module.ManagerInterface, allowedCommands []string, logger log.Logger) *execService {
+	return &execService{
+		moduleManager:   moduleManager,
+		allowedCommands: allowedCommands,
+		newExecutor:     remotecommand.NewSPDYExecutor,
+		logger:          logger,
+		sessions:        make(map[*execSession]bool),
+	}
+}
+
+func (s *execService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	namespace := vars["namespace"]
+	pod := vars["pod"]
+
+	query := r.URL.Query()
+
+	command := query["command"]
+	if len(command) == 0 {
+		command = defaultExecCommand
+	}
+
+	if !s.isAllowed(command) {
+		respondWithError(w, http.StatusForbidden, "command is not allowed")
+		return
+	}
+
+	tty := true
+	if v := query.Get("tty"); v == "false" {
+		tty = false
+	}
+
+	options := &corev1.PodExecOptions{
+		Container: query.Get("container"),
+		Command:   command,
+		Stdin:     true,
+		Stdout:    true,
+		Stderr:    !tty,
+		TTY:       tty,
+	}
+
+	executor, err := s.executor(namespace, pod, options)
+	if err != nil {
+		s.logger.Errorf("creating executor for pod %s/%s: %v", namespace, pod, err)
+		respondWithError(w, http.StatusInternalServerError, "unable to create executor")
+		return
+	}
+
+	server := websocket.Server{
+		Handshake: checkSameOrigin,
+		Handler: func(ws *websocket.Conn) {
+			s.serve(ws, executor, tty)
+		},
+	}
+
+	server.ServeHTTP(w, r)
+}
+
+func (s *execService) isAllowed(command []string) bool {
+	if len(s.allowedCommands) == 0 {
+		return true
+	}
+
+	for _, allowed := range s.allowedCommands {
+		if command[0] == allowed {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *execService) executor(namespace, pod string, options *corev1.PodExecOptions) (remotecommand.Executor, error) {
+	clusterClient := s.moduleManager.ClusterClient()
+	if clusterClient == nil {
+		return nil, errors.New("cluster client is not available")
+	}
+
+	kubeClient, err := clusterClient.KubernetesClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "creating kubernetes client")
+	}
+
+	req := kubeClient.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(pod).
+		SubResource("exec").
+		VersionedParams(options, scheme.ParameterCodec)
+
+	return s.newExecutor(clusterClient.RESTConfig(), http.MethodPost, req.URL())
+}
+
+// serve runs an exec session until the remote command exits, the client
+// disconnects, or the service is closed.
+func (s *execService) serve(ws *websocket.Conn, executor remotecommand.Executor, tty bool) {
+	session := newExecSession(ws)
+
+	if !s.add(session) {
+		session.send(execMessage{Type: execMessageError, Data: "exec service is shutting down"})
+		ws.Close()
+		return
+	}
+	defer s.remove(session)
+
+	go session.receive()
+
+	streamOptions := remotecommand.StreamOptions{
+		Stdin:  session.stdin,
+		Stdout: session.writer(execMessageStdout),
+		Tty:    tty,
+	}
+	if tty {
+		streamOptions.TerminalSizeQueue = session
+	} else {
+		streamOptions.Stderr = session.writer(execMessageStderr)
+	}
+
+	if err := executor.Stream(streamOptions); err != nil {
+		session.send(execMessage{Type: execMessageError, Data: err.Error()})
+	} else {
+		session.send(execMessage{Type: execMessageExit})
+	}
+
+	session.close()
+}
+
+func (s *execService) add(session *execSession) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return false
+	}
+
+	s.sessions[session] = true
+	s.wg.Add(1)
+	return true
+}
+
+func (s *execService) remove(session *execSession) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.sessions, session)
+	s.wg.Done()
+}
+
+// close closes all running sessions and rejects new ones. It waits for
+// sessions to exit for at most execTeardownTimeout.
+func (s *execService) close() {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+
+	s.closeSessions()
+}
+
+// closeSessions closes all running sessions, e.g. when the dashboard
+// switches to another cluster. It waits for sessions to exit for at most
+// execTeardownTimeout.
+func (s *execService) closeSessions() {
+	s.mu.Lock()
+	for session := range s.sessions {
+		session.close()
+	}
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(execTeardownTimeout):
+		s.logger.Warnf("timed out waiting for exec sessions to exit")
+	}
+}
+
+// execSession is a single websocket exec session.
+type execSession struct {
+	ws     *websocket.Conn
+	stdin  *io.PipeReader
+	stdinW *io.PipeWriter
+	sizeCh chan remotecommand.TerminalSize
+
+	sendMu    sync.Mutex
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+var _ remotecommand.TerminalSizeQueue = (*execSession)(nil)
+
+func newExecSession(ws *websocket.Conn) *execSession {
+	r, w := io.Pipe()
+
+	return &execSession{
+		ws:     ws,
+		stdin:  r,
+		stdinW: w,
+		sizeCh: make(chan remotecommand.TerminalSize, 1),
+		done:   make(chan struct{}),
+	}
+}
+
+// receive reads messages from the client until the connection is closed.
+// The session is closed when the client goes away.
+func (s *execSession) receive() {
+	defer s.close()
+
+	for {
+		var msg execMessage
+		if err := websocket.JSON.Receive(s.ws, &msg); err != nil {
+			return
+		}
+
+		switch msg.Type {
+		case execMessageStdin:
+			if _, err := s.stdinW.Write([]byte(msg.Data)); err != nil {
+				return
+			}
+		case execMessageResize:
+			s.resize(remotecommand.TerminalSize{Width: msg.Width, Height: msg.Height})
+		}
+	}
+}
+
+// resize queues a terminal size. Only the latest size is kept.
+func (s *execSession) resize(size remotecommand.TerminalSize) {
+	select {
+	case <-s.sizeCh:
+	default:
+	}
+
+	select {
+	case s.sizeCh <- size:
+	case <-s.done:
+	}
+}
+
+// Next returns the next terminal size. It returns nil when the session is
+// closed.
+func (s *execSession) Next() *remotecommand.TerminalSize {
+	select {
+	case size := <-s.sizeCh:
+		return &size
+	case <-s.done:
+		return nil
+	}
+}
+
+func (s *execSession) send(msg execMessage) error {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+
+	return websocket.JSON.Send(s.ws, msg)
+}
+
+func (s *execSession) writer(messageType string) io.Writer {
+	return &execWriter{session: s, messageType: messageType}
+}
+
+// close closes the websocket. This unblocks the remote command streams.
+func (s *execSession) close() {
+	s.closeOnce.Do(func() {
+		close(s.done)
+		s.stdinW.Close()
+		s.ws.Close()
+	})
+}
+
+// execWriter sends output from the remote command to the client.
+type execWriter struct {
+	session     *execSession
+	messageType string
+}
+
+func (w *execWriter) Write(p []byte) (int, error) {
+	if err := w.session.send(execMessage{Type: w.messageType, Data: string(p)}); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// checkSameOrigin rejects websocket connections from other origins. Browsers
+// don't apply CORS to websockets, so without this any site could exec into
+// pods using the dashboard's credentials.
+func checkSameOrigin(config *websocket.Config, r *http.Request) error {
+	return checkRequestOrigin(r)
+}
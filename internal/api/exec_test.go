@@ -0,0 +1,173 @@
+package api
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twosson/kubeapt/internal/cluster/fake"
+	"github.com/twosson/kubeapt/internal/log"
+	modulefake "github.com/twosson/kubeapt/internal/module/fake"
+	"golang.org/x/net/websocket"
+	"io"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// echoExecutor echoes stdin to stdout and records terminal sizes.
+type echoExecutor struct {
+	sizes chan remotecommand.TerminalSize
+}
+
+func (e *echoExecutor) Stream(options remotecommand.StreamOptions) error {
+	if options.TerminalSizeQueue != nil {
+		go func() {
+			for {
+				size := options.TerminalSizeQueue.Next()
+				if size == nil {
+					return
+				}
+				e.sizes <- *size
+			}
+		}()
+	}
+
+	_, err := io.Copy(options.Stdout, options.Stdin)
+	return err
+}
+
+type execTest struct {
+	ts       *httptest.Server
+	manager  *modulefake.StubManager
+	urls     chan *url.URL
+	executor *echoExecutor
+}
+
+func newExecTest(t *testing.T, opts ...Option) *execTest {
+	clusterClient, err := fake.NewClient(runtime.NewScheme(), nil, nil)
+	require.NoError(t, err)
+
+	kubeClient, err := kubernetes.NewForConfig(&rest.Config{Host: "https://cluster.example.com"})
+	require.NoError(t, err)
+	clusterClient.FakeKubernetes = kubeClient
+
+	manager := modulefake.NewStubManager("default", nil)
+	manager.SetClusterClient(clusterClient)
+
+	nsClient := fake.NewNamespaceClient([]string{"default"}, nil, "default")
+	srv := New("/", nsClient, fake.ClusterInfo{}, manager, log.NopLogger(), telemetryClient, opts...)
+
+	et := &execTest{
+		manager:  manager,
+		urls:     make(chan *url.URL, 1),
+		executor: &echoExecutor{sizes: make(chan remotecommand.TerminalSize, 1)},
+	}
+
+	srv.exec.newExecutor = func(config *rest.Config, method string, u *url.URL) (remotecommand.Executor, error) {
+		et.urls <- u
+		return et.executor, nil
+	}
+
+	et.ts = httptest.NewServer(srv.Handler())
+
+	return et
+}
+
+func (et *execTest) dial(t *testing.T, query string) (*websocket.Conn, error) {
+	u, err := url.Parse(et.ts.URL)
+	require.NoError(t, err)
+
+	origin := u.String()
+	u.Scheme = "ws"
+	u.Path = "/exec/namespaces/default/pods/pod"
+	u.RawQuery = query
+
+	return websocket.Dial(u.String(), "", origin)
+}
+
+func TestExec(t *testing.T) {
+	et := newExecTest(t)
+	defer et.ts.Close()
+
+	ws, err := et.dial(t, "container=app&command=sh")
+	require.NoError(t, err)
+	defer ws.Close()
+
+	u := <-et.urls
+	assert.Equal(t, "/api/v1/namespaces/default/pods/pod/exec", u.Path)
+	assert.Equal(t, "app", u.Query().Get("container"))
+	assert.Equal(t, []string{"sh"}, u.Query()["command"])
+	assert.Equal(t, "true", u.Query().Get("tty"))
+
+	require.NoError(t, websocket.JSON.Send(ws, execMessage{Type: execMessageStdin, Data: "ls\n"}))
+
+	var msg execMessage
+	require.NoError(t, websocket.JSON.Receive(ws, &msg))
+	assert.Equal(t, execMessage{Type: execMessageStdout, Data: "ls\n"}, msg)
+
+	require.NoError(t, websocket.JSON.Send(ws, execMessage{Type: execMessageResize, Width: 80, Height: 24}))
+	assert.Equal(t, remotecommand.TerminalSize{Width: 80, Height: 24}, <-et.executor.sizes)
+}
+
+func TestExec_allowed_commands(t *testing.T) {
+	et := newExecTest(t, WithExecCommands("sh"))
+	defer et.ts.Close()
+
+	u := strings.Replace(et.ts.URL, "http", "ws", 1) + "/exec/namespaces/default/pods/pod?command=rm"
+	_, err := websocket.Dial(u, "", et.ts.URL)
+	require.Error(t, err)
+
+	res, err := http.Get(et.ts.URL + "/exec/namespaces/default/pods/pod?command=rm")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+
+	ws, err := et.dial(t, "command=sh")
+	require.NoError(t, err)
+	ws.Close()
+}
+
+func TestExec_cross_origin(t *testing.T) {
+	et := newExecTest(t)
+	defer et.ts.Close()
+
+	u := strings.Replace(et.ts.URL, "http", "ws", 1) + "/exec/namespaces/default/pods/pod"
+	_, err := websocket.Dial(u, "", "http://evil.example.com")
+	require.Error(t, err)
+}
+
+func TestExec_unload(t *testing.T) {
+	et := newExecTest(t)
+	defer et.ts.Close()
+
+	ws, err := et.dial(t, "")
+	require.NoError(t, err)
+	defer ws.Close()
+
+	// Wait for the session to start.
+	require.NoError(t, websocket.JSON.Send(ws, execMessage{Type: execMessageStdin, Data: "x"}))
+	var msg execMessage
+	require.NoError(t, websocket.JSON.Receive(ws, &msg))
+
+	et.manager.Unload()
+
+	// The session is closed by the server.
+	for {
+		if err := websocket.JSON.Receive(ws, &msg); err != nil {
+			break
+		}
+	}
+
+	// New sessions are rejected.
+	ws2, err := et.dial(t, "")
+	require.NoError(t, err)
+	defer ws2.Close()
+
+	require.NoError(t, websocket.JSON.Receive(ws2, &msg))
+	assert.Equal(t, execMessageError, msg.Type)
+}
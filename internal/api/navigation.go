@@ -11,14 +11,18 @@ type navigationResponse struct {
 	Sections []*apt.Navigation `json:"sections,omitempty"`
 }
 
+// navigationSectionsFunc returns navigation sections. It is called for every
+// request, so sections can change while dash is running.
+type navigationSectionsFunc func() ([]*apt.Navigation, error)
+
 type navigation struct {
-	sections []*apt.Navigation
+	sections navigationSectionsFunc
 	logger   log.Logger
 }
 
 var _ http.Handler = (*navigation)(nil)
 
-func newNavigation(sections []*apt.Navigation, logger log.Logger) *navigation {
+func newNavigation(sections navigationSectionsFunc, logger log.Logger) *navigation {
 	return &navigation{
 		sections: sections,
 		logger:   logger,
@@ -26,8 +30,17 @@ func newNavigation(sections []*apt.Navigation, logger log.Logger) *navigation {
 }
 
 func (n *navigation) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	sections, err := n.sections()
+	if err != nil {
+		n.logger.Errorf("generating navigation: %v", err)
+		if err := respondWithError(w, http.StatusInternalServerError, "unable to generate navigation"); err != nil {
+			n.logger.Errorf("responding with error: %v", err)
+		}
+		return
+	}
+
 	nr := navigationResponse{
-		Sections: n.sections,
+		Sections: sections,
 	}
 
 	if err := json.NewEncoder(w).Encode(nr); err != nil {
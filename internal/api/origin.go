@@ -0,0 +1,46 @@
+package api
+
+import (
+	"github.com/pkg/errors"
+	"net/http"
+	"net/url"
+)
+
+// sameOriginMiddleware rejects requests which change state if they were
+// sent by a page from another origin. The dashboard acts with the user's
+// cluster credentials, so without this any site could apply manifests,
+// delete objects, or port forward by posting to it.
+func sameOriginMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+		default:
+			if err := checkRequestOrigin(r); err != nil {
+				respondWithError(w, http.StatusForbidden, err.Error())
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// checkRequestOrigin returns an error if a request's origin isn't the
+// dashboard. Requests without an origin weren't sent by a browser page.
+func checkRequestOrigin(r *http.Request) error {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return nil
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil {
+		return errors.Wrap(err, "parsing origin")
+	}
+
+	if u.Host != r.Host {
+		return errors.Errorf("origin %q is not allowed", origin)
+	}
+
+	return nil
+}
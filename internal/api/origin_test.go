@@ -0,0 +1,62 @@
+package api
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_sameOriginMiddleware(t *testing.T) {
+	cases := []struct {
+		name         string
+		method       string
+		origin       string
+		expectedCode int
+	}{
+		{
+			name:         "same origin",
+			method:       http.MethodPost,
+			origin:       "http://127.0.0.1:7777",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "no origin",
+			method:       http.MethodPost,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "other origin",
+			method:       http.MethodPost,
+			origin:       "http://evil.example.com",
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "other origin delete",
+			method:       http.MethodDelete,
+			origin:       "http://evil.example.com",
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "other origin get",
+			method:       http.MethodGet,
+			origin:       "http://evil.example.com",
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := sameOriginMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+			r := httptest.NewRequest(tc.method, "http://127.0.0.1:7777/api/v1/apply", nil)
+			if tc.origin != "" {
+				r.Header.Set("Origin", tc.origin)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedCode, w.Code)
+		})
+	}
+}
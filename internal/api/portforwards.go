@@ -0,0 +1,133 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"github.com/twosson/kubeapt/internal/cluster"
+	"github.com/twosson/kubeapt/internal/log"
+	"net/http"
+)
+
+type portForwardsResponse struct {
+	PortForwards []cluster.PortForwardState `json:"portForwards"`
+}
+
+// portForwards serves port forwards. Forwards are started in the current
+// namespace unless the spec has a namespace.
+type portForwards struct {
+	portForwarder    cluster.PortForwardInterface
+	currentNamespace func() string
+	logger           log.Logger
+}
+
+func newPortForwards(portForwarder cluster.PortForwardInterface, currentNamespace func() string, logger log.Logger) *portForwards {
+	return &portForwards{
+		portForwarder:    portForwarder,
+		currentNamespace: currentNamespace,
+		logger:           logger,
+	}
+}
+
+// available responds with an error if port forwarding is not configured.
+func (p *portForwards) available(w http.ResponseWriter) bool {
+	if p.portForwarder == nil {
+		respondWithError(w, http.StatusServiceUnavailable, "port forwarding is not available")
+		return false
+	}
+
+	return true
+}
+
+func (p *portForwards) list(w http.ResponseWriter, r *http.Request) {
+	if !p.available(w) {
+		return
+	}
+
+	pr := &portForwardsResponse{
+		PortForwards: p.portForwarder.List(),
+	}
+	if pr.PortForwards == nil {
+		pr.PortForwards = []cluster.PortForwardState{}
+	}
+
+	if err := json.NewEncoder(w).Encode(pr); err != nil {
+		p.logger.Errorf("encoding port forwards: %v", err)
+	}
+}
+
+func (p *portForwards) create(w http.ResponseWriter, r *http.Request) {
+	if !p.available(w) {
+		return
+	}
+
+	var spec cluster.PortForwardSpec
+	if err := json.NewDecoder(r.Body).Decode(&spec); err != nil {
+		respondWithError(w, http.StatusBadRequest, "unable to decode request")
+		return
+	}
+
+	if spec.Name == "" || spec.Port == 0 {
+		respondWithError(w, http.StatusBadRequest, "name and port are required")
+		return
+	}
+
+	if spec.Namespace == "" {
+		spec.Namespace = p.currentNamespace()
+	}
+
+	state, err := p.portForwarder.Start(spec)
+	if err != nil {
+		switch {
+		case cluster.IsPortForwardNotFound(err):
+			respondWithError(w, http.StatusNotFound, err.Error())
+		case cluster.IsPortForwardInvalid(err):
+			respondWithError(w, http.StatusBadRequest, err.Error())
+		default:
+			p.logger.Errorf("starting port forward: %v", err)
+			respondWithError(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(state); err != nil {
+		p.logger.Errorf("encoding port forward: %v", err)
+	}
+}
+
+func (p *portForwards) read(w http.ResponseWriter, r *http.Request) {
+	if !p.available(w) {
+		return
+	}
+
+	state, ok := p.portForwarder.Get(mux.Vars(r)["id"])
+	if !ok {
+		respondWithError(w, http.StatusNotFound, "port forward not found")
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(state); err != nil {
+		p.logger.Errorf("encoding port forward: %v", err)
+	}
+}
+
+func (p *portForwards) delete(w http.ResponseWriter, r *http.Request) {
+	if !p.available(w) {
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+	if _, ok := p.portForwarder.Get(id); !ok {
+		respondWithError(w, http.StatusNotFound, "port forward not found")
+		return
+	}
+
+	if err := p.portForwarder.Stop(id); err != nil {
+		p.logger.Errorf("stopping port forward: %v", err)
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
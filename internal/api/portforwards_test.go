@@ -0,0 +1,136 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twosson/kubeapt/internal/cluster"
+	clusterfake "github.com/twosson/kubeapt/internal/cluster/fake"
+	"github.com/twosson/kubeapt/internal/log"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newPortForwardsRouter(portForwarder cluster.PortForwardInterface) *mux.Router {
+	currentNamespace := func() string { return "current" }
+	p := newPortForwards(portForwarder, currentNamespace, log.NopLogger())
+
+	router := mux.NewRouter()
+	router.HandleFunc("/port-forwards", p.list).Methods(http.MethodGet)
+	router.HandleFunc("/port-forwards", p.create).Methods(http.MethodPost)
+	router.HandleFunc("/port-forwards/{id}", p.read).Methods(http.MethodGet)
+	router.HandleFunc("/port-forwards/{id}", p.delete).Methods(http.MethodDelete)
+
+	return router
+}
+
+func Test_portForwards(t *testing.T) {
+	portForwarder := clusterfake.NewPortForwarder(nil)
+	router := newPortForwardsRouter(portForwarder)
+
+	spec := cluster.PortForwardSpec{Namespace: "default", Kind: cluster.PortForwardKindService, Name: "svc", Port: 80}
+	data, err := json.Marshal(spec)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/port-forwards", bytes.NewReader(data)))
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var state cluster.PortForwardState
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&state))
+	assert.Equal(t, "1", state.ID)
+	assert.Equal(t, spec, state.Spec)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/port-forwards", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var pr portForwardsResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&pr))
+	require.Len(t, pr.PortForwards, 1)
+	assert.Equal(t, "1", pr.PortForwards[0].ID)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/port-forwards/1", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/port-forwards/1", nil))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/port-forwards/1", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	assert.Empty(t, portForwarder.List())
+}
+
+func Test_portForwards_current_namespace(t *testing.T) {
+	portForwarder := clusterfake.NewPortForwarder(nil)
+	router := newPortForwardsRouter(portForwarder)
+
+	body := `{"kind":"Pod","name":"pod","port":80}`
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/port-forwards", bytes.NewBufferString(body)))
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var state cluster.PortForwardState
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&state))
+	assert.Equal(t, "current", state.Spec.Namespace)
+}
+
+func Test_portForwards_errors(t *testing.T) {
+	cases := []struct {
+		name          string
+		portForwarder cluster.PortForwardInterface
+		body          string
+		expectedCode  int
+	}{
+		{
+			name:          "invalid body",
+			portForwarder: clusterfake.NewPortForwarder(nil),
+			body:          "{",
+			expectedCode:  http.StatusBadRequest,
+		},
+		{
+			name:          "missing port",
+			portForwarder: clusterfake.NewPortForwarder(nil),
+			body:          `{"kind":"Pod","name":"pod"}`,
+			expectedCode:  http.StatusBadRequest,
+		},
+		{
+			name:          "not found",
+			portForwarder: clusterfake.NewPortForwarder(apierrors.NewNotFound(schema.GroupResource{Resource: "pods"}, "pod")),
+			body:          `{"kind":"Pod","name":"pod","port":80}`,
+			expectedCode:  http.StatusNotFound,
+		},
+		{
+			name:          "start fails",
+			portForwarder: clusterfake.NewPortForwarder(errors.New("failed")),
+			body:          `{"kind":"Pod","name":"pod","port":80}`,
+			expectedCode:  http.StatusInternalServerError,
+		},
+		{
+			name:         "not available",
+			body:         `{"kind":"Pod","name":"pod","port":80}`,
+			expectedCode: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := newPortForwardsRouter(tc.portForwarder)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/port-forwards", bytes.NewBufferString(tc.body)))
+			assert.Equal(t, tc.expectedCode, w.Code)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/twosson/kubeapt/internal/apt"
+	"github.com/twosson/kubeapt/internal/log"
+	"github.com/twosson/kubeapt/internal/module"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// defaultSearchLimit is the number of results returned when a search
+	// doesn't set a limit.
+	defaultSearchLimit = 20
+
+	// maxSearchLimit is the largest number of results a search can return.
+	maxSearchLimit = 100
+)
+
+// search finds objects across the modules which can search.
+type search struct {
+	prefix        string
+	moduleManager module.ManagerInterface
+	logger        log.Logger
+}
+
+var _ http.Handler = (*search)(nil)
+
+func newSearch(prefix string, moduleManager module.ManagerInterface, logger log.Logger) *search {
+	return &search{
+		prefix:        prefix,
+		moduleManager: moduleManager,
+		logger:        logger,
+	}
+}
+
+type searchResponse struct {
+	Results []apt.SearchResult `json:"results"`
+}
+
+// ServeHTTP searches for the q query parameter. Results are limited to the
+// namespace parameter, or the current namespace, and are ordered by score.
+// The limit parameter sets the number of results, and warm=true loads
+// commonly used kinds before searching.
+func (s *search) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	sq := apt.SearchQuery{
+		Query:     strings.TrimSpace(query.Get("q")),
+		Namespace: query.Get("namespace"),
+		Limit:     defaultSearchLimit,
+	}
+
+	if sq.Query == "" {
+		respondWithError(w, http.StatusBadRequest, "search query is required")
+		return
+	}
+
+	if sq.Namespace == "" {
+		sq.Namespace = s.moduleManager.GetNamespace()
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		i, err := strconv.Atoi(limit)
+		if err != nil || i < 1 || i > maxSearchLimit {
+			respondWithError(w, http.StatusBadRequest, "limit must be between 1 and "+strconv.Itoa(maxSearchLimit))
+			return
+		}
+		sq.Limit = i
+	}
+
+	if warm := query.Get("warm"); warm != "" {
+		b, err := strconv.ParseBool(warm)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "warm must be true or false")
+			return
+		}
+		sq.Warm = b
+	}
+
+	results := []apt.SearchResult{}
+	for _, m := range s.moduleManager.Modules() {
+		searcher, ok := m.(module.Searcher)
+		if !ok {
+			continue
+		}
+
+		moduleQuery := sq
+		moduleQuery.Root = path.Join(s.prefix, moduleContentPath(m))
+
+		moduleResults, err := searcher.Search(r.Context(), moduleQuery)
+		if err != nil {
+			s.logger.With("module", m.Name()).Errorf("searching: %v", err)
+			continue
+		}
+
+		results = append(results, moduleResults...)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	if len(results) > sq.Limit {
+		results = results[:sq.Limit]
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if err := json.NewEncoder(w).Encode(&searchResponse{Results: results}); err != nil {
+		s.logger.Errorf("encoding search response: %v", err)
+	}
+}
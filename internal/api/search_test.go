@@ -0,0 +1,126 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twosson/kubeapt/internal/apt"
+	"github.com/twosson/kubeapt/internal/log"
+	"github.com/twosson/kubeapt/internal/module"
+	modulefake "github.com/twosson/kubeapt/internal/module/fake"
+	"github.com/twosson/kubeapt/pkg/content"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type searchModule struct {
+	*modulefake.Module
+	results []apt.SearchResult
+	queries []apt.SearchQuery
+}
+
+func (m *searchModule) Search(ctx context.Context, query apt.SearchQuery) ([]apt.SearchResult, error) {
+	m.queries = append(m.queries, query)
+	return m.results, nil
+}
+
+func searchResult(name string, score int) apt.SearchResult {
+	return apt.SearchResult{
+		Link:       content.NewLinkText(name, "/content/overview/workloads/pods/"+name),
+		APIVersion: "v1",
+		Kind:       "Pod",
+		Namespace:  "default",
+		Matches:    []string{"name"},
+		Score:      score,
+	}
+}
+
+func Test_search(t *testing.T) {
+	cases := []struct {
+		name          string
+		query         string
+		expectedCode  int
+		expectedNames []string
+		expectedQuery apt.SearchQuery
+	}{
+		{
+			name:          "results from every module by score",
+			query:         "q=web",
+			expectedCode:  http.StatusOK,
+			expectedNames: []string{"web-2", "web-1", "web-3"},
+			expectedQuery: apt.SearchQuery{Query: "web", Namespace: "default", Limit: defaultSearchLimit},
+		},
+		{
+			name:          "limit and options",
+			query:         "q=web&namespace=other&limit=2&warm=true",
+			expectedCode:  http.StatusOK,
+			expectedNames: []string{"web-2", "web-1"},
+			expectedQuery: apt.SearchQuery{Query: "web", Namespace: "other", Limit: 2, Warm: true},
+		},
+		{
+			name:         "missing query",
+			query:        "q=",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid limit",
+			query:        "q=web&limit=1000",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid warm",
+			query:        "q=web&warm=maybe",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			m1 := &searchModule{
+				Module:  modulefake.NewModule("one", log.NopLogger()),
+				results: []apt.SearchResult{searchResult("web-1", 80), searchResult("web-3", 40)},
+			}
+			m2 := &searchModule{
+				Module:  modulefake.NewModule("two", log.NopLogger()),
+				results: []apt.SearchResult{searchResult("web-2", 100)},
+			}
+			other := modulefake.NewModule("other", log.NopLogger())
+
+			manager := modulefake.NewStubManager("default", []module.Module{m1, other, m2})
+
+			ts := httptest.NewServer(newSearch("/api/v1", manager, log.NopLogger()))
+			defer ts.Close()
+
+			res, err := http.Get(ts.URL + "?" + tc.query)
+			require.NoError(t, err)
+			defer res.Body.Close()
+
+			require.Equal(t, tc.expectedCode, res.StatusCode)
+			if tc.expectedCode != http.StatusOK {
+				return
+			}
+
+			var sr struct {
+				Results []struct {
+					Link  map[string]interface{} `json:"link"`
+					Score int                    `json:"score"`
+				} `json:"results"`
+			}
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&sr))
+
+			var names []string
+			for _, result := range sr.Results {
+				names = append(names, result.Link["text"].(string))
+			}
+			assert.Equal(t, tc.expectedNames, names)
+
+			// Each module is searched with the root its handler is served from.
+			m1Query, m2Query := tc.expectedQuery, tc.expectedQuery
+			m1Query.Root, m2Query.Root = "/api/v1/content/one", "/api/v1/content/two"
+			assert.Equal(t, []apt.SearchQuery{m1Query}, m1.queries)
+			assert.Equal(t, []apt.SearchQuery{m2Query}, m2.queries)
+		})
+	}
+}
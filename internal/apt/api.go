@@ -0,0 +1,48 @@
+package apt
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+	"net/http"
+	"strings"
+)
+
+// APIPathPrefix is the path the dashboard API is served from. Module
+// handlers are served below it.
+const APIPathPrefix = "/api/v1"
+
+// LinkRoot returns the path links to a module's content start with, given
+// the root its handler is served from. The dashboard requests the content
+// for a link from the API, so links don't include the API prefix.
+func LinkRoot(root string) string {
+	return strings.TrimPrefix(root, APIPathPrefix)
+}
+
+type errorMessage struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type errorResponse struct {
+	Error errorMessage `json:"error,omitempty"`
+}
+
+// RespondWithError responds with an error in the format the dashboard
+// expects from the API.
+func RespondWithError(w http.ResponseWriter, code int, message string) error {
+	r := &errorResponse{
+		Error: errorMessage{
+			Code:    code,
+			Message: message,
+		},
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	w.WriteHeader(code)
+
+	if err := json.NewEncoder(w).Encode(r); err != nil {
+		return errors.Errorf("encoding response: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package apt
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLinkRoot(t *testing.T) {
+	assert.Equal(t, "/content/overview", LinkRoot("/api/v1/content/overview"))
+	assert.Equal(t, "/clusters/staging/content/overview", LinkRoot("/api/v1/clusters/staging/content/overview"))
+	assert.Equal(t, "/content/overview", LinkRoot("/content/overview"))
+}
+
+func TestRespondWithError(t *testing.T) {
+	w := httptest.NewRecorder()
+	require.NoError(t, RespondWithError(w, http.StatusNotFound, "not found"))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"error":{"code":404,"message":"not found"}}`, w.Body.String())
+}
@@ -0,0 +1,32 @@
+package apt
+
+import (
+	"github.com/twosson/kubeapt/pkg/content"
+)
+
+// SearchQuery is a query for objects.
+type SearchQuery struct {
+	// Query is the text to search for.
+	Query string
+	// Namespace limits results to a namespace. Cluster scoped objects are
+	// always included.
+	Namespace string
+	// Limit is the maximum number of results.
+	Limit int
+	// Warm loads commonly used kinds before searching, so objects which
+	// haven't been viewed yet are found.
+	Warm bool
+	// Root is the path the searched module's handler is served from.
+	// Result links start with its link root.
+	Root string
+}
+
+// SearchResult is an object found by a search.
+type SearchResult struct {
+	Link       *content.LinkText `json:"link"`
+	APIVersion string            `json:"apiVersion"`
+	Kind       string            `json:"kind"`
+	Namespace  string            `json:"namespace,omitempty"`
+	Matches    []string          `json:"matches"`
+	Score      int               `json:"score"`
+}
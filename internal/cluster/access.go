@@ -0,0 +1,172 @@
+package cluster
+
+import (
+	"github.com/pkg/errors"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/client-go/kubernetes"
+	"sync"
+	"time"
+)
+
+// accessCacheTTL is how long access decisions are reused. Role changes are
+// picked up once it expires.
+const accessCacheTTL = time.Minute
+
+// AccessInterface checks what the current user is allowed to do.
+type AccessInterface interface {
+	// Allowed reports whether the current user can perform an action.
+	Allowed(access ResourceAccess) (bool, error)
+}
+
+// ResourceAccess is an action on a kind of resource. The namespace is empty
+// for cluster scoped resources, or for actions across every namespace.
+type ResourceAccess struct {
+	Namespace string
+	Verb      string
+	Group     string
+	Resource  string
+}
+
+type accessDecision struct {
+	allowed bool
+	expires time.Time
+}
+
+type namespaceRules struct {
+	rules   []authorizationv1.ResourceRule
+	expires time.Time
+}
+
+// accessClient checks access with self subject reviews. The rules for a
+// namespace are retrieved with a single SelfSubjectRulesReview, and other
+// actions are checked with a SelfSubjectAccessReview. Results are cached.
+type accessClient struct {
+	client kubernetes.Interface
+	now    func() time.Time
+
+	mu        sync.Mutex
+	rules     map[string]namespaceRules
+	decisions map[ResourceAccess]accessDecision
+}
+
+var _ AccessInterface = (*accessClient)(nil)
+
+func newAccessClient(client kubernetes.Interface) *accessClient {
+	return &accessClient{
+		client:    client,
+		now:       time.Now,
+		rules:     make(map[string]namespaceRules),
+		decisions: make(map[ResourceAccess]accessDecision),
+	}
+}
+
+// Allowed reports whether the current user can perform an action.
+func (ac *accessClient) Allowed(access ResourceAccess) (bool, error) {
+	if access.Namespace != "" {
+		rules, ok, err := ac.namespaceRules(access.Namespace)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			return rulesAllow(rules, access), nil
+		}
+	}
+
+	ac.mu.Lock()
+	decision, ok := ac.decisions[access]
+	ac.mu.Unlock()
+	if ok && ac.now().Before(decision.expires) {
+		return decision.allowed, nil
+	}
+
+	review := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: access.Namespace,
+				Verb:      access.Verb,
+				Group:     access.Group,
+				Resource:  access.Resource,
+			},
+		},
+	}
+
+	review, err := ac.client.AuthorizationV1().SelfSubjectAccessReviews().Create(review)
+	if err != nil {
+		return false, errors.Wrapf(err, "reviewing access to %s %s", access.Verb, access.Resource)
+	}
+
+	ac.mu.Lock()
+	ac.decisions[access] = accessDecision{
+		allowed: review.Status.Allowed,
+		expires: ac.now().Add(accessCacheTTL),
+	}
+	ac.mu.Unlock()
+
+	return review.Status.Allowed, nil
+}
+
+// namespaceRules returns the rules for the current user in a namespace. It
+// returns false if the rules are incomplete, which happens when an
+// authorizer can't list rules.
+func (ac *accessClient) namespaceRules(namespace string) ([]authorizationv1.ResourceRule, bool, error) {
+	ac.mu.Lock()
+	cached, ok := ac.rules[namespace]
+	ac.mu.Unlock()
+	if ok && ac.now().Before(cached.expires) {
+		return cached.rules, cached.rules != nil, nil
+	}
+
+	review := &authorizationv1.SelfSubjectRulesReview{
+		Spec: authorizationv1.SelfSubjectRulesReviewSpec{
+			Namespace: namespace,
+		},
+	}
+
+	review, err := ac.client.AuthorizationV1().SelfSubjectRulesReviews().Create(review)
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "reviewing rules in namespace %s", namespace)
+	}
+
+	// Incomplete rules are cached as nil, so access is reviewed per action.
+	var rules []authorizationv1.ResourceRule
+	if !review.Status.Incomplete {
+		rules = append([]authorizationv1.ResourceRule{}, review.Status.ResourceRules...)
+	}
+
+	ac.mu.Lock()
+	ac.rules[namespace] = namespaceRules{
+		rules:   rules,
+		expires: ac.now().Add(accessCacheTTL),
+	}
+	ac.mu.Unlock()
+
+	return rules, rules != nil, nil
+}
+
+// rulesAllow reports whether any rule allows an action. Rules which are
+// limited to resource names don't allow actions on every resource.
+func rulesAllow(rules []authorizationv1.ResourceRule, access ResourceAccess) bool {
+	for _, rule := range rules {
+		if len(rule.ResourceNames) > 0 {
+			continue
+		}
+
+		if matchesRule(rule.Verbs, access.Verb) &&
+			matchesRule(rule.APIGroups, access.Group) &&
+			matchesRule(rule.Resources, access.Resource) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchesRule(values []string, s string) bool {
+	for _, value := range values {
+		if value == "*" || value == s {
+			return true
+		}
+	}
+
+	return false
+}
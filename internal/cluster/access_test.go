@@ -0,0 +1,143 @@
+package cluster
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"testing"
+	"time"
+)
+
+func newAccessTestClient(rules []authorizationv1.ResourceRule, incomplete bool, allowed bool) (*fake.Clientset, *int, *int) {
+	client := fake.NewSimpleClientset()
+
+	var rulesReviews, accessReviews int
+
+	client.PrependReactor("create", "selfsubjectrulesreviews", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		rulesReviews++
+		review := action.(clienttesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectRulesReview)
+		review.Status.ResourceRules = rules
+		review.Status.Incomplete = incomplete
+		return true, review, nil
+	})
+
+	client.PrependReactor("create", "selfsubjectaccessreviews", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		accessReviews++
+		review := action.(clienttesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		review.Status.Allowed = allowed
+		return true, review, nil
+	})
+
+	return client, &rulesReviews, &accessReviews
+}
+
+func Test_accessClient_namespaceRules(t *testing.T) {
+	rules := []authorizationv1.ResourceRule{
+		{Verbs: []string{"list", "watch"}, APIGroups: []string{""}, Resources: []string{"pods"}},
+		{Verbs: []string{"*"}, APIGroups: []string{"apps"}, Resources: []string{"*"}},
+		{Verbs: []string{"list"}, APIGroups: []string{""}, Resources: []string{"secrets"}, ResourceNames: []string{"token"}},
+	}
+
+	client, rulesReviews, accessReviews := newAccessTestClient(rules, false, false)
+	ac := newAccessClient(client)
+
+	cases := []struct {
+		name     string
+		access   ResourceAccess
+		expected bool
+	}{
+		{
+			name:     "allowed",
+			access:   ResourceAccess{Namespace: "default", Verb: "list", Resource: "pods"},
+			expected: true,
+		},
+		{
+			name:     "wildcards",
+			access:   ResourceAccess{Namespace: "default", Verb: "watch", Group: "apps", Resource: "deployments"},
+			expected: true,
+		},
+		{
+			name:     "other verb",
+			access:   ResourceAccess{Namespace: "default", Verb: "delete", Resource: "pods"},
+			expected: false,
+		},
+		{
+			name:     "limited to names",
+			access:   ResourceAccess{Namespace: "default", Verb: "list", Resource: "secrets"},
+			expected: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ac.Allowed(tc.access)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+
+	assert.Equal(t, 1, *rulesReviews)
+	assert.Equal(t, 0, *accessReviews)
+}
+
+func Test_accessClient_accessReview(t *testing.T) {
+	cases := []struct {
+		name          string
+		access        ResourceAccess
+		incomplete    bool
+		rulesReviews  int
+		accessReviews int
+	}{
+		{
+			name:          "cluster scoped",
+			access:        ResourceAccess{Verb: "list", Resource: "nodes"},
+			accessReviews: 1,
+		},
+		{
+			name:          "incomplete rules",
+			access:        ResourceAccess{Namespace: "default", Verb: "list", Resource: "pods"},
+			incomplete:    true,
+			rulesReviews:  1,
+			accessReviews: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client, rulesReviews, accessReviews := newAccessTestClient(nil, tc.incomplete, true)
+			ac := newAccessClient(client)
+
+			for i := 0; i < 2; i++ {
+				got, err := ac.Allowed(tc.access)
+				require.NoError(t, err)
+				assert.True(t, got)
+			}
+
+			assert.Equal(t, tc.rulesReviews, *rulesReviews)
+			assert.Equal(t, tc.accessReviews, *accessReviews)
+		})
+	}
+}
+
+func Test_accessClient_expires(t *testing.T) {
+	client, _, accessReviews := newAccessTestClient(nil, false, true)
+	ac := newAccessClient(client)
+
+	now := time.Now()
+	ac.now = func() time.Time { return now }
+
+	access := ResourceAccess{Verb: "list", Resource: "nodes"}
+
+	_, err := ac.Allowed(access)
+	require.NoError(t, err)
+
+	now = now.Add(accessCacheTTL + time.Second)
+
+	_, err = ac.Allowed(access)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, *accessReviews)
+}
@@ -5,8 +5,10 @@ import (
 	"github.com/pkg/errors"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	"sync"
 
 	// auth plugins
 	_ "k8s.io/client-go/plugin/pkg/client/auth/azure"
@@ -18,14 +20,25 @@ import (
 type ClientInterface interface {
 	DynamicClient() (dynamic.Interface, error)
 	DiscoveryClient() (discovery.DiscoveryInterface, error)
+	KubernetesClient() (kubernetes.Interface, error)
+	RESTConfig() *rest.Config
 	NamespaceClient() (NamespaceInterface, error)
 	InfoClient() (InfoInterface, error)
+	MutationClient() (MutationInterface, error)
+	AccessClient() (AccessInterface, error)
+	MetricsClient() (MetricsInterface, error)
 }
 
 // Cluster is a client cluster operations
 type Cluster struct {
 	clientConfig clientcmd.ClientConfig
 	restClient   *rest.Config
+	// contextName is the kubeconfig context, or empty for the current
+	// context.
+	contextName string
+
+	mu             sync.Mutex
+	mutationClient *mutationClient
 }
 
 var _ ClientInterface = (*Cluster)(nil)
@@ -54,9 +67,65 @@ func (c *Cluster) DiscoveryClient() (discovery.DiscoveryInterface, error) {
 	return discovery.NewDiscoveryClientForConfig(c.restClient)
 }
 
+// KubernetesClient returns a typed Kubernetes client for the cluster. It is
+// used for subresources, e.g. pod logs, which the dynamic client can't access.
+func (c *Cluster) KubernetesClient() (kubernetes.Interface, error) {
+	return kubernetes.NewForConfig(c.restClient)
+}
+
+// RESTConfig returns a copy of the REST config for the cluster. It is used
+// by clients which can't be created from a clientset, e.g. SPDY executors.
+func (c *Cluster) RESTConfig() *rest.Config {
+	return rest.CopyConfig(c.restClient)
+}
+
 // InfoClient returns an InfoClient for the cluster.
 func (c *Cluster) InfoClient() (InfoInterface, error) {
-	return newClusterInfo(c.clientConfig), nil
+	return newClusterInfo(c.clientConfig, c.contextName), nil
+}
+
+// MutationClient returns a client for changing objects in the cluster. The
+// client caches discovered resources, so the same client is returned each
+// time.
+func (c *Cluster) MutationClient() (MutationInterface, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.mutationClient != nil {
+		return c.mutationClient, nil
+	}
+
+	kubeClient, err := c.KubernetesClient()
+	if err != nil {
+		return nil, err
+	}
+
+	dc := kubeClient.Discovery()
+	c.mutationClient = newMutationClient(dc, dc.RESTClient())
+	return c.mutationClient, nil
+}
+
+// AccessClient returns a client for checking what the current user is
+// allowed to do. Decisions are cached by the client, so callers should
+// reuse it.
+func (c *Cluster) AccessClient() (AccessInterface, error) {
+	kubeClient, err := c.KubernetesClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return newAccessClient(kubeClient), nil
+}
+
+// MetricsClient returns a client for the resource usage reported by
+// metrics-server.
+func (c *Cluster) MetricsClient() (MetricsInterface, error) {
+	dc, err := c.DynamicClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return newMetricsClient(dc), nil
 }
 
 // Version returns a ServerVersion for the cluster
@@ -74,11 +143,14 @@ func (c *Cluster) Version() (string, error) {
 
 // FromKubeconfig creates a Cluster from a kubeconfig.
 func FromKubeconfig(kubeconfig string) (*Cluster, error) {
-	rules := clientcmd.NewDefaultClientConfigLoadingRules()
-	if kubeconfig != "" {
-		rules.ExplicitPath = kubeconfig
-	}
-	cc := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{})
+	return FromKubeconfigContext(kubeconfig, "")
+}
+
+// FromKubeconfigContext creates a Cluster for a context in a kubeconfig. If
+// contextName is empty, the kubeconfig's current context is used.
+func FromKubeconfigContext(kubeconfig, contextName string) (*Cluster, error) {
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: contextName}
+	cc := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules(kubeconfig), overrides)
 	config, err := cc.ClientConfig()
 	if err != nil {
 		return nil, err
@@ -87,5 +159,14 @@ func FromKubeconfig(kubeconfig string) (*Cluster, error) {
 	return &Cluster{
 		clientConfig: cc,
 		restClient:   config,
+		contextName:  contextName,
 	}, nil
 }
+
+func loadingRules(kubeconfig string) *clientcmd.ClientConfigLoadingRules {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if kubeconfig != "" {
+		rules.ExplicitPath = kubeconfig
+	}
+	return rules
+}
@@ -0,0 +1,78 @@
+package cluster
+
+import (
+	"github.com/pkg/errors"
+	"sort"
+)
+
+// Context is a context in a kubeconfig.
+type Context struct {
+	Name      string `json:"name"`
+	Cluster   string `json:"cluster"`
+	User      string `json:"user"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// ContextInterface lists the contexts in a kubeconfig and creates clients
+// for them.
+type ContextInterface interface {
+	Contexts() ([]Context, error)
+	ClientForContext(name string) (ClientInterface, error)
+}
+
+// KubeConfig creates clients for the contexts in a kubeconfig. The
+// kubeconfig is loaded each time it is used, so contexts which are added
+// while the dashboard is running are available.
+type KubeConfig struct {
+	kubeconfig string
+}
+
+var _ ContextInterface = (*KubeConfig)(nil)
+
+// NewKubeConfig creates an instance of KubeConfig. If kubeconfig is empty,
+// the default loading rules are used.
+func NewKubeConfig(kubeconfig string) *KubeConfig {
+	return &KubeConfig{kubeconfig: kubeconfig}
+}
+
+// Contexts returns the contexts in the kubeconfig ordered by name.
+func (k *KubeConfig) Contexts() ([]Context, error) {
+	raw, err := loadingRules(k.kubeconfig).Load()
+	if err != nil {
+		return nil, errors.Wrap(err, "loading kubeconfig")
+	}
+
+	var contexts []Context
+	for name, ktx := range raw.Contexts {
+		if ktx == nil {
+			continue
+		}
+
+		contexts = append(contexts, Context{
+			Name:      name,
+			Cluster:   ktx.Cluster,
+			User:      ktx.AuthInfo,
+			Namespace: ktx.Namespace,
+		})
+	}
+
+	sort.Slice(contexts, func(i, j int) bool {
+		return contexts[i].Name < contexts[j].Name
+	})
+
+	return contexts, nil
+}
+
+// ClientForContext creates a cluster client for a context.
+func (k *KubeConfig) ClientForContext(name string) (ClientInterface, error) {
+	if name == "" {
+		return nil, errors.New("context name is required")
+	}
+
+	c, err := FromKubeconfigContext(k.kubeconfig, name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "creating client for context %q", name)
+	}
+
+	return c, nil
+}
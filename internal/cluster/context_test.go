@@ -0,0 +1,38 @@
+package cluster
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"testing"
+)
+
+func TestKubeConfig_Contexts(t *testing.T) {
+	k := NewKubeConfig(filepath.Join("testdata", "kubeconfig-contexts.yaml"))
+
+	contexts, err := k.Contexts()
+	require.NoError(t, err)
+
+	expected := []Context{
+		{Name: "prod", Cluster: "prod-cluster", User: "prod-user"},
+		{Name: "staging", Cluster: "staging-cluster", User: "staging-user", Namespace: "apps"},
+	}
+	assert.Equal(t, expected, contexts)
+}
+
+func TestKubeConfig_ClientForContext(t *testing.T) {
+	k := NewKubeConfig(filepath.Join("testdata", "kubeconfig-contexts.yaml"))
+
+	client, err := k.ClientForContext("prod")
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://prod:4443", client.RESTConfig().Host)
+
+	infoClient, err := client.InfoClient()
+	require.NoError(t, err)
+	assert.Equal(t, "prod", infoClient.Context())
+	assert.Equal(t, "prod-cluster", infoClient.Cluster())
+
+	_, err = k.ClientForContext("missing")
+	assert.Error(t, err)
+}
@@ -0,0 +1,34 @@
+package fake
+
+import "github.com/twosson/kubeapt/internal/cluster"
+
+// AccessClient is a fake cluster.AccessInterface. Every action is allowed
+// unless it is denied.
+type AccessClient struct {
+	// Denied lists denied actions. An action with an empty namespace is
+	// denied in every namespace.
+	Denied []cluster.ResourceAccess
+	// Err is returned by Allowed if it is set.
+	Err error
+}
+
+var _ cluster.AccessInterface = (*AccessClient)(nil)
+
+// Allowed reports whether an action is allowed.
+func (ac *AccessClient) Allowed(access cluster.ResourceAccess) (bool, error) {
+	if ac.Err != nil {
+		return false, ac.Err
+	}
+
+	for _, denied := range ac.Denied {
+		if denied.Namespace != "" && denied.Namespace != access.Namespace {
+			continue
+		}
+
+		if denied.Verb == access.Verb && denied.Group == access.Group && denied.Resource == access.Resource {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
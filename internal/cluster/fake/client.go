@@ -15,7 +15,9 @@ import (
 	"k8s.io/client-go/discovery"
 	fakediscovery "k8s.io/client-go/discovery/fake"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
 	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/restmapper"
 	"k8s.io/client-go/testing"
 )
@@ -30,6 +32,21 @@ var (
 type Client struct {
 	FakeDynamic   *dynamicfake.FakeDynamicClient
 	FakeDiscovery *fakediscovery.FakeDiscovery
+	// FakeKubernetes is a fake clientset by default. Tests which need
+	// subresources can replace it with a client for a fake REST server.
+	FakeKubernetes kubernetes.Interface
+	// FakeRESTConfig is the REST config returned by RESTConfig.
+	FakeRESTConfig *rest.Config
+	// FakeMutation is the client returned by MutationClient.
+	FakeMutation *MutationClient
+	// FakeNamespace is the client returned by NamespaceClient.
+	FakeNamespace *NamespaceClient
+	// FakeInfo is the client returned by InfoClient.
+	FakeInfo ClusterInfo
+	// FakeAccess is the client returned by AccessClient.
+	FakeAccess *AccessClient
+	// FakeMetrics is the client returned by MetricsClient.
+	FakeMetrics *MetricsClient
 }
 
 // NewClient creates an instance of Client.
@@ -48,8 +65,14 @@ func NewClient(scheme *runtime.Scheme, resources []*metav1.APIResourceList, obje
 	dynamicClient := NewSimpleDynamicClient(scheme, restMapper, objects...)
 
 	return &Client{
-		FakeDynamic:   dynamicClient,
-		FakeDiscovery: fakeDiscovery,
+		FakeDynamic:    dynamicClient,
+		FakeDiscovery:  fakeDiscovery,
+		FakeKubernetes: client,
+		FakeRESTConfig: &rest.Config{},
+		FakeMutation:   &MutationClient{},
+		FakeNamespace:  &NamespaceClient{},
+		FakeAccess:     &AccessClient{},
+		FakeMetrics:    &MetricsClient{},
 	}, nil
 }
 
@@ -63,14 +86,39 @@ func (c *Client) DiscoveryClient() (discovery.DiscoveryInterface, error) {
 	return c.FakeDiscovery, nil
 }
 
+// KubernetesClient returns a Kubernetes client or an error.
+func (c *Client) KubernetesClient() (kubernetes.Interface, error) {
+	return c.FakeKubernetes, nil
+}
+
+// RESTConfig returns a REST config.
+func (c *Client) RESTConfig() *rest.Config {
+	return c.FakeRESTConfig
+}
+
 // NamespaceClient returns a namspace client or an error.
 func (c *Client) NamespaceClient() (cluster.NamespaceInterface, error) {
-	return &NamespaceClient{}, nil
+	return c.FakeNamespace, nil
 }
 
 // NamespaceClient returns an info client or an error.
 func (c *Client) InfoClient() (cluster.InfoInterface, error) {
-	return &ClusterInfo{}, nil
+	return c.FakeInfo, nil
+}
+
+// MutationClient returns a mutation client or an error.
+func (c *Client) MutationClient() (cluster.MutationInterface, error) {
+	return c.FakeMutation, nil
+}
+
+// AccessClient returns an access client or an error.
+func (c *Client) AccessClient() (cluster.AccessInterface, error) {
+	return c.FakeAccess, nil
+}
+
+// MetricsClient returns a metrics client or an error.
+func (c *Client) MetricsClient() (cluster.MetricsInterface, error) {
+	return c.FakeMetrics, nil
 }
 
 // RESTMapper returns a RESTMapper using the client's discovery interface.
@@ -0,0 +1,30 @@
+package fake
+
+import (
+	"github.com/pkg/errors"
+	"github.com/twosson/kubeapt/internal/cluster"
+)
+
+// Contexts is a fake cluster.ContextInterface. Clients are looked up by
+// context name.
+type Contexts struct {
+	ContextList []cluster.Context
+	Clients     map[string]cluster.ClientInterface
+}
+
+var _ cluster.ContextInterface = (*Contexts)(nil)
+
+// Contexts returns the contexts.
+func (c *Contexts) Contexts() ([]cluster.Context, error) {
+	return c.ContextList, nil
+}
+
+// ClientForContext returns the client for a context.
+func (c *Contexts) ClientForContext(name string) (cluster.ClientInterface, error) {
+	client, ok := c.Clients[name]
+	if !ok {
+		return nil, errors.Errorf("no client for context %q", name)
+	}
+
+	return client, nil
+}
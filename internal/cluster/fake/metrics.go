@@ -0,0 +1,67 @@
+package fake
+
+import (
+	"github.com/pkg/errors"
+	"github.com/twosson/kubeapt/internal/cluster"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// MetricsClient is a fake cluster.MetricsInterface which returns fixed
+// metrics.
+type MetricsClient struct {
+	Pods  []cluster.PodMetrics
+	Nodes []cluster.NodeMetrics
+	// Err is returned by PodMetrics and NodeMetrics if it is set.
+	Err error
+	// Namespaces limits the pod metrics which can be read to the pods in
+	// these namespaces if it is set. Reading other namespaces, or every
+	// namespace, is forbidden.
+	Namespaces []string
+}
+
+var _ cluster.MetricsInterface = (*MetricsClient)(nil)
+
+// PodMetrics returns the metrics of the pods in a namespace, or of every
+// pod if the namespace is empty.
+func (mc *MetricsClient) PodMetrics(namespace string) ([]cluster.PodMetrics, error) {
+	if mc.Err != nil {
+		return nil, mc.Err
+	}
+	if !mc.canRead(namespace) {
+		gr := schema.GroupResource{Group: "metrics.k8s.io", Resource: "pods"}
+		return nil, &cluster.MetricsUnavailableError{Err: apierrors.NewForbidden(gr, "", errors.New("denied"))}
+	}
+
+	var pods []cluster.PodMetrics
+	for _, pm := range mc.Pods {
+		if namespace == "" || pm.Namespace == namespace {
+			pods = append(pods, pm)
+		}
+	}
+
+	return pods, nil
+}
+
+// NodeMetrics returns the metrics of every node.
+func (mc *MetricsClient) NodeMetrics() ([]cluster.NodeMetrics, error) {
+	if mc.Err != nil {
+		return nil, mc.Err
+	}
+
+	return mc.Nodes, nil
+}
+
+func (mc *MetricsClient) canRead(namespace string) bool {
+	if mc.Namespaces == nil {
+		return true
+	}
+
+	for _, allowed := range mc.Namespaces {
+		if namespace == allowed {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,69 @@
+package fake
+
+import (
+	"github.com/twosson/kubeapt/internal/cluster"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// MutationUpdate is an update recorded by MutationClient.
+type MutationUpdate struct {
+	Object  *unstructured.Unstructured
+	Options cluster.MutationOptions
+}
+
+// MutationPatch is a patch recorded by MutationClient.
+type MutationPatch struct {
+	Object    *unstructured.Unstructured
+	PatchType types.PatchType
+	Data      string
+}
+
+// MutationDelete is a delete recorded by MutationClient.
+type MutationDelete struct {
+	Object            *unstructured.Unstructured
+	PropagationPolicy metav1.DeletionPropagation
+}
+
+// MutationClient is a fake cluster.MutationInterface. Mutations are
+// recorded. If Err is set, it is returned by Patch and Delete, and
+// UpdateErr is returned by Update.
+type MutationClient struct {
+	Updates   []MutationUpdate
+	Patches   []MutationPatch
+	Deletes   []MutationDelete
+	UpdateErr error
+	Err       error
+}
+
+var _ cluster.MutationInterface = (*MutationClient)(nil)
+
+// Update records an update.
+func (mc *MutationClient) Update(object *unstructured.Unstructured, options cluster.MutationOptions) (*unstructured.Unstructured, error) {
+	mc.Updates = append(mc.Updates, MutationUpdate{Object: object, Options: options})
+
+	if mc.UpdateErr != nil {
+		return nil, mc.UpdateErr
+	}
+
+	return object.DeepCopy(), nil
+}
+
+// Patch records a patch. It returns the object unchanged.
+func (mc *MutationClient) Patch(object *unstructured.Unstructured, patchType types.PatchType, data []byte) (*unstructured.Unstructured, error) {
+	mc.Patches = append(mc.Patches, MutationPatch{Object: object, PatchType: patchType, Data: string(data)})
+
+	if mc.Err != nil {
+		return nil, mc.Err
+	}
+
+	return object.DeepCopy(), nil
+}
+
+// Delete records a delete.
+func (mc *MutationClient) Delete(object *unstructured.Unstructured, propagationPolicy metav1.DeletionPropagation) error {
+	mc.Deletes = append(mc.Deletes, MutationDelete{Object: object, PropagationPolicy: propagationPolicy})
+
+	return mc.Err
+}
@@ -0,0 +1,85 @@
+package fake
+
+import (
+	"github.com/pkg/errors"
+	"github.com/twosson/kubeapt/internal/cluster"
+	"strconv"
+)
+
+// PortForwarder is a fake cluster.PortForwardInterface which records port
+// forwards without connecting to a cluster.
+type PortForwarder struct {
+	forwards   []cluster.PortForwardState
+	errOnStart error
+}
+
+var _ cluster.PortForwardInterface = (*PortForwarder)(nil)
+
+// NewPortForwarder creates an instance of PortForwarder. If errOnStart is
+// not nil, it is returned by Start.
+func NewPortForwarder(errOnStart error) *PortForwarder {
+	return &PortForwarder{errOnStart: errOnStart}
+}
+
+// Start records a port forward. The local port is the requested local port,
+// or the remote port if none was requested.
+func (pf *PortForwarder) Start(spec cluster.PortForwardSpec) (cluster.PortForwardState, error) {
+	if pf.errOnStart != nil {
+		return cluster.PortForwardState{}, pf.errOnStart
+	}
+
+	localPort := spec.LocalPort
+	if localPort == 0 {
+		localPort = uint16(spec.Port)
+	}
+
+	state := cluster.PortForwardState{
+		ID:         strconv.Itoa(len(pf.forwards) + 1),
+		Spec:       spec,
+		Pod:        spec.Name,
+		LocalPort:  localPort,
+		RemotePort: uint16(spec.Port),
+	}
+	pf.forwards = append(pf.forwards, state)
+
+	return state, nil
+}
+
+// List lists port forwards.
+func (pf *PortForwarder) List() []cluster.PortForwardState {
+	return pf.forwards
+}
+
+// Get returns a port forward by ID.
+func (pf *PortForwarder) Get(id string) (cluster.PortForwardState, bool) {
+	for _, state := range pf.forwards {
+		if state.ID == id {
+			return state, true
+		}
+	}
+
+	return cluster.PortForwardState{}, false
+}
+
+// Stop removes a port forward.
+func (pf *PortForwarder) Stop(id string) error {
+	for i, state := range pf.forwards {
+		if state.ID == id {
+			pf.forwards = append(pf.forwards[:i], pf.forwards[i+1:]...)
+			return nil
+		}
+	}
+
+	return errors.Errorf("port forward %q not found", id)
+}
+
+// StopAll removes all port forwards.
+func (pf *PortForwarder) StopAll() {
+	pf.forwards = nil
+}
+
+// SetClient stops all port forwards.
+func (pf *PortForwarder) SetClient(client cluster.ClientInterface) error {
+	pf.StopAll()
+	return nil
+}
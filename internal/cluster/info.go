@@ -1,6 +1,9 @@
 package cluster
 
-import "k8s.io/client-go/tools/clientcmd"
+import (
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
 
 // InfoInterface provides connection details for a cluster
 type InfoInterface interface {
@@ -12,10 +15,20 @@ type InfoInterface interface {
 
 type clusterInfo struct {
 	clientConfig clientcmd.ClientConfig
+	// contextName overrides the kubeconfig's current context if it is set.
+	contextName string
 }
 
-func newClusterInfo(clientConfig clientcmd.ClientConfig) clusterInfo {
-	return clusterInfo{clientConfig: clientConfig}
+func newClusterInfo(clientConfig clientcmd.ClientConfig, contextName string) clusterInfo {
+	return clusterInfo{clientConfig: clientConfig, contextName: contextName}
+}
+
+// currentContext returns the name of the context in use.
+func (ci clusterInfo) currentContext(raw clientcmdapi.Config) string {
+	if ci.contextName != "" {
+		return ci.contextName
+	}
+	return raw.CurrentContext
 }
 
 func (ci clusterInfo) Context() string {
@@ -23,7 +36,7 @@ func (ci clusterInfo) Context() string {
 	if err != nil {
 		return ""
 	}
-	return raw.CurrentContext
+	return ci.currentContext(raw)
 }
 
 func (ci clusterInfo) Cluster() string {
@@ -31,7 +44,7 @@ func (ci clusterInfo) Cluster() string {
 	if err != nil {
 		return ""
 	}
-	ktx, ok := raw.Contexts[raw.CurrentContext]
+	ktx, ok := raw.Contexts[ci.currentContext(raw)]
 	if !ok || ktx == nil {
 		return ""
 	}
@@ -43,7 +56,7 @@ func (ci clusterInfo) Server() string {
 	if err != nil {
 		return ""
 	}
-	ktx, ok := raw.Contexts[raw.CurrentContext]
+	ktx, ok := raw.Contexts[ci.currentContext(raw)]
 	if !ok || ktx == nil {
 		return ""
 	}
@@ -59,7 +72,7 @@ func (ci clusterInfo) User() string {
 	if err != nil {
 		return ""
 	}
-	ktx, ok := raw.Contexts[raw.CurrentContext]
+	ktx, ok := raw.Contexts[ci.currentContext(raw)]
 	if !ok || ktx == nil {
 		return ""
 	}
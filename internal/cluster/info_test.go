@@ -12,6 +12,7 @@ func Test_clusterInfo(t *testing.T) {
 	tests := []struct {
 		name          string
 		kubeConfig    []byte
+		contextName   string
 		expectContext string
 		expectCluster string
 		expectServer  string
@@ -94,6 +95,34 @@ current-context: other-context
 			expectServer:  "https://other-localhost:6443",
 			expectUser:    "",
 		},
+		{
+			name: "context override",
+			kubeConfig: []byte(`---
+apiVersion: v1
+clusters:
+- cluster:
+    server: https://other-localhost:6443
+  name: other-cluster
+- cluster:
+    server: https://localhost:6443
+  name: docker-for-desktop
+contexts:
+- context:
+    cluster: docker-for-desktop
+    user: docker-user
+  name: main-context
+- context:
+    cluster: other-cluster
+    user: other-user
+  name: other-context
+current-context: main-context
+`),
+			contextName:   "other-context",
+			expectContext: "other-context",
+			expectCluster: "other-cluster",
+			expectServer:  "https://other-localhost:6443",
+			expectUser:    "other-user",
+		},
 	}
 
 	for _, tc := range tests {
@@ -101,7 +130,7 @@ current-context: other-context
 			config, err := clientcmd.NewClientConfigFromBytes(tc.kubeConfig)
 			require.NoError(t, err)
 
-			ci := newClusterInfo(config)
+			ci := newClusterInfo(config, tc.contextName)
 			assert.Equal(t, tc.expectContext, ci.Context(), "unexpected context")
 			assert.Equal(t, tc.expectCluster, ci.Cluster(), "unexpected cluster")
 			assert.Equal(t, tc.expectServer, ci.Server(), "unexpected server")
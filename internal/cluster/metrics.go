@@ -0,0 +1,239 @@
+package cluster
+
+import (
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"time"
+)
+
+var (
+	podMetricsResource = schema.GroupVersionResource{
+		Group:    "metrics.k8s.io",
+		Version:  "v1beta1",
+		Resource: "pods",
+	}
+	nodeMetricsResource = schema.GroupVersionResource{
+		Group:    "metrics.k8s.io",
+		Version:  "v1beta1",
+		Resource: "nodes",
+	}
+)
+
+// MetricsInterface retrieves resource usage from the metrics.k8s.io API,
+// which is served by metrics-server. Errors for clusters which don't serve
+// the API, or users who can't read it, are detected with
+// IsMetricsUnavailable.
+type MetricsInterface interface {
+	// PodMetrics returns the usage of the pods in a namespace, or of every
+	// pod if the namespace is empty.
+	PodMetrics(namespace string) ([]PodMetrics, error)
+	// NodeMetrics returns the usage of every node.
+	NodeMetrics() ([]NodeMetrics, error)
+}
+
+// PodMetrics is the usage of a pod's containers, sampled at Timestamp.
+type PodMetrics struct {
+	Namespace  string
+	Name       string
+	Timestamp  time.Time
+	Containers []ContainerMetrics
+}
+
+// Usage returns the total usage of the pod's containers.
+func (pm PodMetrics) Usage() corev1.ResourceList {
+	usage := corev1.ResourceList{}
+	for _, container := range pm.Containers {
+		for name, quantity := range container.Usage {
+			total := usage[name]
+			total.Add(quantity)
+			usage[name] = total
+		}
+	}
+
+	return usage
+}
+
+// ContainerMetrics is the usage of a container.
+type ContainerMetrics struct {
+	Name  string
+	Usage corev1.ResourceList
+}
+
+// NodeMetrics is the usage of a node, sampled at Timestamp.
+type NodeMetrics struct {
+	Name      string
+	Timestamp time.Time
+	Usage     corev1.ResourceList
+}
+
+// MetricsUnavailableError is returned when the metrics API isn't served, or
+// can't be read by the current user. Err is the error from the API server.
+type MetricsUnavailableError struct {
+	Err error
+}
+
+func (e *MetricsUnavailableError) Error() string {
+	return "metrics API is unavailable: " + e.Err.Error()
+}
+
+// IsMetricsUnavailable returns true if err was caused by the metrics API
+// not being served, or not being readable by the current user.
+func IsMetricsUnavailable(err error) bool {
+	_, ok := errors.Cause(err).(*MetricsUnavailableError)
+	return ok
+}
+
+// IsMetricsForbidden returns true if err was caused by the current user
+// not being allowed to read the metrics API, e.g. across the cluster.
+func IsMetricsForbidden(err error) bool {
+	unavailable, ok := errors.Cause(err).(*MetricsUnavailableError)
+	return ok && apierrors.IsForbidden(unavailable.Err)
+}
+
+// metricsClient reads the metrics API with a dynamic client, so it doesn't
+// depend on the metrics API types.
+type metricsClient struct {
+	dynamicClient dynamic.Interface
+}
+
+var _ MetricsInterface = (*metricsClient)(nil)
+
+func newMetricsClient(dynamicClient dynamic.Interface) *metricsClient {
+	return &metricsClient{
+		dynamicClient: dynamicClient,
+	}
+}
+
+// PodMetrics returns the usage of the pods in a namespace, or of every pod
+// if the namespace is empty.
+func (mc *metricsClient) PodMetrics(namespace string) ([]PodMetrics, error) {
+	list, err := mc.list(podMetricsResource, namespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing pod metrics")
+	}
+
+	var podMetrics []PodMetrics
+	for i := range list.Items {
+		item := &list.Items[i]
+
+		pm := PodMetrics{
+			Namespace: item.GetNamespace(),
+			Name:      item.GetName(),
+		}
+
+		if pm.Timestamp, err = metricsTimestamp(item); err != nil {
+			return nil, err
+		}
+
+		containers, _, err := unstructured.NestedSlice(item.Object, "containers")
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading containers of pod metrics %s", item.GetName())
+		}
+
+		for _, c := range containers {
+			container, ok := c.(map[string]interface{})
+			if !ok {
+				return nil, errors.Errorf("unexpected container in pod metrics %s: %T", item.GetName(), c)
+			}
+
+			name, _, _ := unstructured.NestedString(container, "name")
+			usage, err := metricsUsage(container)
+			if err != nil {
+				return nil, errors.Wrapf(err, "reading usage of pod metrics %s", item.GetName())
+			}
+
+			pm.Containers = append(pm.Containers, ContainerMetrics{Name: name, Usage: usage})
+		}
+
+		podMetrics = append(podMetrics, pm)
+	}
+
+	return podMetrics, nil
+}
+
+// NodeMetrics returns the usage of every node.
+func (mc *metricsClient) NodeMetrics() ([]NodeMetrics, error) {
+	list, err := mc.list(nodeMetricsResource, "")
+	if err != nil {
+		return nil, errors.Wrap(err, "listing node metrics")
+	}
+
+	var nodeMetrics []NodeMetrics
+	for i := range list.Items {
+		item := &list.Items[i]
+
+		nm := NodeMetrics{Name: item.GetName()}
+
+		if nm.Timestamp, err = metricsTimestamp(item); err != nil {
+			return nil, err
+		}
+
+		if nm.Usage, err = metricsUsage(item.Object); err != nil {
+			return nil, errors.Wrapf(err, "reading usage of node metrics %s", item.GetName())
+		}
+
+		nodeMetrics = append(nodeMetrics, nm)
+	}
+
+	return nodeMetrics, nil
+}
+
+func (mc *metricsClient) list(gvr schema.GroupVersionResource, namespace string) (*unstructured.UnstructuredList, error) {
+	nri := mc.dynamicClient.Resource(gvr)
+
+	var ri dynamic.ResourceInterface = nri
+	if namespace != "" {
+		ri = nri.Namespace(namespace)
+	}
+
+	list, err := ri.List(metav1.ListOptions{})
+	if err != nil {
+		// The API isn't registered when metrics-server isn't installed, and
+		// is unavailable while it is starting.
+		if apierrors.IsNotFound(err) || apierrors.IsForbidden(err) || apierrors.IsServiceUnavailable(err) {
+			return nil, &MetricsUnavailableError{Err: err}
+		}
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func metricsTimestamp(item *unstructured.Unstructured) (time.Time, error) {
+	s, _, err := unstructured.NestedString(item.Object, "timestamp")
+	if err != nil || s == "" {
+		return time.Time{}, errors.Errorf("metrics %s don't have a timestamp", item.GetName())
+	}
+
+	timestamp, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "parsing timestamp of metrics %s", item.GetName())
+	}
+
+	return timestamp, nil
+}
+
+// metricsUsage reads the usage field of a metrics object or container.
+func metricsUsage(object map[string]interface{}) (corev1.ResourceList, error) {
+	m, _, err := unstructured.NestedStringMap(object, "usage")
+	if err != nil {
+		return nil, err
+	}
+
+	usage := corev1.ResourceList{}
+	for name, value := range m {
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing %s usage", name)
+		}
+		usage[corev1.ResourceName(name)] = quantity
+	}
+
+	return usage, nil
+}
@@ -0,0 +1,158 @@
+package cluster
+
+import (
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twosson/kubeapt/third_party/dynamicfake"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clienttesting "k8s.io/client-go/testing"
+	"testing"
+	"time"
+)
+
+func newPodMetrics(namespace, name string, usage ...map[string]interface{}) unstructured.Unstructured {
+	var containers []interface{}
+	for i, u := range usage {
+		containers = append(containers, map[string]interface{}{
+			"name":  []string{"app", "sidecar"}[i],
+			"usage": u,
+		})
+	}
+
+	u := newUnstructured("metrics.k8s.io/v1beta1", "PodMetrics", namespace, name)
+	u.Object["timestamp"] = "2018-11-20T10:00:00Z"
+	u.Object["window"] = "30s"
+	u.Object["containers"] = containers
+	return *u
+}
+
+// newFakeMetricsClient creates a dynamic client which lists metrics. The
+// object tracker can't guess the resources of metrics kinds, so lists are
+// answered by reactors.
+func newFakeMetricsClient(pods, nodes []unstructured.Unstructured, err error) *dynamicfake.FakeDynamicClient {
+	dc := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+
+	react := func(items []unstructured.Unstructured) clienttesting.ReactionFunc {
+		return func(action clienttesting.Action) (bool, runtime.Object, error) {
+			if err != nil {
+				return true, nil, err
+			}
+
+			list := &unstructured.UnstructuredList{Object: map[string]interface{}{}}
+			for _, item := range items {
+				if action.GetNamespace() == "" || item.GetNamespace() == action.GetNamespace() {
+					list.Items = append(list.Items, item)
+				}
+			}
+			return true, list, nil
+		}
+	}
+
+	dc.PrependReactor("list", "pods", react(pods))
+	dc.PrependReactor("list", "nodes", react(nodes))
+
+	return dc
+}
+
+func Test_metricsClient_PodMetrics(t *testing.T) {
+	pods := []unstructured.Unstructured{
+		newPodMetrics("default", "web",
+			map[string]interface{}{"cpu": "100m", "memory": "64Mi"},
+			map[string]interface{}{"cpu": "5m", "memory": "16Mi"},
+		),
+		newPodMetrics("other", "db", map[string]interface{}{"cpu": "1", "memory": "1Gi"}),
+	}
+
+	mc := newMetricsClient(newFakeMetricsClient(pods, nil, nil))
+
+	got, err := mc.PodMetrics("default")
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+
+	assert.Equal(t, "default", got[0].Namespace)
+	assert.Equal(t, "web", got[0].Name)
+	assert.Equal(t, time.Date(2018, 11, 20, 10, 0, 0, 0, time.UTC), got[0].Timestamp.UTC())
+	require.Len(t, got[0].Containers, 2)
+	assert.Equal(t, "sidecar", got[0].Containers[1].Name)
+
+	usage := got[0].Usage()
+	cpu := usage[corev1.ResourceCPU]
+	memory := usage[corev1.ResourceMemory]
+	assert.Equal(t, "105m", cpu.String())
+	assert.Equal(t, "80Mi", memory.String())
+
+	got, err = mc.PodMetrics("")
+	require.NoError(t, err)
+	assert.Len(t, got, 2)
+}
+
+func Test_metricsClient_NodeMetrics(t *testing.T) {
+	node := newUnstructured("metrics.k8s.io/v1beta1", "NodeMetrics", "", "node1")
+	node.Object["timestamp"] = "2018-11-20T10:00:00Z"
+	node.Object["usage"] = map[string]interface{}{"cpu": "1500m", "memory": "2Gi"}
+
+	mc := newMetricsClient(newFakeMetricsClient(nil, []unstructured.Unstructured{*node}, nil))
+
+	got, err := mc.NodeMetrics()
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+
+	assert.Equal(t, "node1", got[0].Name)
+	assert.Equal(t, corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("1500m"),
+		corev1.ResourceMemory: resource.MustParse("2Gi"),
+	}, got[0].Usage)
+}
+
+func Test_metricsClient_unavailable(t *testing.T) {
+	gr := schema.GroupResource{Group: "metrics.k8s.io", Resource: "pods"}
+
+	cases := []struct {
+		name        string
+		err         error
+		unavailable bool
+		forbidden   bool
+	}{
+		{
+			name:        "not installed",
+			err:         apierrors.NewNotFound(gr, ""),
+			unavailable: true,
+		},
+		{
+			name:        "forbidden",
+			err:         apierrors.NewForbidden(gr, "", errors.New("denied")),
+			unavailable: true,
+			forbidden:   true,
+		},
+		{
+			name:        "starting",
+			err:         apierrors.NewServiceUnavailable("metrics-server is starting"),
+			unavailable: true,
+		},
+		{
+			name: "other error",
+			err:  errors.New("connection refused"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mc := newMetricsClient(newFakeMetricsClient(nil, nil, tc.err))
+
+			_, err := mc.PodMetrics("default")
+			require.Error(t, err)
+			assert.Equal(t, tc.unavailable, IsMetricsUnavailable(err))
+			assert.Equal(t, tc.forbidden, IsMetricsForbidden(err))
+
+			_, err = mc.NodeMetrics()
+			require.Error(t, err)
+			assert.Equal(t, tc.unavailable, IsMetricsUnavailable(err))
+		})
+	}
+}
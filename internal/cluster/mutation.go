@@ -0,0 +1,224 @@
+package cluster
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// MutationOptions are options for mutating objects.
+type MutationOptions struct {
+	// DryRun validates the mutation with the API server without persisting it.
+	DryRun bool
+}
+
+// MutationInterface changes objects in the cluster. Objects are identified
+// by their apiVersion, kind, namespace and name.
+type MutationInterface interface {
+	Update(object *unstructured.Unstructured, options MutationOptions) (*unstructured.Unstructured, error)
+	Patch(object *unstructured.Unstructured, patchType types.PatchType, data []byte) (*unstructured.Unstructured, error)
+	Delete(object *unstructured.Unstructured, propagationPolicy metav1.DeletionPropagation) error
+}
+
+// ErrDryRunNotSupported is returned when the API server can't perform
+// server-side dry-runs.
+var ErrDryRunNotSupported = errors.New("server-side dry-run requires Kubernetes 1.13 or later")
+
+// mutationClient changes objects using the REST API. The dynamic client in
+// this version of client-go can't pass options such as dryRun, so requests
+// are made with a REST client. Discovered resources are cached, and only
+// discovered again when an object's kind isn't found, e.g. after a custom
+// resource definition is created.
+type mutationClient struct {
+	discoveryClient discovery.DiscoveryInterface
+	restClient      rest.Interface
+
+	mu         sync.Mutex
+	restMapper meta.RESTMapper
+}
+
+var _ MutationInterface = (*mutationClient)(nil)
+
+func newMutationClient(discoveryClient discovery.DiscoveryInterface, restClient rest.Interface) *mutationClient {
+	return &mutationClient{
+		discoveryClient: discoveryClient,
+		restClient:      restClient,
+	}
+}
+
+// Update replaces an object.
+func (mc *mutationClient) Update(object *unstructured.Unstructured, options MutationOptions) (*unstructured.Unstructured, error) {
+	if options.DryRun {
+		info, err := mc.discoveryClient.ServerVersion()
+		if err != nil {
+			return nil, errors.Wrap(err, "fetching server version")
+		}
+
+		if !supportsDryRun(info) {
+			return nil, ErrDryRunNotSupported
+		}
+	}
+
+	objectPath, err := mc.objectPath(object)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(object.Object)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling object")
+	}
+
+	req := mc.restClient.Put().
+		AbsPath(objectPath).
+		SetHeader("Content-Type", "application/json").
+		Body(data)
+	if options.DryRun {
+		req = req.Param("dryRun", "All")
+	}
+
+	return decodeObject(req.Do().Raw())
+}
+
+// Patch patches an object.
+func (mc *mutationClient) Patch(object *unstructured.Unstructured, patchType types.PatchType, data []byte) (*unstructured.Unstructured, error) {
+	objectPath, err := mc.objectPath(object)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeObject(mc.restClient.Patch(patchType).
+		AbsPath(objectPath).
+		Body(data).
+		Do().
+		Raw())
+}
+
+// Delete deletes an object. Dependents are deleted according to the
+// propagation policy.
+func (mc *mutationClient) Delete(object *unstructured.Unstructured, propagationPolicy metav1.DeletionPropagation) error {
+	objectPath, err := mc.objectPath(object)
+	if err != nil {
+		return err
+	}
+
+	options := metav1.DeleteOptions{
+		TypeMeta:          metav1.TypeMeta{APIVersion: "v1", Kind: "DeleteOptions"},
+		PropagationPolicy: &propagationPolicy,
+	}
+
+	data, err := json.Marshal(&options)
+	if err != nil {
+		return errors.Wrap(err, "marshaling delete options")
+	}
+
+	return mc.restClient.Delete().
+		AbsPath(objectPath).
+		SetHeader("Content-Type", "application/json").
+		Body(data).
+		Do().
+		Error()
+}
+
+func decodeObject(raw []byte, err error) (*unstructured.Unstructured, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	object := &unstructured.Unstructured{}
+	if err := object.UnmarshalJSON(raw); err != nil {
+		return nil, errors.Wrap(err, "decoding object")
+	}
+
+	return object, nil
+}
+
+// objectPath returns the REST path for an object.
+func (mc *mutationClient) objectPath(object *unstructured.Unstructured) (string, error) {
+	gvk := object.GroupVersionKind()
+	if gvk.Kind == "" || gvk.Version == "" {
+		return "", errors.New("object must have an apiVersion and kind")
+	}
+
+	if object.GetName() == "" {
+		return "", errors.New("object must have a name")
+	}
+
+	mapping, err := mc.restMapping(gvk)
+	if err != nil {
+		return "", err
+	}
+
+	parts := []string{"/apis", gvk.Group, gvk.Version}
+	if gvk.Group == "" {
+		parts = []string{"/api", gvk.Version}
+	}
+
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		namespace := object.GetNamespace()
+		if namespace == "" {
+			return "", errors.New("namespaced object must have a namespace")
+		}
+		parts = append(parts, "namespaces", namespace)
+	}
+
+	parts = append(parts, mapping.Resource.Resource, object.GetName())
+
+	return path.Join(parts...), nil
+}
+
+// restMapping returns the REST mapping for a kind. Resources are discovered
+// if they haven't been, or if the cached resources don't have the kind.
+func (mc *mutationClient) restMapping(gvk schema.GroupVersionKind) (*meta.RESTMapping, error) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	if mc.restMapper != nil {
+		mapping, err := mc.restMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if !meta.IsNoMatchError(err) {
+			return mapping, errors.Wrapf(err, "finding resource for %s", gvk)
+		}
+	}
+
+	resources, err := restmapper.GetAPIGroupResources(mc.discoveryClient)
+	if err != nil {
+		return nil, errors.Wrap(err, "discovering API resources")
+	}
+	mc.restMapper = restmapper.NewDiscoveryRESTMapper(resources)
+
+	mapping, err := mc.restMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding resource for %s", gvk)
+	}
+
+	return mapping, nil
+}
+
+// supportsDryRun returns true if the server has server-side dry-run enabled
+// by default. Older servers ignore the dryRun parameter and would persist
+// the change.
+func supportsDryRun(info *version.Info) bool {
+	major, err := strconv.Atoi(info.Major)
+	if err != nil {
+		return false
+	}
+
+	minor, err := strconv.Atoi(strings.TrimRight(info.Minor, "+"))
+	if err != nil {
+		return false
+	}
+
+	return major > 1 || (major == 1 && minor >= 13)
+}
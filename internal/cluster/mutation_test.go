@@ -0,0 +1,235 @@
+package cluster
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+)
+
+type mutationRequest struct {
+	method      string
+	path        string
+	query       url.Values
+	contentType string
+	body        string
+}
+
+func newFakeMutationServer(t *testing.T, minor string, requests chan<- mutationRequest) *httptest.Server {
+	mux := http.NewServeMux()
+
+	writeJSON := func(path, body string) {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, body)
+		})
+	}
+
+	writeJSON("/version", fmt.Sprintf(`{"major":"1","minor":%q}`, minor))
+	writeJSON("/api", `{"kind":"APIVersions","versions":["v1"]}`)
+	writeJSON("/apis", `{"kind":"APIGroupList","apiVersion":"v1","groups":[]}`)
+	writeJSON("/api/v1", `{"kind":"APIResourceList","groupVersion":"v1","resources":[
+		{"name":"configmaps","namespaced":true,"kind":"ConfigMap","verbs":["get","update"]},
+		{"name":"namespaces","namespaced":false,"kind":"Namespace","verbs":["get","update"]}]}`)
+
+	mux.HandleFunc("/api/v1/namespaces/", func(w http.ResponseWriter, r *http.Request) {
+		data, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		requests <- mutationRequest{
+			method:      r.Method,
+			path:        r.URL.Path,
+			query:       r.URL.Query(),
+			contentType: r.Header.Get("Content-Type"),
+			body:        string(data),
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodPut:
+			w.Write(data)
+		case http.MethodPatch:
+			fmt.Fprint(w, `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm","namespace":"default"}}`)
+		default:
+			fmt.Fprint(w, `{"kind":"Status","apiVersion":"v1","status":"Success"}`)
+		}
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func newTestMutationClient(t *testing.T, ts *httptest.Server) *mutationClient {
+	kubeClient, err := kubernetes.NewForConfig(&rest.Config{Host: ts.URL})
+	require.NoError(t, err)
+
+	dc := kubeClient.Discovery()
+	return newMutationClient(dc, dc.RESTClient())
+}
+
+func newConfigMap() *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"namespace": "default",
+				"name":      "cm",
+			},
+			"data": map[string]interface{}{"key": "value"},
+		},
+	}
+}
+
+func Test_mutationClient_Update(t *testing.T) {
+	cases := []struct {
+		name           string
+		object         *unstructured.Unstructured
+		dryRun         bool
+		expectedPath   string
+		expectedDryRun string
+	}{
+		{
+			name:           "dry run",
+			object:         newConfigMap(),
+			dryRun:         true,
+			expectedPath:   "/api/v1/namespaces/default/configmaps/cm",
+			expectedDryRun: "All",
+		},
+		{
+			name:         "update",
+			object:       newConfigMap(),
+			expectedPath: "/api/v1/namespaces/default/configmaps/cm",
+		},
+		{
+			name: "cluster scoped",
+			object: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "v1",
+					"kind":       "Namespace",
+					"metadata":   map[string]interface{}{"name": "default"},
+				},
+			},
+			expectedPath: "/api/v1/namespaces/default",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			requests := make(chan mutationRequest, 1)
+			ts := newFakeMutationServer(t, "13", requests)
+			defer ts.Close()
+
+			mc := newTestMutationClient(t, ts)
+
+			got, err := mc.Update(tc.object, MutationOptions{DryRun: tc.dryRun})
+			require.NoError(t, err)
+			assert.Equal(t, tc.object, got)
+
+			put := <-requests
+			assert.Equal(t, http.MethodPut, put.method)
+			assert.Equal(t, tc.expectedPath, put.path)
+			assert.Equal(t, tc.expectedDryRun, put.query.Get("dryRun"))
+		})
+	}
+}
+
+func Test_mutationClient_Patch(t *testing.T) {
+	requests := make(chan mutationRequest, 1)
+	ts := newFakeMutationServer(t, "13", requests)
+	defer ts.Close()
+
+	mc := newTestMutationClient(t, ts)
+
+	patch := `{"data":{"key":"changed"}}`
+	_, err := mc.Patch(newConfigMap(), types.MergePatchType, []byte(patch))
+	require.NoError(t, err)
+
+	req := <-requests
+	assert.Equal(t, http.MethodPatch, req.method)
+	assert.Equal(t, "/api/v1/namespaces/default/configmaps/cm", req.path)
+	assert.Equal(t, string(types.MergePatchType), req.contentType)
+	assert.Equal(t, patch, req.body)
+}
+
+func Test_mutationClient_Delete(t *testing.T) {
+	requests := make(chan mutationRequest, 1)
+	ts := newFakeMutationServer(t, "13", requests)
+	defer ts.Close()
+
+	mc := newTestMutationClient(t, ts)
+
+	require.NoError(t, mc.Delete(newConfigMap(), metav1.DeletePropagationForeground))
+
+	req := <-requests
+	assert.Equal(t, http.MethodDelete, req.method)
+	assert.Equal(t, "/api/v1/namespaces/default/configmaps/cm", req.path)
+	assert.Contains(t, req.body, `"propagationPolicy":"Foreground"`)
+}
+
+func Test_mutationClient_caches_resources(t *testing.T) {
+	ts := newFakeMutationServer(t, "13", make(chan mutationRequest, 3))
+	defer ts.Close()
+
+	var discoveries int32
+	counting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1" {
+			atomic.AddInt32(&discoveries, 1)
+		}
+		ts.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer counting.Close()
+
+	mc := newTestMutationClient(t, counting)
+
+	_, err := mc.Update(newConfigMap(), MutationOptions{})
+	require.NoError(t, err)
+	require.NoError(t, mc.Delete(newConfigMap(), metav1.DeletePropagationForeground))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&discoveries))
+
+	// Kinds which aren't found are discovered again.
+	unknown := newConfigMap()
+	unknown.SetKind("Unknown")
+	require.Error(t, mc.Delete(unknown, metav1.DeletePropagationForeground))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&discoveries))
+}
+
+func Test_mutationClient_Update_dry_run_not_supported(t *testing.T) {
+	ts := newFakeMutationServer(t, "11", nil)
+	defer ts.Close()
+
+	mc := newTestMutationClient(t, ts)
+
+	_, err := mc.Update(newConfigMap(), MutationOptions{DryRun: true})
+	assert.Equal(t, ErrDryRunNotSupported, err)
+}
+
+func Test_supportsDryRun(t *testing.T) {
+	cases := []struct {
+		major    string
+		minor    string
+		expected bool
+	}{
+		{major: "1", minor: "11", expected: false},
+		{major: "1", minor: "13", expected: true},
+		{major: "1", minor: "14+", expected: true},
+		{major: "", minor: "", expected: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.major+"."+tc.minor, func(t *testing.T) {
+			got := supportsDryRun(&version.Info{Major: tc.major, Minor: tc.minor})
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
@@ -0,0 +1,438 @@
+package cluster
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"io/ioutil"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// PortForwardInterface manages port forwards.
+type PortForwardInterface interface {
+	Start(spec PortForwardSpec) (PortForwardState, error)
+	List() []PortForwardState
+	Get(id string) (PortForwardState, bool)
+	Stop(id string) error
+	StopAll()
+	SetClient(client ClientInterface) error
+}
+
+// Kinds which can be port forwarded.
+const (
+	PortForwardKindPod     = "Pod"
+	PortForwardKindService = "Service"
+)
+
+// PortForwardSpec describes a port forward. For services, Port is a service
+// port which is resolved to a container port on a pod backing the service.
+// If LocalPort is zero, a random local port is used.
+type PortForwardSpec struct {
+	Namespace string `json:"namespace"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Port      int32  `json:"port"`
+	LocalPort uint16 `json:"localPort,omitempty"`
+}
+
+// PortForwardState describes a running port forward.
+type PortForwardState struct {
+	ID         string          `json:"id"`
+	Spec       PortForwardSpec `json:"spec"`
+	Pod        string          `json:"pod"`
+	LocalPort  uint16          `json:"localPort"`
+	RemotePort uint16          `json:"remotePort"`
+	CreatedAt  time.Time       `json:"createdAt"`
+}
+
+// invalidPortForwardError is returned when a spec can't be forwarded, e.g.
+// its kind is unknown or its port can't be resolved to a container port.
+type invalidPortForwardError struct {
+	message string
+}
+
+func (e *invalidPortForwardError) Error() string {
+	return e.message
+}
+
+func invalidPortForwardf(format string, args ...interface{}) error {
+	return &invalidPortForwardError{message: fmt.Sprintf(format, args...)}
+}
+
+// IsPortForwardInvalid returns true if a port forward couldn't be started
+// because its spec can't be forwarded.
+func IsPortForwardInvalid(err error) bool {
+	_, ok := errors.Cause(err).(*invalidPortForwardError)
+	return ok
+}
+
+// IsPortForwardNotFound returns true if a port forward couldn't be started
+// because its pod or service doesn't exist.
+func IsPortForwardNotFound(err error) bool {
+	return apierrors.IsNotFound(errors.Cause(err))
+}
+
+// forwarder forwards ports to a pod. It is implemented by
+// portforward.PortForwarder.
+type forwarder interface {
+	ForwardPorts() error
+	GetPorts() ([]portforward.ForwardedPort, error)
+}
+
+// forwarderFactory creates a forwarder for a pod.
+type forwarderFactory func(namespace, pod string, ports []string, stopCh <-chan struct{}, readyCh chan struct{}) (forwarder, error)
+
+type portForward struct {
+	seq    int
+	state  PortForwardState
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+// PortForwarder starts and stops port forwards to pods and services.
+type PortForwarder struct {
+	kubeClient   kubernetes.Interface
+	newForwarder forwarderFactory
+
+	mu       sync.Mutex
+	nextID   int
+	forwards map[string]*portForward
+	// generation changes when every forward is stopped. Forwards which
+	// were starting at the time are stopped once they are ready.
+	generation int
+}
+
+var _ PortForwardInterface = (*PortForwarder)(nil)
+
+// NewPortForwarder creates an instance of PortForwarder.
+func NewPortForwarder(client ClientInterface) (*PortForwarder, error) {
+	kubeClient, err := client.KubernetesClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "creating kubernetes client")
+	}
+
+	return &PortForwarder{
+		kubeClient:   kubeClient,
+		newForwarder: spdyForwarderFactory(kubeClient, client.RESTConfig()),
+		forwards:     make(map[string]*portForward),
+	}, nil
+}
+
+// SetClient stops running and starting port forwards, and forwards new
+// ports using client. It is called when the dashboard switches to another
+// cluster.
+func (pf *PortForwarder) SetClient(client ClientInterface) error {
+	kubeClient, err := client.KubernetesClient()
+	if err != nil {
+		return errors.Wrap(err, "creating kubernetes client")
+	}
+
+	pf.mu.Lock()
+	pf.kubeClient = kubeClient
+	pf.newForwarder = spdyForwarderFactory(kubeClient, client.RESTConfig())
+	forwards := pf.takeForwards()
+	pf.mu.Unlock()
+
+	stopForwards(forwards)
+
+	return nil
+}
+
+// clients returns the clients new forwards use, and the generation forwards
+// started with them belong to.
+func (pf *PortForwarder) clients() (kubernetes.Interface, forwarderFactory, int) {
+	pf.mu.Lock()
+	defer pf.mu.Unlock()
+
+	return pf.kubeClient, pf.newForwarder, pf.generation
+}
+
+// takeForwards removes every forward and starts a new generation. pf.mu
+// must be held.
+func (pf *PortForwarder) takeForwards() map[string]*portForward {
+	forwards := pf.forwards
+	pf.forwards = make(map[string]*portForward)
+	pf.generation++
+	return forwards
+}
+
+// stopForwards stops forwards and waits for them to finish.
+func stopForwards(forwards map[string]*portForward) {
+	for _, forward := range forwards {
+		close(forward.stopCh)
+		<-forward.doneCh
+	}
+}
+
+// spdyForwarderFactory creates forwarders which connect to the
+// pods/portforward subresource over SPDY.
+func spdyForwarderFactory(kubeClient kubernetes.Interface, restConfig *rest.Config) forwarderFactory {
+	return func(namespace, pod string, ports []string, stopCh <-chan struct{}, readyCh chan struct{}) (forwarder, error) {
+		transport, upgrader, err := spdy.RoundTripperFor(restConfig)
+		if err != nil {
+			return nil, errors.Wrap(err, "creating round tripper")
+		}
+
+		u := kubeClient.CoreV1().RESTClient().Post().
+			Resource("pods").
+			Namespace(namespace).
+			Name(pod).
+			SubResource("portforward").
+			URL()
+
+		dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, u)
+
+		return portforward.New(dialer, ports, stopCh, readyCh, ioutil.Discard, ioutil.Discard)
+	}
+}
+
+// Start starts a port forward. It returns once the local port is listening.
+// The forward is stopped, and an error returned, if every forward was
+// stopped while it was starting.
+func (pf *PortForwarder) Start(spec PortForwardSpec) (PortForwardState, error) {
+	if spec.Namespace == "" {
+		return PortForwardState{}, invalidPortForwardf("namespace is required")
+	}
+
+	kubeClient, newForwarder, generation := pf.clients()
+
+	pod, remotePort, err := pf.resolve(kubeClient, spec)
+	if err != nil {
+		return PortForwardState{}, err
+	}
+
+	stopCh := make(chan struct{})
+	readyCh := make(chan struct{})
+	errCh := make(chan error, 1)
+
+	ports := []string{fmt.Sprintf("%d:%d", spec.LocalPort, remotePort)}
+	fw, err := newForwarder(spec.Namespace, pod.Name, ports, stopCh, readyCh)
+	if err != nil {
+		return PortForwardState{}, errors.Wrapf(err, "creating port forward to pod %s", pod.Name)
+	}
+
+	forward := &portForward{
+		stopCh: stopCh,
+		doneCh: make(chan struct{}),
+	}
+
+	go func() {
+		defer close(forward.doneCh)
+		errCh <- fw.ForwardPorts()
+	}()
+
+	select {
+	case err := <-errCh:
+		if err == nil {
+			err = errors.New("port forward exited")
+		}
+		return PortForwardState{}, errors.Wrapf(err, "forwarding ports to pod %s", pod.Name)
+	case <-readyCh:
+	}
+
+	forwarded, err := fw.GetPorts()
+	if err != nil || len(forwarded) == 0 {
+		close(stopCh)
+		return PortForwardState{}, errors.Errorf("unable to determine local port for pod %s", pod.Name)
+	}
+
+	pf.mu.Lock()
+	defer pf.mu.Unlock()
+
+	if pf.generation != generation {
+		close(stopCh)
+		return PortForwardState{}, errors.Errorf("port forward to pod %s was stopped while starting", pod.Name)
+	}
+
+	pf.nextID++
+	forward.seq = pf.nextID
+	forward.state = PortForwardState{
+		ID:         strconv.Itoa(pf.nextID),
+		Spec:       spec,
+		Pod:        pod.Name,
+		LocalPort:  forwarded[0].Local,
+		RemotePort: forwarded[0].Remote,
+		CreatedAt:  time.Now(),
+	}
+	pf.forwards[forward.state.ID] = forward
+
+	// Forget the forward if the connection to the pod ends.
+	go func(id string) {
+		<-forward.doneCh
+		pf.mu.Lock()
+		defer pf.mu.Unlock()
+		if pf.forwards[id] == forward {
+			delete(pf.forwards, id)
+		}
+	}(forward.state.ID)
+
+	return forward.state, nil
+}
+
+// List lists running port forwards in the order they were started.
+func (pf *PortForwarder) List() []PortForwardState {
+	pf.mu.Lock()
+	defer pf.mu.Unlock()
+
+	var forwards []*portForward
+	for _, forward := range pf.forwards {
+		forwards = append(forwards, forward)
+	}
+
+	sort.Slice(forwards, func(i, j int) bool {
+		return forwards[i].seq < forwards[j].seq
+	})
+
+	var list []PortForwardState
+	for _, forward := range forwards {
+		list = append(list, forward.state)
+	}
+
+	return list
+}
+
+// Get returns a port forward by ID.
+func (pf *PortForwarder) Get(id string) (PortForwardState, bool) {
+	pf.mu.Lock()
+	defer pf.mu.Unlock()
+
+	forward, ok := pf.forwards[id]
+	if !ok {
+		return PortForwardState{}, false
+	}
+
+	return forward.state, true
+}
+
+// Stop stops a port forward.
+func (pf *PortForwarder) Stop(id string) error {
+	pf.mu.Lock()
+	forward, ok := pf.forwards[id]
+	if ok {
+		delete(pf.forwards, id)
+	}
+	pf.mu.Unlock()
+
+	if !ok {
+		return errors.Errorf("port forward %q not found", id)
+	}
+
+	close(forward.stopCh)
+	<-forward.doneCh
+
+	return nil
+}
+
+// StopAll stops all port forwards, including forwards which are starting.
+func (pf *PortForwarder) StopAll() {
+	pf.mu.Lock()
+	forwards := pf.takeForwards()
+	pf.mu.Unlock()
+
+	stopForwards(forwards)
+}
+
+// resolve returns the pod and container port for a spec.
+func (pf *PortForwarder) resolve(kubeClient kubernetes.Interface, spec PortForwardSpec) (*corev1.Pod, int32, error) {
+	switch spec.Kind {
+	case PortForwardKindPod:
+		pod, err := kubeClient.CoreV1().Pods(spec.Namespace).Get(spec.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, 0, errors.Wrapf(err, "getting pod %s", spec.Name)
+		}
+		return pod, spec.Port, nil
+	case PortForwardKindService:
+		return pf.resolveService(kubeClient, spec)
+	default:
+		return nil, 0, invalidPortForwardf("unable to port forward to kind %q", spec.Kind)
+	}
+}
+
+// resolveService finds a running pod selected by a service and the container
+// port the service port targets.
+func (pf *PortForwarder) resolveService(kubeClient kubernetes.Interface, spec PortForwardSpec) (*corev1.Pod, int32, error) {
+	svc, err := kubeClient.CoreV1().Services(spec.Namespace).Get(spec.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "getting service %s", spec.Name)
+	}
+
+	if len(svc.Spec.Selector) == 0 {
+		return nil, 0, invalidPortForwardf("service %s does not have a selector", svc.Name)
+	}
+
+	var servicePort *corev1.ServicePort
+	for i := range svc.Spec.Ports {
+		if svc.Spec.Ports[i].Port == spec.Port {
+			servicePort = &svc.Spec.Ports[i]
+			break
+		}
+	}
+	if servicePort == nil {
+		return nil, 0, invalidPortForwardf("service %s does not expose port %d", svc.Name, spec.Port)
+	}
+
+	pods, err := kubeClient.CoreV1().Pods(svc.Namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "listing pods for service %s", svc.Name)
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+
+		// Match pods the same way the service's overview does
+		matches, err := SelectorMatches(ServiceSelector(svc.Spec.Selector), pod.Labels, false)
+		if err != nil {
+			return nil, 0, errors.Wrapf(err, "matching pods for service %s", svc.Name)
+		}
+		if !matches {
+			continue
+		}
+
+		if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil {
+			continue
+		}
+
+		port, err := containerPortForServicePort(pod, *servicePort)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		return pod, port, nil
+	}
+
+	return nil, 0, invalidPortForwardf("service %s does not have any running pods", svc.Name)
+}
+
+// containerPortForServicePort resolves a service port's target port on a pod.
+// Named target ports are looked up in the pod's containers.
+func containerPortForServicePort(pod *corev1.Pod, servicePort corev1.ServicePort) (int32, error) {
+	targetPort := servicePort.TargetPort
+
+	switch {
+	case targetPort.Type == intstr.Int && targetPort.IntVal == 0:
+		return servicePort.Port, nil
+	case targetPort.Type == intstr.Int:
+		return targetPort.IntVal, nil
+	}
+
+	for _, container := range pod.Spec.Containers {
+		for _, port := range container.Ports {
+			if port.Name == targetPort.StrVal {
+				return port.ContainerPort, nil
+			}
+		}
+	}
+
+	return 0, invalidPortForwardf("pod %s does not have a container port named %q", pod.Name, targetPort.StrVal)
+}
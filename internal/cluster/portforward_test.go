@@ -0,0 +1,230 @@
+package cluster
+
+import (
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/portforward"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type fakeForwarder struct {
+	ports   []string
+	stopCh  <-chan struct{}
+	readyCh chan struct{}
+	err     error
+}
+
+func (f *fakeForwarder) ForwardPorts() error {
+	if f.err != nil {
+		return f.err
+	}
+
+	close(f.readyCh)
+	<-f.stopCh
+	return nil
+}
+
+func (f *fakeForwarder) GetPorts() ([]portforward.ForwardedPort, error) {
+	parts := strings.Split(f.ports[0], ":")
+	local, _ := strconv.Atoi(parts[0])
+	remote, _ := strconv.Atoi(parts[1])
+	if local == 0 {
+		local = 40000
+	}
+
+	return []portforward.ForwardedPort{{Local: uint16(local), Remote: uint16(remote)}}, nil
+}
+
+type forwardRequest struct {
+	namespace string
+	pod       string
+	ports     []string
+}
+
+func newTestPortForwarder(forwardErr error) (*PortForwarder, *[]forwardRequest) {
+	objects := []runtime.Object{
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: "default"},
+			Spec: corev1.ServiceSpec{
+				Selector: map[string]string{"app": "web"},
+				Ports: []corev1.ServicePort{
+					{Port: 80, TargetPort: intstr.FromString("http")},
+					{Port: 443, TargetPort: intstr.FromInt(8443)},
+				},
+			},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: "default", Labels: map[string]string{"app": "web"}},
+			Status:     corev1.PodStatus{Phase: corev1.PodPending},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Labels: map[string]string{"app": "web"}},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "app", Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}}},
+				},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+	}
+
+	var requests []forwardRequest
+
+	pf := &PortForwarder{
+		kubeClient: fake.NewSimpleClientset(objects...),
+		newForwarder: func(namespace, pod string, ports []string, stopCh <-chan struct{}, readyCh chan struct{}) (forwarder, error) {
+			requests = append(requests, forwardRequest{namespace: namespace, pod: pod, ports: ports})
+			return &fakeForwarder{ports: ports, stopCh: stopCh, readyCh: readyCh, err: forwardErr}, nil
+		},
+		forwards: make(map[string]*portForward),
+	}
+
+	return pf, &requests
+}
+
+func TestPortForwarder(t *testing.T) {
+	pf, requests := newTestPortForwarder(nil)
+
+	state, err := pf.Start(PortForwardSpec{Namespace: "default", Kind: PortForwardKindPod, Name: "web", Port: 8080, LocalPort: 9000})
+	require.NoError(t, err)
+
+	assert.Equal(t, "1", state.ID)
+	assert.Equal(t, "web", state.Pod)
+	assert.Equal(t, "default", state.Spec.Namespace)
+	assert.Equal(t, uint16(9000), state.LocalPort)
+	assert.Equal(t, uint16(8080), state.RemotePort)
+
+	state2, err := pf.Start(PortForwardSpec{Namespace: "default", Kind: PortForwardKindService, Name: "svc", Port: 443})
+	require.NoError(t, err)
+	assert.Equal(t, uint16(40000), state2.LocalPort)
+
+	assert.Equal(t, []forwardRequest{
+		{namespace: "default", pod: "web", ports: []string{"9000:8080"}},
+		{namespace: "default", pod: "web", ports: []string{"0:8443"}},
+	}, *requests)
+
+	assert.Equal(t, []PortForwardState{state, state2}, pf.List())
+
+	got, ok := pf.Get("2")
+	require.True(t, ok)
+	assert.Equal(t, state2, got)
+
+	require.NoError(t, pf.Stop("1"))
+	require.Error(t, pf.Stop("1"))
+	assert.Equal(t, []PortForwardState{state2}, pf.List())
+
+	pf.StopAll()
+	assert.Empty(t, pf.List())
+}
+
+func TestPortForwarder_service_named_port(t *testing.T) {
+	pf, requests := newTestPortForwarder(nil)
+	defer pf.StopAll()
+
+	state, err := pf.Start(PortForwardSpec{Namespace: "default", Kind: PortForwardKindService, Name: "svc", Port: 80})
+	require.NoError(t, err)
+
+	assert.Equal(t, "web", state.Pod)
+	assert.Equal(t, []string{"0:8080"}, (*requests)[0].ports)
+}
+
+func TestPortForwarder_SetClient(t *testing.T) {
+	pf, _ := newTestPortForwarder(nil)
+	oldClient := pf.kubeClient
+
+	_, err := pf.Start(PortForwardSpec{Namespace: "default", Kind: PortForwardKindPod, Name: "web", Port: 8080})
+	require.NoError(t, err)
+
+	c, err := FromKubeconfig(filepath.Join("testdata", "kubeconfig.yaml"))
+	require.NoError(t, err)
+
+	require.NoError(t, pf.SetClient(c))
+
+	assert.Empty(t, pf.List())
+	assert.NotEqual(t, oldClient, pf.kubeClient)
+}
+
+func TestPortForwarder_errors(t *testing.T) {
+	cases := []struct {
+		name             string
+		spec             PortForwardSpec
+		forwardErr       error
+		expectedInvalid  bool
+		expectedNotFound bool
+	}{
+		{
+			name:            "missing namespace",
+			spec:            PortForwardSpec{Kind: PortForwardKindPod, Name: "web", Port: 80},
+			expectedInvalid: true,
+		},
+		{
+			name:            "unknown kind",
+			spec:            PortForwardSpec{Namespace: "default", Kind: "Deployment", Name: "web", Port: 80},
+			expectedInvalid: true,
+		},
+		{
+			name:             "missing pod",
+			spec:             PortForwardSpec{Namespace: "default", Kind: PortForwardKindPod, Name: "missing", Port: 80},
+			expectedNotFound: true,
+		},
+		{
+			name:             "missing service",
+			spec:             PortForwardSpec{Namespace: "default", Kind: PortForwardKindService, Name: "missing", Port: 80},
+			expectedNotFound: true,
+		},
+		{
+			name:            "missing service port",
+			spec:            PortForwardSpec{Namespace: "default", Kind: PortForwardKindService, Name: "svc", Port: 8000},
+			expectedInvalid: true,
+		},
+		{
+			name:       "forward fails",
+			spec:       PortForwardSpec{Namespace: "default", Kind: PortForwardKindPod, Name: "web", Port: 80},
+			forwardErr: errors.New("connection refused"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pf, _ := newTestPortForwarder(tc.forwardErr)
+
+			_, err := pf.Start(tc.spec)
+			require.Error(t, err)
+			assert.Equal(t, tc.expectedInvalid, IsPortForwardInvalid(err))
+			assert.Equal(t, tc.expectedNotFound, IsPortForwardNotFound(err))
+			assert.Empty(t, pf.List())
+		})
+	}
+}
+
+func TestPortForwarder_stopped_while_starting(t *testing.T) {
+	pf, _ := newTestPortForwarder(nil)
+
+	var stopCh <-chan struct{}
+	newForwarder := pf.newForwarder
+	pf.newForwarder = func(namespace, pod string, ports []string, fwStopCh <-chan struct{}, readyCh chan struct{}) (forwarder, error) {
+		stopCh = fwStopCh
+		// The dashboard switches clusters before the forward is ready.
+		pf.StopAll()
+		return newForwarder(namespace, pod, ports, fwStopCh, readyCh)
+	}
+
+	_, err := pf.Start(PortForwardSpec{Namespace: "default", Kind: PortForwardKindPod, Name: "web", Port: 8080})
+	require.Error(t, err)
+	assert.Empty(t, pf.List())
+
+	select {
+	case <-stopCh:
+	default:
+		t.Fatal("port forward was not stopped")
+	}
+}
@@ -0,0 +1,31 @@
+package cluster
+
+import (
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// SelectorMatches returns true if a label selector matches a set of labels.
+// Whether an empty selector matches everything or nothing depends on what is
+// selecting, so the caller decides with matchEmpty. Services without a
+// selector don't select any pods, while network policies with an empty pod
+// selector select every pod in their namespace.
+func SelectorMatches(labelSelector *metav1.LabelSelector, set map[string]string, matchEmpty bool) (bool, error) {
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return false, errors.Wrap(err, "invalid selector")
+	}
+
+	if selector.Empty() {
+		return matchEmpty, nil
+	}
+	return selector.Matches(labels.Set(set)), nil
+}
+
+// ServiceSelector returns the label selector for a service's pod selector.
+func ServiceSelector(selector map[string]string) *metav1.LabelSelector {
+	return &metav1.LabelSelector{
+		MatchLabels: selector,
+	}
+}
@@ -0,0 +1,61 @@
+package cluster
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func TestSelectorMatches(t *testing.T) {
+	podLabels := map[string]string{"app": "web", "tier": "frontend"}
+
+	cases := []struct {
+		name       string
+		selector   *metav1.LabelSelector
+		matchEmpty bool
+		expected   bool
+		isErr      bool
+	}{
+		{
+			name:     "subset of labels",
+			selector: ServiceSelector(map[string]string{"app": "web"}),
+			expected: true,
+		},
+		{
+			name:     "different labels",
+			selector: ServiceSelector(map[string]string{"app": "db"}),
+			expected: false,
+		},
+		{
+			name:     "empty selector matches nothing",
+			selector: ServiceSelector(nil),
+			expected: false,
+		},
+		{
+			name:       "empty selector matches everything",
+			selector:   &metav1.LabelSelector{},
+			matchEmpty: true,
+			expected:   true,
+		},
+		{
+			name: "invalid selector",
+			selector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "bad"}},
+			},
+			isErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := SelectorMatches(tc.selector, podLabels, tc.matchEmpty)
+			if tc.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
@@ -11,8 +11,10 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/homedir"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"strconv"
 	"time"
 )
@@ -21,6 +23,9 @@ func newDashCmd() *cobra.Command {
 	var namespace string
 	var uiURL string
 	var kubeconfig string
+	var pluginDir string
+	var execCommands []string
+	var clusterContexts []string
 	var verboseLevel int
 
 	dashCmd := &cobra.Command{
@@ -51,7 +56,7 @@ func newDashCmd() *cobra.Command {
 			startTime := time.Now()
 
 			go func() {
-				if err := dash.Run(ctx, namespace, uiURL, kubeconfig, logger, telemetryClient); err != nil {
+				if err := dash.Run(ctx, namespace, uiURL, kubeconfig, pluginDir, execCommands, clusterContexts, logger, telemetryClient); err != nil {
 					logger.Errorf("running dashboard: %v", err)
 					os.Exit(1)
 				}
@@ -82,12 +87,17 @@ func newDashCmd() *cobra.Command {
 
 	dashCmd.Flags().StringVarP(&namespace, "namespace", "n", "", "initial namespace")
 	dashCmd.Flags().StringVar(&uiURL, "ui-url", "", "dashboard url")
+	dashCmd.Flags().StringSliceVar(&execCommands, "exec-command", nil, "commands which can be run in containers (default: any command)")
+	dashCmd.Flags().StringSliceVar(&clusterContexts, "cluster-context", nil, "kubeconfig contexts to show alongside the current context")
 	dashCmd.Flags().CountVarP(&verboseLevel, "verbose", "v", "verbosity level")
 
 	kubeconfig = clientcmd.NewDefaultClientConfigLoadingRules().GetDefaultFilename()
 
 	dashCmd.Flags().StringVar(&kubeconfig, "kubeconfig", kubeconfig, "absolute path to kubeconfig file")
 
+	pluginDir = filepath.Join(homedir.HomeDir(), ".kubeapt", "plugins")
+	dashCmd.Flags().StringVar(&pluginDir, "plugin-dir", pluginDir, "directory containing module plugins")
+
 	return dashCmd
 }
 
@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/twosson/kubeapt/internal/cluster"
+	"k8s.io/client-go/tools/clientcmd"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+)
+
+func newPortForwardCmd() *cobra.Command {
+	var namespace string
+	var kubeconfig string
+
+	portForwardCmd := &cobra.Command{
+		Use:   "port-forward TYPE/NAME [LOCAL_PORT:]REMOTE_PORT...",
+		Short: "Forward local ports to a pod or service",
+		Long: `Forward local ports to a pod or service. TYPE is pod or service. For
+services, REMOTE_PORT is a service port which is forwarded to a pod backing the
+service. If LOCAL_PORT is omitted, a random local port is used.`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			specs, err := portForwardSpecs(namespace, args[0], args[1:])
+			if err != nil {
+				return err
+			}
+
+			clusterClient, err := cluster.FromKubeconfig(kubeconfig)
+			if err != nil {
+				return errors.Wrap(err, "failed to init cluster client")
+			}
+
+			if namespace == "" {
+				nsClient, err := clusterClient.NamespaceClient()
+				if err != nil {
+					return errors.Wrap(err, "failed to create namespace client")
+				}
+				for i := range specs {
+					specs[i].Namespace = nsClient.InitialNamespace()
+				}
+			}
+
+			portForwarder, err := cluster.NewPortForwarder(clusterClient)
+			if err != nil {
+				return errors.Wrap(err, "failed to create port forwarder")
+			}
+			defer portForwarder.StopAll()
+
+			out := cmd.OutOrStdout()
+			for _, spec := range specs {
+				state, err := portForwarder.Start(spec)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(out, "Forwarding from 127.0.0.1:%d -> %s/%s:%d\n",
+					state.LocalPort, state.Spec.Namespace, state.Pod, state.RemotePort)
+			}
+
+			sigCh := make(chan os.Signal, 1)
+			signal.Notify(sigCh, os.Interrupt)
+			<-sigCh
+
+			return nil
+		},
+	}
+
+	portForwardCmd.Flags().StringVarP(&namespace, "namespace", "n", "", "namespace")
+
+	kubeconfig = clientcmd.NewDefaultClientConfigLoadingRules().GetDefaultFilename()
+	portForwardCmd.Flags().StringVar(&kubeconfig, "kubeconfig", kubeconfig, "absolute path to kubeconfig file")
+
+	return portForwardCmd
+}
+
+// portForwardSpecs creates port forward specs from command line arguments.
+func portForwardSpecs(namespace, target string, ports []string) ([]cluster.PortForwardSpec, error) {
+	parts := strings.SplitN(target, "/", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return nil, errors.Errorf("invalid target %q: expected TYPE/NAME", target)
+	}
+
+	var kind string
+	switch strings.ToLower(parts[0]) {
+	case "pod", "pods", "po":
+		kind = cluster.PortForwardKindPod
+	case "service", "services", "svc":
+		kind = cluster.PortForwardKindService
+	default:
+		return nil, errors.Errorf("invalid target type %q: expected pod or service", parts[0])
+	}
+
+	var specs []cluster.PortForwardSpec
+	for _, port := range ports {
+		spec := cluster.PortForwardSpec{
+			Namespace: namespace,
+			Kind:      kind,
+			Name:      parts[1],
+		}
+
+		remote := port
+		if i := strings.Index(port, ":"); i >= 0 {
+			if i > 0 {
+				local, err := strconv.ParseUint(port[:i], 10, 16)
+				if err != nil {
+					return nil, errors.Errorf("invalid local port in %q", port)
+				}
+				spec.LocalPort = uint16(local)
+			}
+			remote = port[i+1:]
+		}
+
+		remotePort, err := strconv.ParseInt(remote, 10, 32)
+		if err != nil || remotePort <= 0 {
+			return nil, errors.Errorf("invalid remote port in %q", port)
+		}
+		spec.Port = int32(remotePort)
+
+		specs = append(specs, spec)
+	}
+
+	return specs, nil
+}
@@ -28,6 +28,7 @@ func newRoot(version string, gitCommit string, buildTime string) *cobra.Command
 	}
 
 	rootCmd.AddCommand(newDashCmd())
+	rootCmd.AddCommand(newPortForwardCmd())
 	rootCmd.AddCommand(newVersionCmd(version, gitCommit, buildTime))
 
 	return rootCmd
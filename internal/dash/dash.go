@@ -7,6 +7,7 @@ import (
 	"github.com/heptio/go-telemetry/pkg/telemetry"
 	"github.com/pkg/errors"
 	"github.com/twosson/kubeapt/internal/api"
+	"github.com/twosson/kubeapt/internal/apt"
 	"github.com/twosson/kubeapt/internal/cluster"
 	"github.com/twosson/kubeapt/internal/log"
 	"github.com/twosson/kubeapt/internal/module"
@@ -24,12 +25,15 @@ import (
 )
 
 const (
-	apiPathPrefix       = "/api/v1"
+	apiPathPrefix       = apt.APIPathPrefix
 	defaultListenerAddr = "127.0.0.1:0"
 )
 
-// Run runs the dashboard.
-func Run(ctx context.Context, namespace, uiURL, kubeconfig string, logger log.Logger, telemetryClient telemetry.Interface) error {
+// Run runs the dashboard. Module plugins are loaded from pluginDir. If
+// execCommands is not empty, only those commands can be run in containers.
+// Each of clusterContexts is a kubeconfig context which is shown alongside
+// the current cluster.
+func Run(ctx context.Context, namespace, uiURL, kubeconfig, pluginDir string, execCommands, clusterContexts []string, logger log.Logger, telemetryClient telemetry.Interface) error {
 	logger.Debugf("Loading configuration: %v", kubeconfig)
 	clusterClient, err := cluster.FromKubeconfig(kubeconfig)
 	if err != nil {
@@ -53,13 +57,44 @@ func Run(ctx context.Context, namespace, uiURL, kubeconfig string, logger log.Lo
 		return errors.Wrap(err, "failed to create info client")
 	}
 
-	moduleManager, err := module.NewManager(clusterClient, namespace, logger)
+	moduleManager, err := module.NewManager(clusterClient, namespace, logger, module.WithPluginDir(pluginDir))
 	if err != nil {
 		return errors.Wrap(err, "create module manager")
 	}
 
+	portForwarder, err := cluster.NewPortForwarder(clusterClient)
+	if err != nil {
+		moduleManager.Unload()
+		return errors.Wrap(err, "create port forwarder")
+	}
+
+	contexts, err := newClusterContexts(kubeconfig, pluginDir, clusterContexts, logger)
+	if err != nil {
+		moduleManager.Unload()
+		portForwarder.StopAll()
+		return err
+	}
+
+	stop := func() {
+		moduleManager.Unload()
+		portForwarder.StopAll()
+		for _, c := range contexts {
+			c.stop()
+		}
+	}
+
+	apiOptions := []api.Option{
+		api.WithExecCommands(execCommands...),
+		api.WithPortForwarder(portForwarder),
+		api.WithContexts(cluster.NewKubeConfig(kubeconfig)),
+	}
+	for name, c := range contexts {
+		apiOptions = append(apiOptions, api.WithCluster(name, c.manager, c.portForwarder))
+	}
+
 	listener, err := buildListener()
 	if err != nil {
+		stop()
 		return errors.Wrap(err, "failed to create net listener")
 	}
 
@@ -74,8 +109,10 @@ func Run(ctx context.Context, namespace, uiURL, kubeconfig string, logger log.Lo
 		"kubernetes.version": version,
 	})
 
-	d, err := newDash(listener, namespace, uiURL, nsClient, infoClient, moduleManager, logger, telemetryClient)
+	d, err := newDash(listener, namespace, uiURL, nsClient, infoClient, moduleManager, logger, telemetryClient, apiOptions...)
 	if err != nil {
+		listener.Close()
+		stop()
 		return errors.Wrap(err, "failed to create dash instance")
 	}
 
@@ -90,11 +127,74 @@ func Run(ctx context.Context, namespace, uiURL, kubeconfig string, logger log.Lo
 	}()
 
 	<-ctx.Done()
-	moduleManager.Unload()
+	stop()
 
 	return nil
 }
 
+// clusterContext is a kubeconfig context which is shown alongside the
+// current cluster.
+type clusterContext struct {
+	manager       *module.Manager
+	portForwarder *cluster.PortForwarder
+}
+
+// stop unloads the context's modules and stops its port forwards.
+func (c *clusterContext) stop() {
+	c.manager.Unload()
+	c.portForwarder.StopAll()
+}
+
+// newClusterContexts creates a module manager and a port forwarder for each
+// kubeconfig context. The modules for each context use the context's
+// initial namespace.
+func newClusterContexts(kubeconfig, pluginDir string, contextNames []string, logger log.Logger) (map[string]*clusterContext, error) {
+	contexts := make(map[string]*clusterContext)
+
+	for _, name := range contextNames {
+		if _, ok := contexts[name]; ok {
+			continue
+		}
+
+		c, err := newClusterContext(kubeconfig, pluginDir, name, logger)
+		if err != nil {
+			for _, created := range contexts {
+				created.stop()
+			}
+			return nil, err
+		}
+
+		contexts[name] = c
+	}
+
+	return contexts, nil
+}
+
+func newClusterContext(kubeconfig, pluginDir, contextName string, logger log.Logger) (*clusterContext, error) {
+	clusterClient, err := cluster.FromKubeconfigContext(kubeconfig, contextName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to init cluster client for context %s", contextName)
+	}
+
+	nsClient, err := clusterClient.NamespaceClient()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create namespace client for context %s", contextName)
+	}
+
+	manager, err := module.NewManager(clusterClient, nsClient.InitialNamespace(), logger.With("context", contextName), module.WithPluginDir(pluginDir))
+	if err != nil {
+		return nil, errors.Wrapf(err, "create module manager for context %s", contextName)
+	}
+
+	portForwarder, err := cluster.NewPortForwarder(clusterClient)
+	if err != nil {
+		manager.Unload()
+		return nil, errors.Wrapf(err, "create port forwarder for context %s", contextName)
+	}
+
+	return &clusterContext{manager: manager, portForwarder: portForwarder}, nil
+}
+
 func buildListener() (net.Listener, error) {
 	listenerAddr := defaultListenerAddr
 	if customListenerAddr := os.Getenv("DASH_LISTENER_ADDR"); customListenerAddr != "" {
@@ -115,8 +215,8 @@ type dash struct {
 	telemetryClient telemetry.Interface
 }
 
-func newDash(listener net.Listener, namespace, uiURL string, nsClient cluster.NamespaceInterface, infoClient cluster.InfoInterface, moduleManager module.ManagerInterface, logger log.Logger, telemetryClient telemetry.Interface) (*dash, error) {
-	ah := api.New(apiPathPrefix, nsClient, infoClient, moduleManager, logger, telemetryClient)
+func newDash(listener net.Listener, namespace, uiURL string, nsClient cluster.NamespaceInterface, infoClient cluster.InfoInterface, moduleManager module.ManagerInterface, logger log.Logger, telemetryClient telemetry.Interface, apiOptions ...api.Option) (*dash, error) {
+	ah := api.New(apiPathPrefix, nsClient, infoClient, moduleManager, logger, telemetryClient, apiOptions...)
 
 	for _, m := range moduleManager.Modules() {
 		if err := ah.RegisterModule(m); err != nil {
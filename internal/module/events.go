@@ -0,0 +1,75 @@
+package module
+
+import (
+	"context"
+	"sync"
+)
+
+const (
+	// EventNamespaceChanged is the type of events sent when the namespace
+	// changes.
+	EventNamespaceChanged = "namespaceChanged"
+
+	// EventClusterChanged is the type of events sent when the cluster is
+	// switched. Clients reload all content, even if the namespace didn't
+	// change.
+	EventClusterChanged = "clusterChanged"
+)
+
+// Event is a change in the module manager which clients react to.
+type Event struct {
+	Type              string `json:"type"`
+	Namespace         string `json:"namespace,omitempty"`
+	PreviousNamespace string `json:"previousNamespace,omitempty"`
+}
+
+// eventBufferSize is the number of events buffered for a subscriber.
+// Events are dropped for subscribers which fall further behind.
+const eventBufferSize = 16
+
+// EventBroadcaster sends events to subscribers.
+type EventBroadcaster struct {
+	mu            sync.Mutex
+	subscriptions map[chan Event]bool
+}
+
+// NewEventBroadcaster creates an instance of EventBroadcaster.
+func NewEventBroadcaster() *EventBroadcaster {
+	return &EventBroadcaster{
+		subscriptions: make(map[chan Event]bool),
+	}
+}
+
+// Subscribe returns a channel which receives events. The channel is closed
+// when ctx is done.
+func (b *EventBroadcaster) Subscribe(ctx context.Context) <-chan Event {
+	ch := make(chan Event, eventBufferSize)
+
+	b.mu.Lock()
+	b.subscriptions[ch] = true
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		b.mu.Lock()
+		delete(b.subscriptions, ch)
+		close(ch)
+		b.mu.Unlock()
+	}()
+
+	return ch
+}
+
+// Broadcast sends an event to subscribers without blocking.
+func (b *EventBroadcaster) Broadcast(event Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscriptions {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
@@ -1,11 +1,19 @@
 package fake
 
-import "github.com/twosson/kubeapt/internal/module"
+import (
+	"context"
+	"github.com/twosson/kubeapt/internal/cluster"
+	"github.com/twosson/kubeapt/internal/module"
+)
 
 // StubManager is a stub for module.Module.
 type StubManager struct {
-	modules   []module.Module
-	namespace string
+	modules       []module.Module
+	namespace     string
+	clusterClient cluster.ClientInterface
+	unloadHooks   []func()
+	switchErr     error
+	events        *module.EventBroadcaster
 }
 
 // NewStubManager creates an instance of StubManager.
@@ -13,6 +21,7 @@ func NewStubManager(namespace string, modules []module.Module) *StubManager {
 	return &StubManager{
 		modules:   modules,
 		namespace: namespace,
+		events:    module.NewEventBroadcaster(),
 	}
 }
 
@@ -23,12 +32,69 @@ func (m *StubManager) Modules() []module.Module {
 	return m.modules
 }
 
-// SetNamespace sets the namespace
+// SetNamespace sets the namespace and broadcasts a namespaceChanged event.
 func (m *StubManager) SetNamespace(namespace string) {
+	previousNamespace := m.namespace
 	m.namespace = namespace
+	m.events.Broadcast(module.Event{
+		Type:              module.EventNamespaceChanged,
+		Namespace:         namespace,
+		PreviousNamespace: previousNamespace,
+	})
 }
 
 // GetNamespace returns the namespace
 func (m *StubManager) GetNamespace() string {
 	return m.namespace
 }
+
+// SetClusterClient sets the cluster client returned by ClusterClient.
+func (m *StubManager) SetClusterClient(clusterClient cluster.ClientInterface) {
+	m.clusterClient = clusterClient
+}
+
+// ClusterClient returns the cluster client.
+func (m *StubManager) ClusterClient() cluster.ClientInterface {
+	return m.clusterClient
+}
+
+// OnUnload registers a function which is called by Unload.
+func (m *StubManager) OnUnload(fn func()) {
+	m.unloadHooks = append(m.unloadHooks, fn)
+}
+
+// Unload calls the registered unload hooks.
+func (m *StubManager) Unload() {
+	for _, fn := range m.unloadHooks {
+		fn()
+	}
+	m.unloadHooks = nil
+}
+
+// SetSwitchClusterErr sets the error returned by SwitchCluster.
+func (m *StubManager) SetSwitchClusterErr(err error) {
+	m.switchErr = err
+}
+
+// SwitchCluster sets the cluster client and namespace, and broadcasts a
+// clusterChanged event.
+func (m *StubManager) SwitchCluster(clusterClient cluster.ClientInterface, namespace string) error {
+	if m.switchErr != nil {
+		return m.switchErr
+	}
+
+	previousNamespace := m.namespace
+	m.clusterClient = clusterClient
+	m.namespace = namespace
+	m.events.Broadcast(module.Event{
+		Type:              module.EventClusterChanged,
+		Namespace:         namespace,
+		PreviousNamespace: previousNamespace,
+	})
+	return nil
+}
+
+// Events returns a channel which receives the manager's events.
+func (m *StubManager) Events(ctx context.Context) <-chan module.Event {
+	return m.events.Subscribe(ctx)
+}
@@ -10,8 +10,9 @@ import (
 
 // Module is a fake module.
 type Module struct {
-	name   string
-	logger log.Logger
+	name          string
+	logger        log.Logger
+	navigationErr error
 }
 
 // NewModule creates an instance of Module.
@@ -55,6 +56,10 @@ func (m *Module) Handler(prefix string) http.Handler {
 
 // Navigation returns navigation entries for the module.
 func (m *Module) Navigation(prefix string) (*apt.Navigation, error) {
+	if m.navigationErr != nil {
+		return nil, m.navigationErr
+	}
+
 	nav := &apt.Navigation{
 		Path:  prefix,
 		Title: m.name,
@@ -63,6 +68,11 @@ func (m *Module) Navigation(prefix string) (*apt.Navigation, error) {
 	return nav, nil
 }
 
+// SetNavigationErr sets the error returned by Navigation.
+func (m *Module) SetNavigationErr(err error) {
+	m.navigationErr = err
+}
+
 // SetNamespace sets the current namespace.
 func (m *Module) SetNamespace(namespace string) error {
 	return nil
@@ -1,10 +1,13 @@
 package module
 
 import (
+	"context"
 	"github.com/pkg/errors"
 	"github.com/twosson/kubeapt/internal/cluster"
 	"github.com/twosson/kubeapt/internal/log"
 	"github.com/twosson/kubeapt/internal/overview"
+	"github.com/twosson/kubeapt/internal/plugin"
+	"sync"
 )
 
 // ManagerInterface is an interface for managing module lifecycle.
@@ -12,6 +15,10 @@ type ManagerInterface interface {
 	Modules() []Module
 	SetNamespace(namespace string)
 	GetNamespace() string
+	ClusterClient() cluster.ClientInterface
+	OnUnload(fn func())
+	SwitchCluster(clusterClient cluster.ClientInterface, namespace string) error
+	Events(ctx context.Context) <-chan Event
 }
 
 // Manager manages module lifecycle.
@@ -19,17 +26,41 @@ type Manager struct {
 	clusterClient cluster.ClientInterface
 	namespace     string
 	logger        log.Logger
+	pluginDir     string
 	loadedModules []Module
+
+	mu          sync.Mutex
+	unloadHooks []func()
+
+	events *EventBroadcaster
 }
 
 var _ ManagerInterface = (*Manager)(nil)
 
+var _ Module = (*plugin.Module)(nil)
+
+// ManagerOption is an option for configuring Manager.
+type ManagerOption func(m *Manager)
+
+// WithPluginDir loads module plugins from a directory in addition to the
+// built-in modules.
+func WithPluginDir(dir string) ManagerOption {
+	return func(m *Manager) {
+		m.pluginDir = dir
+	}
+}
+
 // NewManager creates an instance of Manager.
-func NewManager(clusterClient cluster.ClientInterface, namespace string, logger log.Logger) (*Manager, error) {
+func NewManager(clusterClient cluster.ClientInterface, namespace string, logger log.Logger, opts ...ManagerOption) (*Manager, error) {
 	manager := &Manager{
 		clusterClient: clusterClient,
 		namespace:     namespace,
 		logger:        logger,
+		events:        NewEventBroadcaster(),
+	}
+
+	for _, opt := range opts {
+		opt(manager)
 	}
 
 	if err := manager.Load(); err != nil {
@@ -41,6 +72,13 @@ func NewManager(clusterClient cluster.ClientInterface, namespace string, logger
 
 // Load loads modules.
 func (m *Manager) Load() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.load()
+}
+
+func (m *Manager) load() error {
 	overviewModule, err := overview.NewClusterOverview(m.clusterClient, m.namespace, m.logger)
 	if err != nil {
 		return errors.Wrap(err, "loading overview module")
@@ -50,38 +88,190 @@ func (m *Manager) Load() error {
 
 	for _, module := range modules {
 		if err := module.Start(); err != nil {
+			for _, created := range modules {
+				created.Stop()
+			}
 			return errors.Wrapf(err, "%s module failed to start", module.Name())
 		}
 	}
 
-	m.loadedModules = modules
+	m.loadedModules = append(modules, m.loadPlugins(modules)...)
 
 	return nil
 }
 
+// loadPlugins starts the plugins in the plugin directory. Plugins which
+// fail to load, or which use the content path of another module, are logged
+// and skipped, so a broken plugin doesn't prevent the dashboard from
+// starting.
+func (m *Manager) loadPlugins(loaded []Module) []Module {
+	contentPaths := make(map[string]bool)
+	for _, module := range loaded {
+		contentPaths[module.ContentPath()] = true
+	}
+
+	paths, err := plugin.Discover(m.pluginDir)
+	if err != nil {
+		m.logger.Errorf("discovering plugins: %v", err)
+		return nil
+	}
+
+	var modules []Module
+	for _, p := range paths {
+		logger := m.logger.With("plugin", p)
+
+		pluginModule, err := plugin.Load(p, logger)
+		if err != nil {
+			logger.Errorf("loading plugin: %v", err)
+			continue
+		}
+
+		if contentPaths[pluginModule.ContentPath()] {
+			logger.Errorf("content path %s is already used by another module", pluginModule.ContentPath())
+			pluginModule.Stop()
+			continue
+		}
+
+		if err := pluginModule.SetNamespace(m.namespace); err != nil {
+			logger.Errorf("setting plugin namespace: %v", err)
+			pluginModule.Stop()
+			continue
+		}
+
+		if err := pluginModule.Start(); err != nil {
+			logger.Errorf("starting plugin: %v", err)
+			pluginModule.Stop()
+			continue
+		}
+
+		logger.With("module", pluginModule.Name()).Infof("loaded plugin")
+		contentPaths[pluginModule.ContentPath()] = true
+		modules = append(modules, pluginModule)
+	}
+
+	return modules
+}
+
 // Modules returns a list of modules.
 func (m *Manager) Modules() []Module {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	return m.loadedModules
 }
 
-// Unload unloads modules.
+// Unload unloads modules. Hooks registered with OnUnload are called after
+// the modules are stopped.
 func (m *Manager) Unload() {
+	m.mu.Lock()
+	m.stopModules()
+	hooks := m.unloadHooks
+	m.unloadHooks = nil
+	m.mu.Unlock()
+
+	for _, fn := range hooks {
+		fn()
+	}
+}
+
+func (m *Manager) stopModules() {
 	for _, module := range m.loadedModules {
 		module.Stop()
 	}
+	m.loadedModules = nil
+}
+
+// SwitchCluster stops the modules and loads them again for another cluster,
+// so their caches are rebuilt from the new cluster. A clusterChanged event
+// is broadcast. If the modules can't be loaded, the previous cluster is
+// restored.
+func (m *Manager) SwitchCluster(clusterClient cluster.ClientInterface, namespace string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	previousClient, previousNamespace := m.clusterClient, m.namespace
+
+	m.stopModules()
+	m.clusterClient = clusterClient
+	m.namespace = namespace
+
+	if err := m.load(); err != nil {
+		m.clusterClient = previousClient
+		m.namespace = previousNamespace
+		if restoreErr := m.load(); restoreErr != nil {
+			m.logger.Errorf("restoring modules: %v", restoreErr)
+		}
+		return errors.Wrap(err, "switching cluster")
+	}
+
+	// Content streams from the stopped modules have been closed, and the
+	// namespace may be unchanged, so clients are always told to reload.
+	m.events.Broadcast(Event{
+		Type:              EventClusterChanged,
+		Namespace:         namespace,
+		PreviousNamespace: previousNamespace,
+	})
+
+	return nil
 }
 
-// SetNamespace sets the current namespace.
+// OnUnload registers a function which is called when modules are unloaded.
+// It is used to tear down resources which depend on the modules, e.g.
+// long running exec sessions.
+func (m *Manager) OnUnload(fn func()) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.unloadHooks = append(m.unloadHooks, fn)
+}
+
+// ClusterClient returns the cluster client used by the modules.
+func (m *Manager) ClusterClient() cluster.ClientInterface {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.clusterClient
+}
+
+// SetNamespace sets the current namespace. A namespaceChanged event is
+// broadcast if the namespace changed.
 func (m *Manager) SetNamespace(namespace string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	previousNamespace := m.namespace
 	m.namespace = namespace
 	for _, module := range m.loadedModules {
 		if err := module.SetNamespace(namespace); err != nil {
 			m.logger.Errorf("setting namespace for module %q: %v", module.Name(), err)
 		}
 	}
+
+	m.broadcastNamespace(namespace, previousNamespace)
+}
+
+func (m *Manager) broadcastNamespace(namespace, previousNamespace string) {
+	if namespace == previousNamespace {
+		return
+	}
+
+	m.events.Broadcast(Event{
+		Type:              EventNamespaceChanged,
+		Namespace:         namespace,
+		PreviousNamespace: previousNamespace,
+	})
+}
+
+// Events returns a channel which receives the manager's events until ctx
+// is done.
+func (m *Manager) Events(ctx context.Context) <-chan Event {
+	return m.events.Subscribe(ctx)
 }
 
 // GetNamespace gets the current namespace.
 func (m *Manager) GetNamespace() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	return m.namespace
 }
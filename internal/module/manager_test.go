@@ -1,12 +1,16 @@
 package module
 
 import (
+	"context"
 	"github.com/stretchr/testify/require"
 	"github.com/twosson/kubeapt/internal/cluster"
 	"github.com/twosson/kubeapt/internal/cluster/fake"
 	"github.com/twosson/kubeapt/internal/log"
+	"io/ioutil"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -27,7 +31,98 @@ func TestManager(t *testing.T) {
 	require.Len(t, modules, 1)
 
 	manager.SetNamespace("other")
+
+	var unloaded bool
+	manager.OnUnload(func() { unloaded = true })
+
 	manager.Unload()
+	require.True(t, unloaded)
+}
+
+func TestManager_invalidPlugin(t *testing.T) {
+	dir, err := ioutil.TempDir("", "plugins")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	script := "#!/bin/sh\necho not a plugin\n"
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "invalid"), []byte(script), 0755))
+
+	clusterClient, err := fake.NewClient(runtime.NewScheme(), nil, nil)
+	require.NoError(t, err)
+
+	manager, err := NewManager(clusterClient, "default", log.NopLogger(), WithPluginDir(dir))
+	require.NoError(t, err)
+	defer manager.Unload()
+
+	modules := manager.Modules()
+	require.Len(t, modules, 1)
+	require.Equal(t, "overview", modules[0].Name())
+}
+
+func TestManager_SwitchCluster(t *testing.T) {
+	clusterClient, err := fake.NewClient(runtime.NewScheme(), nil, nil)
+	require.NoError(t, err)
+
+	manager, err := NewManager(clusterClient, "default", log.NopLogger())
+	require.NoError(t, err)
+	defer manager.Unload()
+
+	previous := manager.Modules()
+
+	otherClient, err := fake.NewClient(runtime.NewScheme(), nil, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, manager.SwitchCluster(otherClient, "other"))
+
+	require.Equal(t, otherClient, manager.ClusterClient())
+	require.Equal(t, "other", manager.GetNamespace())
+	require.Len(t, manager.Modules(), 1)
+	require.False(t, previous[0] == manager.Modules()[0], "expected modules to be reloaded")
+
+	var badClient cluster.ClientInterface
+	require.Error(t, manager.SwitchCluster(badClient, "bad"))
+
+	require.Equal(t, otherClient, manager.ClusterClient())
+	require.Equal(t, "other", manager.GetNamespace())
+	require.Len(t, manager.Modules(), 1)
+}
+
+func TestManager_Events(t *testing.T) {
+	clusterClient, err := fake.NewClient(runtime.NewScheme(), nil, nil)
+	require.NoError(t, err)
+
+	manager, err := NewManager(clusterClient, "default", log.NopLogger())
+	require.NoError(t, err)
+	defer manager.Unload()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := manager.Events(ctx)
+
+	manager.SetNamespace("other")
+	// Setting the same namespace doesn't send an event.
+	manager.SetNamespace("other")
+	require.NoError(t, manager.SwitchCluster(clusterClient, "kube-system"))
+	// Switching cluster sends an event even if the namespace is unchanged.
+	require.NoError(t, manager.SwitchCluster(clusterClient, "kube-system"))
+
+	expected := []Event{
+		{Type: EventNamespaceChanged, Namespace: "other", PreviousNamespace: "default"},
+		{Type: EventClusterChanged, Namespace: "kube-system", PreviousNamespace: "other"},
+		{Type: EventClusterChanged, Namespace: "kube-system", PreviousNamespace: "kube-system"},
+	}
+	for _, e := range expected {
+		require.Equal(t, e, <-events)
+	}
+
+	select {
+	case e := <-events:
+		t.Fatalf("unexpected event %v", e)
+	default:
+	}
+
+	cancel()
+	for range events {
+	}
 }
 
 func TestManager_badClient(t *testing.T) {
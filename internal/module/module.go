@@ -1,6 +1,7 @@
 package module
 
 import (
+	"context"
 	"github.com/twosson/kubeapt/internal/apt"
 	"net/http"
 )
@@ -15,3 +16,8 @@ type Module interface {
 	Start() error
 	Stop()
 }
+
+// Searcher is implemented by modules which can find objects.
+type Searcher interface {
+	Search(ctx context.Context, query apt.SearchQuery) ([]apt.SearchResult, error)
+}
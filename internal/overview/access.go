@@ -0,0 +1,165 @@
+package overview
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/twosson/kubeapt/internal/apt"
+	"github.com/twosson/kubeapt/internal/cluster"
+	"github.com/twosson/kubeapt/internal/log"
+	"github.com/twosson/kubeapt/pkg/content"
+	apiextv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// forbiddenError is returned when the user isn't allowed to list objects.
+// The namespace is empty for cluster scoped objects and for objects in
+// every namespace.
+type forbiddenError struct {
+	resource  string
+	namespace string
+}
+
+func (e *forbiddenError) Error() string {
+	if e.namespace == "" {
+		return fmt.Sprintf("you are not allowed to list %s in the cluster", e.resource)
+	}
+	return fmt.Sprintf("you are not allowed to list %s in namespace %s", e.resource, e.namespace)
+}
+
+// isForbidden returns true if err was caused by a forbiddenError.
+func isForbidden(err error) bool {
+	_, ok := errors.Cause(err).(*forbiddenError)
+	return ok
+}
+
+// forbiddenContent creates content which explains what the user isn't
+// allowed to list.
+func forbiddenContent(err error) content.Content {
+	forbidden := content.NewForbidden("Forbidden", errors.Cause(err).Error())
+	return &forbidden
+}
+
+// forbiddenContentResponse creates a response which explains why content
+// can't be shown.
+func forbiddenContentResponse(err error) ContentResponse {
+	return ContentResponse{
+		Title: "Forbidden",
+		Views: []Content{
+			{Contents: []content.Content{forbiddenContent(err)}},
+		},
+	}
+}
+
+// listAllowed reports whether the user can list and watch a resource, which
+// is what an informer needs.
+func listAllowed(access cluster.AccessInterface, gvr schema.GroupVersionResource, namespace string) (bool, error) {
+	for _, verb := range []string{"list", "watch"} {
+		allowed, err := access.Allowed(cluster.ResourceAccess{
+			Namespace: namespace,
+			Verb:      verb,
+			Group:     gvr.Group,
+			Resource:  gvr.Resource,
+		})
+		if err != nil {
+			return false, err
+		}
+		if !allowed {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// navigationResources returns the objects listed by navigation entries,
+// keyed by path relative to the overview root.
+func navigationResources(crds []*apiextv1beta1.CustomResourceDefinition) map[string]CacheKey {
+	keys := make(map[string]CacheKey)
+	describerResources(rootDescriber, keys)
+	describerResources(eventsDescriber, keys)
+
+	for _, crd := range crds {
+		keys[path.Join(customResourcesPath, crd.Name)] = CacheKey{
+			APIVersion: crdAPIVersion(crd),
+			Kind:       crd.Spec.Names.Kind,
+		}
+	}
+
+	return keys
+}
+
+func describerResources(d Describer, keys map[string]CacheKey) {
+	switch d := d.(type) {
+	case *SectionDescriber:
+		for _, child := range d.describers {
+			describerResources(child, keys)
+		}
+	case *Resource:
+		keys[d.Path] = d.CacheKey
+	case *CustomResourceDefinitionsDescriber:
+		keys[d.path] = crdCacheKey
+	}
+}
+
+// navigationAccess checks whether the user can list the objects shown by
+// navigation entries.
+type navigationAccess struct {
+	access     cluster.AccessInterface
+	restMapper meta.RESTMapper
+	namespace  string
+	logger     log.Logger
+}
+
+// allowed reports whether objects for a cache key can be listed. Entries are
+// shown if access can't be checked, since the content explains the problem.
+func (na *navigationAccess) allowed(key CacheKey) bool {
+	gvk := schema.FromAPIVersionAndKind(key.APIVersion, key.Kind)
+
+	restMapping, err := na.restMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		na.logger.With("gvk", gvk.String()).Debugf("mapping navigation resource: %v", err)
+		return true
+	}
+
+	namespace := scopedNamespace(restMapping, na.namespace)
+	if namespace == AllNamespaces {
+		namespace = ""
+	}
+
+	allowed, err := listAllowed(na.access, restMapping.Resource, namespace)
+	if err != nil {
+		na.logger.With("resource", restMapping.Resource.String()).Errorf("checking navigation access: %v", err)
+		return true
+	}
+
+	return allowed
+}
+
+// filterNavigation removes navigation entries for objects which can't be
+// listed. Sections are removed if none of their entries remain.
+func filterNavigation(nav *apt.Navigation, root string, keys map[string]CacheKey, allowed func(CacheKey) bool) {
+	var children []*apt.Navigation
+
+	for _, child := range nav.Children {
+		relative := "/" + strings.Trim(strings.TrimPrefix(child.Path, root), "/")
+
+		if key, ok := keys[relative]; ok && !allowed(key) {
+			continue
+		}
+
+		if len(child.Children) > 0 {
+			filterNavigation(child, root, keys, allowed)
+			if len(child.Children) == 0 {
+				continue
+			}
+		}
+
+		children = append(children, child)
+	}
+
+	nav.Children = children
+}
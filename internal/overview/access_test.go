@@ -0,0 +1,170 @@
+package overview
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twosson/kubeapt/internal/apt"
+	"github.com/twosson/kubeapt/internal/cluster"
+	"github.com/twosson/kubeapt/internal/cluster/fake"
+	"github.com/twosson/kubeapt/internal/log"
+	"github.com/twosson/kubeapt/pkg/content"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func Test_isForbidden(t *testing.T) {
+	err := &forbiddenError{resource: "pods", namespace: "default"}
+
+	assert.True(t, isForbidden(err))
+	assert.True(t, isForbidden(errors.Wrap(err, "loading objects")))
+	assert.False(t, isForbidden(errors.New("error")))
+
+	assert.Equal(t, "you are not allowed to list pods in namespace default", err.Error())
+}
+
+func Test_filterNavigation(t *testing.T) {
+	nav, err := navigationEntries("/content/overview", nil)
+	require.NoError(t, err)
+
+	denied := map[string]bool{
+		"CronJob":            true,
+		"Ingress":            true,
+		"Service":            true,
+		"Node":               true,
+		"ClusterRole":        true,
+		"ClusterRoleBinding": true,
+	}
+	allowed := func(key CacheKey) bool {
+		return !denied[key.Kind]
+	}
+
+	filterNavigation(nav, "/content/overview", navigationResources(nil), allowed)
+
+	titles := make(map[string][]string)
+	for _, child := range nav.Children {
+		for _, grandchild := range child.Children {
+			titles[child.Title] = append(titles[child.Title], grandchild.Title)
+		}
+	}
+
+	assert.NotContains(t, titles["Workloads"], "Cron Jobs")
+	assert.Contains(t, titles["Workloads"], "Pods")
+	assert.NotContains(t, titles, "Discovery and Load Balancing")
+	assert.NotContains(t, titles["Cluster"], "Nodes")
+	assert.Contains(t, titles["Cluster"], "Namespaces")
+
+	var sections []string
+	for _, child := range nav.Children {
+		sections = append(sections, child.Title)
+	}
+	assert.Contains(t, sections, "Custom Resources")
+	assert.Contains(t, sections, "Events")
+}
+
+func TestClusterOverview_Navigation_forbidden(t *testing.T) {
+	clusterClient, err := fake.NewClient(runtime.NewScheme(), resources, nil)
+	require.NoError(t, err)
+
+	clusterClient.FakeAccess.Denied = []cluster.ResourceAccess{
+		{Verb: "list", Group: "apps", Resource: "deployments"},
+		{Verb: "watch", Resource: "services"},
+	}
+
+	o, err := NewClusterOverview(clusterClient, "default", log.NopLogger())
+	require.NoError(t, err)
+	defer o.Stop()
+
+	nav, err := o.Navigation("/content/overview")
+	require.NoError(t, err)
+
+	var paths []string
+	var walk func(*apt.Navigation)
+	walk = func(n *apt.Navigation) {
+		paths = append(paths, n.Path)
+		for _, child := range n.Children {
+			walk(child)
+		}
+	}
+	walk(nav)
+
+	assert.NotContains(t, paths, "/content/overview/workloads/deployments")
+	assert.NotContains(t, paths, "/content/overview/discovery-and-load-balancing/services")
+	assert.Contains(t, paths, "/content/overview/discovery-and-load-balancing/ingresses")
+}
+
+func TestInformerCache_Retrieve_forbidden(t *testing.T) {
+	clusterClient, err := fake.NewClient(newScheme(), resources, nil)
+	require.NoError(t, err)
+
+	access := &fake.AccessClient{
+		Denied: []cluster.ResourceAccess{
+			{Namespace: "default", Verb: "list", Group: "apps", Resource: "deployments"},
+		},
+	}
+
+	restMapper, err := clusterClient.RESTMapper()
+	require.NoError(t, err)
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	c := NewInformerCache(stopCh, clusterClient.FakeDynamic, restMapper, InformerCacheAccessOpt(access))
+
+	_, err = c.Retrieve(context.Background(), CacheKey{Namespace: "default", APIVersion: "apps/v1", Kind: "Deployment"})
+	require.Error(t, err)
+	assert.True(t, isForbidden(err))
+
+	objects, err := c.Retrieve(context.Background(), CacheKey{Namespace: "other", APIVersion: "apps/v1", Kind: "Deployment"})
+	require.NoError(t, err)
+	assert.Empty(t, objects)
+}
+
+func Test_realGenerator_forbidden(t *testing.T) {
+	describer := &forbiddenDescriber{resource: "pods"}
+	pathFilters := []pathFilter{*newPathFilter("/pods", describer)}
+
+	g := newGenerator(NewMemoryCache(), pathFilters, nil)
+
+	got, err := g.Generate(context.Background(), "/pods", testPrefix, "default")
+	require.NoError(t, err)
+
+	forbidden := content.NewForbidden("Forbidden", "you are not allowed to list pods in namespace default")
+	expected := ContentResponse{
+		Title: "Forbidden",
+		Views: []Content{
+			{Contents: []content.Content{&forbidden}},
+		},
+	}
+	assert.Equal(t, expected, got)
+}
+
+func TestSectionDescriber_forbidden(t *testing.T) {
+	forbidden := &forbiddenDescriber{resource: "pods"}
+	empty := newEmptyDescriber("/empty")
+
+	ctx := context.Background()
+
+	d := NewSectionDescriber("/section", "Section", forbidden, empty)
+	got, err := d.Describe(ctx, testPrefix, "default", nil, DescriberOptions{})
+	require.NoError(t, err)
+	require.Len(t, got.Views, 1)
+
+	d = NewSectionDescriber("/section", "Section", forbidden, forbidden)
+	_, err = d.Describe(ctx, testPrefix, "default", nil, DescriberOptions{})
+	assert.True(t, isForbidden(err))
+}
+
+type forbiddenDescriber struct {
+	resource string
+}
+
+func (d *forbiddenDescriber) Describe(ctx context.Context, prefix, namespace string, clusterClient cluster.ClientInterface, options DescriberOptions) (ContentResponse, error) {
+	return emptyContentResponse, &forbiddenError{resource: d.resource, namespace: namespace}
+}
+
+func (d *forbiddenDescriber) PathFilters(namespace string) []pathFilter {
+	return nil
+}
@@ -0,0 +1,334 @@
+package overview
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/twosson/kubeapt/internal/apt"
+	"github.com/twosson/kubeapt/internal/cluster"
+	"github.com/twosson/kubeapt/internal/log"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/kubernetes/pkg/apis/extensions"
+)
+
+// Workload actions.
+const (
+	actionScale    = "scale"
+	actionRestart  = "restart"
+	actionPause    = "pause"
+	actionResume   = "resume"
+	actionDelete   = "delete"
+	actionRollback = "rollback"
+)
+
+// restartedAtAnnotation is set on a pod template to restart a workload's
+// pods. It is the same annotation kubectl uses.
+const restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+var actionTitles = map[string]string{
+	actionScale:    "Scale",
+	actionRestart:  "Restart",
+	actionPause:    "Pause",
+	actionResume:   "Resume",
+	actionDelete:   "Delete",
+	actionRollback: "Roll Back",
+}
+
+// actionResources are the resources which have actions.
+var actionResources = []*Resource{
+	workloadsDaemonSets,
+	workloadsDeployments,
+	workloadsReplicaSets,
+	workloadsReplicationControllers,
+	workloadsStatefulSets,
+}
+
+// Action is an action which can be performed on an object. Actions are
+// performed by sending a POST request to Path.
+type Action struct {
+	Name  string `json:"name"`
+	Title string `json:"title"`
+	Path  string `json:"path"`
+}
+
+// actionPath returns the path for an action on an object in a resource.
+func actionPath(resourcePath, name, action string) string {
+	return path.Join(resourcePath, name, "actions", action)
+}
+
+// objectActions returns the actions available for an object shown by the
+// overview handler served from prefix. Paths of namespaced objects carry the
+// object's namespace. Pause and resume are only available if they would
+// change the object.
+func objectActions(prefix, resourcePath string, actions []string, object runtime.Object) []Action {
+	accessor, ok := object.(metav1.Object)
+	if !ok {
+		return nil
+	}
+
+	var query string
+	if namespace := accessor.GetNamespace(); namespace != "" {
+		query = "?" + url.Values{"namespace": []string{namespace}}.Encode()
+	}
+
+	var list []Action
+	for _, action := range actions {
+		if deployment, ok := object.(*extensions.Deployment); ok {
+			if (action == actionPause && deployment.Spec.Paused) || (action == actionResume && !deployment.Spec.Paused) {
+				continue
+			}
+		}
+
+		list = append(list, Action{
+			Name:  action,
+			Title: actionTitles[action],
+			Path:  path.Join(apt.LinkRoot(prefix), actionPath(resourcePath, accessor.GetName(), action)) + query,
+		})
+	}
+
+	return list
+}
+
+type scaleRequest struct {
+	Replicas *int32 `json:"replicas"`
+}
+
+type rollbackRequest struct {
+	Revision int64 `json:"revision"`
+}
+
+type deleteRequest struct {
+	PropagationPolicy metav1.DeletionPropagation `json:"propagationPolicy,omitempty"`
+}
+
+// actionHandler performs actions on objects of a resource.
+type actionHandler struct {
+	resource         *Resource
+	clusterClient    cluster.ClientInterface
+	cache            Cache
+	currentNamespace func() string
+	clock            clock.Clock
+	logger           log.Logger
+}
+
+var _ http.Handler = (*actionHandler)(nil)
+
+func newActionHandler(r *Resource, clusterClient cluster.ClientInterface, c Cache, currentNamespace func() string, logger log.Logger) *actionHandler {
+	return &actionHandler{
+		resource:         r,
+		clusterClient:    clusterClient,
+		cache:            c,
+		currentNamespace: currentNamespace,
+		clock:            &clock.RealClock{},
+		logger:           logger,
+	}
+}
+
+func (h *actionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	name := vars["name"]
+	action := vars["action"]
+
+	namespace := r.URL.Query().Get("namespace")
+	if namespace == "" {
+		namespace = h.currentNamespace()
+	}
+
+	logger := h.logger.With("kind", h.resource.CacheKey.Kind, "name", name, "namespace", namespace, "action", action)
+
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "actions must be performed with POST", logger)
+		return
+	}
+
+	if !containsString(h.resource.Actions, action) {
+		respondWithError(w, http.StatusNotFound, fmt.Sprintf("action %q is not available for %s", action, h.resource.Titles.List), logger)
+		return
+	}
+
+	key := h.resource.CacheKey
+	key.Namespace = namespace
+	key.Name = name
+
+	objects, err := h.cache.Retrieve(r.Context(), key)
+	if err != nil {
+		logger.Errorf("retrieving object: %v", err)
+		respondWithError(w, http.StatusInternalServerError, err.Error(), logger)
+		return
+	}
+	if len(objects) != 1 {
+		respondWithError(w, http.StatusNotFound, contentNotFound.Error(), logger)
+		return
+	}
+	object := objects[0]
+
+	mutationClient, err := h.clusterClient.MutationClient()
+	if err != nil {
+		logger.Errorf("creating mutation client: %v", err)
+		respondWithError(w, http.StatusInternalServerError, err.Error(), logger)
+		return
+	}
+
+	switch action {
+	case actionDelete:
+		var req deleteRequest
+		if err := decodeActionRequest(r, &req); err != nil {
+			respondWithError(w, http.StatusBadRequest, err.Error(), logger)
+			return
+		}
+
+		err = h.delete(mutationClient, object, req)
+	case actionRollback:
+		var patch []byte
+		patch, err = h.rollback(r, object)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, err.Error(), logger)
+			return
+		}
+
+		_, err = mutationClient.Patch(object, types.JSONPatchType, patch)
+	default:
+		var patch []byte
+		patch, err = h.patch(r, action)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, err.Error(), logger)
+			return
+		}
+
+		_, err = mutationClient.Patch(object, types.MergePatchType, patch)
+	}
+
+	if err != nil {
+		respondWithActionError(w, err, action, h.resource.Titles.Object, name, logger)
+		return
+	}
+
+	logger.Infof("performed action")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *actionHandler) delete(mutationClient cluster.MutationInterface, object *unstructured.Unstructured, req deleteRequest) error {
+	policy := req.PropagationPolicy
+	switch policy {
+	case "":
+		policy = metav1.DeletePropagationBackground
+	case metav1.DeletePropagationBackground, metav1.DeletePropagationForeground, metav1.DeletePropagationOrphan:
+	default:
+		return apierrors.NewBadRequest(fmt.Sprintf("invalid propagation policy %q", policy))
+	}
+
+	return mutationClient.Delete(object, policy)
+}
+
+// rollback creates a JSON patch which replaces an object's pod template with
+// the template from one of its revisions.
+func (h *actionHandler) rollback(r *http.Request, object *unstructured.Unstructured) ([]byte, error) {
+	var req rollbackRequest
+	if err := decodeActionRequest(r, &req); err != nil {
+		return nil, err
+	}
+	if req.Revision <= 0 {
+		return nil, errors.New("revision must be a positive integer")
+	}
+
+	if paused, _, _ := unstructured.NestedBool(object.Object, "spec", "paused"); paused {
+		return nil, errors.Errorf("unable to roll back paused %s %q", object.GetKind(), object.GetName())
+	}
+
+	revisions, err := listRevisions(r.Context(), h.cache, object.GetKind(), object)
+	if err != nil {
+		return nil, err
+	}
+
+	rev, ok := findRevision(revisions, req.Revision)
+	if !ok {
+		return nil, errors.Errorf("revision %d not found", req.Revision)
+	}
+
+	patch := []map[string]interface{}{
+		{
+			"op":    "replace",
+			"path":  "/spec/template",
+			"value": rev.Template,
+		},
+	}
+
+	return json.Marshal(patch)
+}
+
+// patch creates a merge patch for an action.
+func (h *actionHandler) patch(r *http.Request, action string) ([]byte, error) {
+	var patch map[string]interface{}
+
+	switch action {
+	case actionScale:
+		var req scaleRequest
+		if err := decodeActionRequest(r, &req); err != nil {
+			return nil, err
+		}
+		if req.Replicas == nil || *req.Replicas < 0 {
+			return nil, errors.New("replicas must be a non-negative integer")
+		}
+
+		patch = map[string]interface{}{
+			"spec": map[string]interface{}{"replicas": *req.Replicas},
+		}
+	case actionRestart:
+		patch = map[string]interface{}{
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"annotations": map[string]interface{}{
+							restartedAtAnnotation: h.clock.Now().UTC().Format(time.RFC3339),
+						},
+					},
+				},
+			},
+		}
+	case actionPause, actionResume:
+		patch = map[string]interface{}{
+			"spec": map[string]interface{}{"paused": action == actionPause},
+		}
+	default:
+		return nil, errors.Errorf("unknown action %q", action)
+	}
+
+	return json.Marshal(patch)
+}
+
+// decodeActionRequest decodes an optional JSON request body.
+func decodeActionRequest(r *http.Request, v interface{}) error {
+	if r.Body == nil || r.ContentLength == 0 {
+		return nil
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return errors.New("unable to decode request")
+	}
+
+	return nil
+}
+
+// respondWithActionError responds with the status of an API error. Forbidden
+// errors explain which action was denied, since RBAC commonly allows viewing
+// objects but not changing them.
+func respondWithActionError(w http.ResponseWriter, err error, action, kind, name string, logger log.Logger) {
+	if apierrors.IsForbidden(err) {
+		message := fmt.Sprintf("you are not allowed to %s %s %q: %v", action, kind, name, err)
+		respondWithError(w, http.StatusForbidden, message, logger)
+		return
+	}
+
+	respondWithMutationError(w, err, logger)
+}
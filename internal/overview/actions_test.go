@@ -0,0 +1,348 @@
+package overview
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twosson/kubeapt/internal/apt"
+	"github.com/twosson/kubeapt/internal/cluster/fake"
+	"github.com/twosson/kubeapt/internal/log"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/kubernetes/pkg/apis/extensions"
+)
+
+func newActionTestHandler(t *testing.T, now time.Time) (*handler, *fake.MutationClient) {
+	c := NewMemoryCache()
+	require.NoError(t, c.Store(newUnstructured("apps/v1", "Deployment", "default", "deployment")))
+
+	return newActionTestHandlerWithCache(t, now, c)
+}
+
+func newActionTestHandlerWithCache(t *testing.T, now time.Time, c Cache) (*handler, *fake.MutationClient) {
+	return newPrefixedActionTestHandler(t, "/api", "default", now, c)
+}
+
+func newPrefixedActionTestHandler(t *testing.T, prefix, namespace string, now time.Time, c Cache) (*handler, *fake.MutationClient) {
+	clusterClient, err := fake.NewClient(runtime.NewScheme(), resources, nil)
+	require.NoError(t, err)
+
+	currentNamespace := func() string { return namespace }
+	ah := newActionHandler(workloadsDeployments, clusterClient, c, currentNamespace, log.NopLogger())
+	ah.clock = clock.NewFakeClock(now)
+
+	h := newHandler(prefix, newStubbedGenerator(nil, nil), nil, stubStream, log.NopLogger())
+	h.handle(prefix, actionPath(workloadsDeployments.Path, "{name}", "{action}"), ah)
+
+	return h, clusterClient.FakeMutation
+}
+
+func Test_actionHandler_patch(t *testing.T) {
+	now := time.Date(2018, 11, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name          string
+		action        string
+		body          string
+		expectedPatch string
+	}{
+		{
+			name:          "scale",
+			action:        actionScale,
+			body:          `{"replicas":3}`,
+			expectedPatch: `{"spec":{"replicas":3}}`,
+		},
+		{
+			name:          "restart",
+			action:        actionRestart,
+			expectedPatch: `{"spec":{"template":{"metadata":{"annotations":{"kubectl.kubernetes.io/restartedAt":"2018-11-01T12:00:00Z"}}}}}`,
+		},
+		{
+			name:          "pause",
+			action:        actionPause,
+			expectedPatch: `{"spec":{"paused":true}}`,
+		},
+		{
+			name:          "resume",
+			action:        actionResume,
+			expectedPatch: `{"spec":{"paused":false}}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, mutationClient := newActionTestHandler(t, now)
+
+			u := "/api/workloads/deployments/deployment/actions/" + tc.action + "?namespace=default"
+			r := httptest.NewRequest(http.MethodPost, u, bytes.NewBufferString(tc.body))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+			require.Len(t, mutationClient.Patches, 1)
+
+			patch := mutationClient.Patches[0]
+			assert.Equal(t, types.MergePatchType, patch.PatchType)
+			assert.Equal(t, "deployment", patch.Object.GetName())
+			assert.JSONEq(t, tc.expectedPatch, patch.Data)
+		})
+	}
+}
+
+func Test_actionHandler_current_namespace(t *testing.T) {
+	c := NewMemoryCache()
+	require.NoError(t, c.Store(newUnstructured("apps/v1", "Deployment", "staging", "deployment")))
+
+	h, mutationClient := newPrefixedActionTestHandler(t, "/api", "staging", time.Now(), c)
+
+	r := httptest.NewRequest(http.MethodPost, "/api/workloads/deployments/deployment/actions/pause", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+	require.Len(t, mutationClient.Patches, 1)
+	assert.Equal(t, "staging", mutationClient.Patches[0].Object.GetNamespace())
+}
+
+func Test_actionHandler_advertised_path(t *testing.T) {
+	c := NewMemoryCache()
+	require.NoError(t, c.Store(newUnstructured("apps/v1", "Deployment", "staging", "deployment")))
+
+	h, mutationClient := newPrefixedActionTestHandler(t, testPrefix, "default", time.Now(), c)
+
+	deployment := &extensions.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "staging", Name: "deployment"},
+	}
+	actions := objectActions(testPrefix, workloadsDeployments.Path, []string{actionPause}, deployment)
+	require.Len(t, actions, 1)
+
+	r := httptest.NewRequest(http.MethodPost, apt.APIPathPrefix+actions[0].Path, nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+	require.Len(t, mutationClient.Patches, 1)
+	assert.Equal(t, "staging", mutationClient.Patches[0].Object.GetNamespace())
+}
+
+func Test_actionHandler_delete(t *testing.T) {
+	cases := []struct {
+		name           string
+		body           string
+		expectedPolicy metav1.DeletionPropagation
+	}{
+		{
+			name:           "default policy",
+			expectedPolicy: metav1.DeletePropagationBackground,
+		},
+		{
+			name:           "foreground",
+			body:           `{"propagationPolicy":"Foreground"}`,
+			expectedPolicy: metav1.DeletePropagationForeground,
+		},
+		{
+			name:           "orphan",
+			body:           `{"propagationPolicy":"Orphan"}`,
+			expectedPolicy: metav1.DeletePropagationOrphan,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, mutationClient := newActionTestHandler(t, time.Now())
+
+			u := "/api/workloads/deployments/deployment/actions/delete"
+			r := httptest.NewRequest(http.MethodPost, u, bytes.NewBufferString(tc.body))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+			require.Len(t, mutationClient.Deletes, 1)
+			assert.Equal(t, tc.expectedPolicy, mutationClient.Deletes[0].PropagationPolicy)
+		})
+	}
+}
+
+func Test_actionHandler_rollback(t *testing.T) {
+	owner := &metav1.ObjectMeta{Namespace: "default", Name: "deployment", UID: "deployment"}
+
+	cases := []struct {
+		name          string
+		body          string
+		expectedCode  int
+		expectedPatch string
+	}{
+		{
+			name:          "existing revision",
+			body:          `{"revision":1}`,
+			expectedCode:  http.StatusNoContent,
+			expectedPatch: `[{"op":"replace","path":"/spec/template","value":{"metadata":{"labels":{"app":"app"}},"spec":{"containers":[{"image":"app:v1","name":"app"}]}}}]`,
+		},
+		{
+			name:         "missing revision",
+			body:         `{"revision":9}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "no revision",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, mutationClient := newActionTestHandlerWithCache(t, time.Now(), newRevisionCache(t, owner))
+
+			u := "/api/workloads/deployments/deployment/actions/rollback"
+			r := httptest.NewRequest(http.MethodPost, u, bytes.NewBufferString(tc.body))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			require.Equal(t, tc.expectedCode, w.Code, w.Body.String())
+			if tc.expectedCode != http.StatusNoContent {
+				assert.Empty(t, mutationClient.Patches)
+				return
+			}
+
+			require.Len(t, mutationClient.Patches, 1)
+			assert.Equal(t, types.JSONPatchType, mutationClient.Patches[0].PatchType)
+			assert.JSONEq(t, tc.expectedPatch, mutationClient.Patches[0].Data)
+		})
+	}
+}
+
+func Test_actionHandler_errors(t *testing.T) {
+	forbidden := apierrors.NewForbidden(schema.GroupResource{Group: "apps", Resource: "deployments"}, "deployment", nil)
+
+	cases := []struct {
+		name         string
+		method       string
+		path         string
+		body         string
+		err          error
+		expectedCode int
+	}{
+		{
+			name:         "invalid method",
+			method:       http.MethodGet,
+			path:         "/api/workloads/deployments/deployment/actions/scale",
+			expectedCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:         "unknown action",
+			path:         "/api/workloads/deployments/deployment/actions/explode",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "object not cached",
+			path:         "/api/workloads/deployments/missing/actions/delete",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "missing replicas",
+			path:         "/api/workloads/deployments/deployment/actions/scale",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid propagation policy",
+			path:         "/api/workloads/deployments/deployment/actions/delete",
+			body:         `{"propagationPolicy":"Sometimes"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "forbidden",
+			path:         "/api/workloads/deployments/deployment/actions/restart",
+			err:          forbidden,
+			expectedCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, mutationClient := newActionTestHandler(t, time.Now())
+			mutationClient.Err = tc.err
+
+			method := tc.method
+			if method == "" {
+				method = http.MethodPost
+			}
+
+			r := httptest.NewRequest(method, tc.path, bytes.NewBufferString(tc.body))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedCode, w.Code, w.Body.String())
+			if tc.expectedCode == http.StatusForbidden {
+				assert.Contains(t, w.Body.String(), `you are not allowed to restart Deployment \"deployment\"`)
+			}
+		})
+	}
+}
+
+func Test_objectActions(t *testing.T) {
+	actions := []string{actionScale, actionPause, actionResume}
+
+	cases := []struct {
+		name      string
+		prefix    string
+		namespace string
+		paused    bool
+		expected  []Action
+	}{
+		{
+			name:   "running",
+			prefix: testPrefix,
+			expected: []Action{
+				{Name: "scale", Title: "Scale", Path: "/content/overview/workloads/deployments/deployment/actions/scale"},
+				{Name: "pause", Title: "Pause", Path: "/content/overview/workloads/deployments/deployment/actions/pause"},
+			},
+		},
+		{
+			name:   "paused",
+			prefix: testPrefix,
+			paused: true,
+			expected: []Action{
+				{Name: "scale", Title: "Scale", Path: "/content/overview/workloads/deployments/deployment/actions/scale"},
+				{Name: "resume", Title: "Resume", Path: "/content/overview/workloads/deployments/deployment/actions/resume"},
+			},
+		},
+		{
+			name:   "cluster",
+			prefix: "/api/v1/clusters/staging/content/overview",
+			expected: []Action{
+				{Name: "scale", Title: "Scale", Path: "/clusters/staging/content/overview/workloads/deployments/deployment/actions/scale"},
+				{Name: "pause", Title: "Pause", Path: "/clusters/staging/content/overview/workloads/deployments/deployment/actions/pause"},
+			},
+		},
+		{
+			name:      "namespaced",
+			prefix:    testPrefix,
+			namespace: "kube-system",
+			expected: []Action{
+				{Name: "scale", Title: "Scale", Path: "/content/overview/workloads/deployments/deployment/actions/scale?namespace=kube-system"},
+				{Name: "pause", Title: "Pause", Path: "/content/overview/workloads/deployments/deployment/actions/pause?namespace=kube-system"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			deployment := &extensions.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Namespace: tc.namespace, Name: "deployment"},
+				Spec:       extensions.DeploymentSpec{Paused: tc.paused},
+			}
+
+			got := objectActions(tc.prefix, "/workloads/deployments", actions, deployment)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
@@ -0,0 +1,293 @@
+package overview
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/twosson/kubeapt/internal/cluster"
+	"github.com/twosson/kubeapt/internal/log"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	applyPath = "/apply"
+
+	// maxManifestSize is the largest manifest which can be applied.
+	maxManifestSize = 1 << 20
+)
+
+// applyResponse is the result of applying a manifest. Diff is a unified diff
+// between the cached object and the object returned by the dry-run.
+type applyResponse struct {
+	Diff    string `json:"diff"`
+	Applied bool   `json:"applied"`
+}
+
+// applyHandler updates an object from a YAML manifest. The manifest is an
+// edited copy of the manifest shown in the YAML view, so the changes made to
+// it are applied to the cached object, and fields the view leaves out are
+// kept. The update is validated with a server-side dry-run before it is
+// applied. If the dryRun query parameter is set, only the dry-run is
+// performed, so a client can show the diff before applying.
+type applyHandler struct {
+	clusterClient cluster.ClientInterface
+	cache         Cache
+	logger        log.Logger
+}
+
+var _ http.Handler = (*applyHandler)(nil)
+
+func newApplyHandler(clusterClient cluster.ClientInterface, c Cache, logger log.Logger) *applyHandler {
+	return &applyHandler{
+		clusterClient: clusterClient,
+		cache:         c,
+		logger:        logger,
+	}
+}
+
+func (h *applyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "manifests must be applied with POST", h.logger)
+		return
+	}
+
+	dryRun, err := parseBoolParam(r.URL.Query(), "dryRun")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), h.logger)
+		return
+	}
+
+	data, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxManifestSize))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "unable to read manifest", h.logger)
+		return
+	}
+
+	object, err := decodeManifest(data)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), h.logger)
+		return
+	}
+
+	// Secret manifests are shown redacted, so applying an edited one would
+	// replace the secret's values with the redacted text.
+	if isSecret(object) {
+		respondWithError(w, http.StatusBadRequest, "secrets can't be applied because their manifests are redacted", h.logger)
+		return
+	}
+
+	logger := h.logger.With("kind", object.GetKind(), "name", object.GetName(), "namespace", object.GetNamespace())
+
+	// Updates are conditional on the version of the object the user edited,
+	// so changes made since then aren't overwritten.
+	if object.GetResourceVersion() == "" {
+		respondWithError(w, http.StatusBadRequest, "manifest must have a resourceVersion", logger)
+		return
+	}
+
+	cached, err := h.cachedObject(r.Context(), object)
+	if err != nil {
+		if err == contentNotFound {
+			respondWithError(w, http.StatusNotFound, err.Error(), logger)
+			return
+		}
+		logger.Errorf("retrieving cached object: %v", err)
+		respondWithError(w, http.StatusInternalServerError, err.Error(), logger)
+		return
+	}
+
+	if object.GetResourceVersion() != cached.GetResourceVersion() {
+		respondWithError(w, http.StatusConflict, "the object has been modified since the manifest was edited", logger)
+		return
+	}
+
+	updated, err := applyManifestChanges(cached, object)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), logger)
+		return
+	}
+
+	mutationClient, err := h.clusterClient.MutationClient()
+	if err != nil {
+		logger.Errorf("creating mutation client: %v", err)
+		respondWithError(w, http.StatusInternalServerError, err.Error(), logger)
+		return
+	}
+
+	dryRunObject, err := mutationClient.Update(updated, cluster.MutationOptions{DryRun: true})
+	if err != nil {
+		respondWithMutationError(w, err, logger)
+		return
+	}
+
+	diff, err := manifestDiff(cached, dryRunObject)
+	if err != nil {
+		logger.Errorf("creating diff: %v", err)
+		respondWithError(w, http.StatusInternalServerError, err.Error(), logger)
+		return
+	}
+
+	resp := applyResponse{Diff: diff}
+
+	if !dryRun {
+		if _, err := mutationClient.Update(updated, cluster.MutationOptions{}); err != nil {
+			respondWithMutationError(w, err, logger)
+			return
+		}
+		logger.Infof("applied manifest")
+		resp.Applied = true
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if err := json.NewEncoder(w).Encode(&resp); err != nil {
+		logger.Errorf("encoding response: %v", err)
+	}
+}
+
+// cachedObject returns the cached version of an object. Only objects which
+// are in the cache can be applied.
+func (h *applyHandler) cachedObject(ctx context.Context, object *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	key := CacheKey{
+		Namespace:  object.GetNamespace(),
+		APIVersion: object.GetAPIVersion(),
+		Kind:       object.GetKind(),
+		Name:       object.GetName(),
+	}
+
+	objects, err := h.cache.Retrieve(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(objects) != 1 {
+		return nil, contentNotFound
+	}
+
+	return objects[0], nil
+}
+
+// decodeManifest decodes a YAML or JSON manifest for a single object.
+func decodeManifest(data []byte) (*unstructured.Unstructured, error) {
+	j, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "manifest is not valid YAML")
+	}
+
+	object := &unstructured.Unstructured{}
+	if err := object.UnmarshalJSON(j); err != nil {
+		return nil, errors.Wrap(err, "manifest is not a valid object")
+	}
+
+	if object.IsList() {
+		return nil, errors.New("manifest must contain a single object")
+	}
+
+	if object.GetName() == "" {
+		return nil, errors.New("manifest must have a name")
+	}
+
+	return object, nil
+}
+
+// applyManifestChanges returns a copy of the cached object with the changes
+// made to its shown manifest applied. The changes are a JSON merge patch from
+// the shown manifest to the edited one, so fields which aren't shown, such as
+// status and the last applied configuration, are kept.
+func applyManifestChanges(cached, edited *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	shown := stripManifest(cached)
+	shownJSON, err := shown.MarshalJSON()
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling shown manifest")
+	}
+
+	// Annotations removed from the manifest are removed one at a time, so
+	// the last applied configuration is kept if every shown annotation is.
+	edited = edited.DeepCopy()
+	if _, ok, _ := unstructured.NestedMap(edited.Object, "metadata", "annotations"); !ok && len(shown.GetAnnotations()) > 0 {
+		if err := unstructured.SetNestedMap(edited.Object, map[string]interface{}{}, "metadata", "annotations"); err != nil {
+			return nil, errors.Wrap(err, "setting annotations")
+		}
+	}
+
+	editedJSON, err := edited.MarshalJSON()
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling edited manifest")
+	}
+
+	patch, err := jsonpatch.CreateMergePatch(shownJSON, editedJSON)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating patch from the edited manifest")
+	}
+
+	cachedJSON, err := cached.MarshalJSON()
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling cached object")
+	}
+
+	updatedJSON, err := jsonpatch.MergePatch(cachedJSON, patch)
+	if err != nil {
+		return nil, errors.Wrap(err, "applying the edited manifest")
+	}
+
+	updated := &unstructured.Unstructured{}
+	if err := updated.UnmarshalJSON(updatedJSON); err != nil {
+		return nil, errors.Wrap(err, "decoding updated object")
+	}
+
+	return updated, nil
+}
+
+// manifestDiff creates a unified diff between two objects. Only the resource
+// version, which changes with every update, is ignored, so changes to fields
+// the YAML view leaves out are shown too.
+func manifestDiff(from, to *unstructured.Unstructured) (string, error) {
+	a, err := manifestYAML(withoutResourceVersion(from), false)
+	if err != nil {
+		return "", err
+	}
+
+	b, err := manifestYAML(withoutResourceVersion(to), false)
+	if err != nil {
+		return "", err
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(a),
+		B:        difflib.SplitLines(b),
+		FromFile: "live",
+		ToFile:   "edited",
+		Context:  3,
+	})
+}
+
+// withoutResourceVersion returns a copy of an object without its resource
+// version, which changes with every update.
+func withoutResourceVersion(object *unstructured.Unstructured) *unstructured.Unstructured {
+	stripped := object.DeepCopy()
+	unstructured.RemoveNestedField(stripped.Object, "metadata", "resourceVersion")
+	return stripped
+}
+
+// respondWithMutationError responds with the status code from an API error,
+// e.g. 409 if the object was changed since it was edited.
+func respondWithMutationError(w http.ResponseWriter, err error, logger log.Logger) {
+	if err == cluster.ErrDryRunNotSupported {
+		respondWithError(w, http.StatusNotImplemented, err.Error(), logger)
+		return
+	}
+
+	if status, ok := err.(apierrors.APIStatus); ok && status.Status().Code != 0 {
+		respondWithError(w, int(status.Status().Code), status.Status().Message, logger)
+		return
+	}
+
+	logger.Errorf("updating object: %v", err)
+	respondWithError(w, http.StatusInternalServerError, err.Error(), logger)
+}
@@ -0,0 +1,207 @@
+package overview
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twosson/kubeapt/internal/cluster"
+	"github.com/twosson/kubeapt/internal/cluster/fake"
+	"github.com/twosson/kubeapt/internal/log"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const editedConfigMap = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+  namespace: default
+  resourceVersion: "7"
+data:
+  key: changed
+`
+
+func newApplyTestHandler(t *testing.T) (*applyHandler, *fake.MutationClient) {
+	clusterClient, err := fake.NewClient(runtime.NewScheme(), resources, nil)
+	require.NoError(t, err)
+
+	c := NewMemoryCache()
+	cm := newUnstructured("v1", "ConfigMap", "default", "cm")
+	cm.Object["data"] = map[string]interface{}{"key": "value"}
+	cm.SetResourceVersion("7")
+	require.NoError(t, c.Store(cm))
+
+	return newApplyHandler(clusterClient, c, log.NopLogger()), clusterClient.FakeMutation
+}
+
+func Test_applyHandler(t *testing.T) {
+	cases := []struct {
+		name            string
+		dryRun          bool
+		expectedUpdates []cluster.MutationOptions
+	}{
+		{
+			name:            "dry run",
+			dryRun:          true,
+			expectedUpdates: []cluster.MutationOptions{{DryRun: true}},
+		},
+		{
+			name:            "apply",
+			expectedUpdates: []cluster.MutationOptions{{DryRun: true}, {}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, mutationClient := newApplyTestHandler(t)
+
+			u := "/apply"
+			if tc.dryRun {
+				u += "?dryRun=true"
+			}
+
+			r := httptest.NewRequest(http.MethodPost, u, bytes.NewBufferString(editedConfigMap))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+			var resp applyResponse
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+			assert.Equal(t, !tc.dryRun, resp.Applied)
+			assert.Contains(t, resp.Diff, "-  key: value\n+  key: changed\n")
+
+			var options []cluster.MutationOptions
+			for _, update := range mutationClient.Updates {
+				options = append(options, update.Options)
+				assert.Equal(t, "7", update.Object.GetResourceVersion())
+			}
+			assert.Equal(t, tc.expectedUpdates, options)
+		})
+	}
+}
+
+func Test_applyHandler_errors(t *testing.T) {
+	conflict := apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, "cm", nil)
+
+	cases := []struct {
+		name         string
+		method       string
+		body         string
+		updateErr    error
+		expectedCode int
+	}{
+		{
+			name:         "invalid method",
+			method:       http.MethodGet,
+			expectedCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:         "invalid manifest",
+			body:         "kind: [",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "secret",
+			body:         "apiVersion: v1\nkind: Secret\nmetadata:\n  name: secret\n  namespace: default\ndata:\n  key: 8 bytes\n",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "missing resource version",
+			body:         "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\n  namespace: default\n",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "modified since edited",
+			body:         "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\n  namespace: default\n  resourceVersion: \"6\"\n",
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:         "object not cached",
+			body:         "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: missing\n  namespace: default\n  resourceVersion: \"1\"\n",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "conflict",
+			body:         editedConfigMap,
+			updateErr:    conflict,
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:         "dry run not supported",
+			body:         editedConfigMap,
+			updateErr:    cluster.ErrDryRunNotSupported,
+			expectedCode: http.StatusNotImplemented,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, mutationClient := newApplyTestHandler(t)
+			mutationClient.UpdateErr = tc.updateErr
+
+			method := tc.method
+			if method == "" {
+				method = http.MethodPost
+			}
+
+			r := httptest.NewRequest(method, "/apply", bytes.NewBufferString(tc.body))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedCode, w.Code)
+		})
+	}
+}
+
+func Test_applyManifestChanges(t *testing.T) {
+	cached := newUnstructured("stable.example.com/v1", "CronTab", "default", "crontab")
+	cached.SetResourceVersion("7")
+	cached.SetUID("uid")
+	cached.SetAnnotations(map[string]string{
+		lastAppliedAnnotation: `{"spec":{"image":"app:1"}}`,
+		"owner":               "team",
+	})
+	cached.Object["spec"] = map[string]interface{}{"image": "app:1", "replicas": int64(2)}
+	cached.Object["status"] = map[string]interface{}{"ready": true}
+
+	edited, err := decodeManifest([]byte(`apiVersion: stable.example.com/v1
+kind: CronTab
+metadata:
+  name: crontab
+  namespace: default
+  resourceVersion: "7"
+spec:
+  image: app:2
+`))
+	require.NoError(t, err)
+
+	got, err := applyManifestChanges(cached, edited)
+	require.NoError(t, err)
+
+	expected := cached.DeepCopy()
+	expected.SetAnnotations(map[string]string{lastAppliedAnnotation: `{"spec":{"image":"app:1"}}`})
+	expected.Object["spec"] = map[string]interface{}{"image": "app:2"}
+	assert.Equal(t, expected, got)
+}
+
+func Test_manifestDiff_removed_fields(t *testing.T) {
+	from := newUnstructured("stable.example.com/v1", "CronTab", "default", "crontab")
+	from.SetResourceVersion("7")
+	from.Object["status"] = map[string]interface{}{"ready": true}
+
+	to := from.DeepCopy()
+	to.SetResourceVersion("8")
+	delete(to.Object, "status")
+
+	diff, err := manifestDiff(from, to)
+	require.NoError(t, err)
+	assert.Contains(t, diff, "-status:\n-  ready: true\n")
+	assert.NotContains(t, diff, "resourceVersion")
+}
@@ -1,28 +1,36 @@
 package overview
 
 import (
+	"context"
 	"sync"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // Cache stores Kubernetes objects.
 type Cache interface {
 	Store(obj *unstructured.Unstructured) error
-	Retrieve(key CacheKey) ([]*unstructured.Unstructured, error)
+	Retrieve(ctx context.Context, key CacheKey) ([]*unstructured.Unstructured, error)
 	Delete(obj *unstructured.Unstructured) error
 
-	Events(obj *unstructured.Unstructured) ([]*unstructured.Unstructured, error)
+	Events(ctx context.Context, obj *unstructured.Unstructured) ([]*unstructured.Unstructured, error)
 }
 
-// CacheKey is a key for the cache.
+// AllNamespaces is a namespace which selects objects in every namespace.
+const AllNamespaces = "*"
+
+// CacheKey is a key for the cache. Lists can be filtered with label and
+// field selectors.
 type CacheKey struct {
-	Namespace  string
-	APIVersion string
-	Kind       string
-	Name       string
+	Namespace     string
+	APIVersion    string
+	Kind          string
+	Name          string
+	LabelSelector string
+	FieldSelector string
 }
 
 // MemoryCacheOpt is an option for configuring memory cache.
@@ -44,6 +52,9 @@ const (
 type CacheNotification struct {
 	CacheKey CacheKey
 	Action   CacheAction
+	// Object is the object which was stored, updated or deleted. It is nil
+	// for notifications which aren't about a single object.
+	Object *unstructured.Unstructured
 }
 
 // CacheNotificationOpt sets a channel that will receive a notification
@@ -103,20 +114,26 @@ func (mc *MemoryCache) Store(obj *unstructured.Unstructured) error {
 	mc.store[key] = obj
 	mc.mu.Unlock()
 
-	mc.notify(CacheStore, key)
+	mc.notify(CacheStore, key, obj)
 
 	return nil
 }
 
-// Retrieve retrieves an object from the cache.
-func (mc *MemoryCache) Retrieve(key CacheKey) ([]*unstructured.Unstructured, error) {
+// Retrieve retrieves an object from the cache. Objects in every namespace
+// are retrieved if the key's namespace is AllNamespaces.
+func (mc *MemoryCache) Retrieve(ctx context.Context, key CacheKey) ([]*unstructured.Unstructured, error) {
+	labelSelector, fieldSelector, err := cacheKeySelectors(key)
+	if err != nil {
+		return nil, err
+	}
+
 	mc.mu.Lock()
 	defer mc.mu.Unlock()
 
 	var objs []*unstructured.Unstructured
 
 	for k, v := range mc.store {
-		if k.Namespace != key.Namespace {
+		if key.Namespace != AllNamespaces && k.Namespace != key.Namespace {
 			continue
 		}
 
@@ -144,7 +161,14 @@ func (mc *MemoryCache) Retrieve(key CacheKey) ([]*unstructured.Unstructured, err
 		}
 	}
 
-	return objs, nil
+	var selected []*unstructured.Unstructured
+	for _, obj := range objs {
+		if labelSelector.Matches(labels.Set(obj.GetLabels())) && matchesFields(obj, fieldSelector) {
+			selected = append(selected, obj)
+		}
+	}
+
+	return selected, nil
 }
 
 // Delete deletes an object from the cache.
@@ -165,13 +189,13 @@ func (mc *MemoryCache) Delete(obj *unstructured.Unstructured) error {
 	delete(mc.store, key)
 	mc.mu.Unlock()
 
-	mc.notify(CacheDelete, key)
+	mc.notify(CacheDelete, key, obj)
 
 	return nil
 }
 
 // Events returns events for an object.
-func (mc *MemoryCache) Events(u *unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+func (mc *MemoryCache) Events(ctx context.Context, u *unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
 	mc.mu.Lock()
 	defer mc.mu.Unlock()
 
@@ -202,13 +226,13 @@ func (mc *MemoryCache) Events(u *unstructured.Unstructured) ([]*unstructured.Uns
 	return events, nil
 }
 
-func (mc *MemoryCache) notify(action CacheAction, key CacheKey) {
+func (mc *MemoryCache) notify(action CacheAction, key CacheKey, obj *unstructured.Unstructured) {
 	if mc.notifyCh == nil {
 		return
 	}
 
 	select {
-	case mc.notifyCh <- CacheNotification{Action: action, CacheKey: key}:
+	case mc.notifyCh <- CacheNotification{Action: action, CacheKey: key, Object: obj}:
 	case <-mc.notifyDone:
 	}
 }
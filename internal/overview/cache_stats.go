@@ -0,0 +1,131 @@
+package overview
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/twosson/kubeapt/internal/log"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const cacheStatsPath = "/cache"
+
+// informerStats describes an informer run by the cache. Bytes approximates
+// the memory used by the informer's objects with their size as JSON.
+type informerStats struct {
+	Namespace  string    `json:"namespace,omitempty"`
+	APIVersion string    `json:"apiVersion"`
+	Kind       string    `json:"kind"`
+	Synced     bool      `json:"synced"`
+	Objects    int       `json:"objects"`
+	Bytes      int64     `json:"bytes"`
+	References int       `json:"references"`
+	LastUsed   time.Time `json:"lastUsed"`
+}
+
+// cacheStats describes the informers run by the cache.
+type cacheStats struct {
+	Informers    []informerStats `json:"informers"`
+	Objects      int             `json:"objects"`
+	Bytes        int64           `json:"bytes"`
+	MaxInformers int             `json:"maxInformers,omitempty"`
+	IdleTTL      string          `json:"idleTTL,omitempty"`
+}
+
+// stats reports the informers run by the cache, and the objects they hold.
+// Objects are sized after the cache's lock is released, as marshalling
+// every object is slow on large clusters and would block new informers.
+func (c *InformerCache) stats() cacheStats {
+	type sizedInformer struct {
+		stats    informerStats
+		informer *cachedInformer
+	}
+
+	c.mu.RLock()
+	stats := cacheStats{
+		Informers:    []informerStats{},
+		MaxInformers: c.maxInformers,
+	}
+	if c.idleTTL > 0 {
+		stats.IdleTTL = c.idleTTL.String()
+	}
+
+	var informers []sizedInformer
+	for _, set := range []map[informerKey]*cachedInformer{c.informers, c.syncing} {
+		for key, ci := range set {
+			informers = append(informers, sizedInformer{
+				stats: informerStats{
+					Namespace:  key.namespace,
+					APIVersion: key.gvk.GroupVersion().String(),
+					Kind:       key.gvk.Kind,
+					References: ci.refs,
+					LastUsed:   ci.lastUsedTime(),
+				},
+				informer: ci,
+			})
+		}
+	}
+	c.mu.RUnlock()
+
+	for _, si := range informers {
+		is := si.stats
+		is.Synced = si.informer.informer.Informer().HasSynced()
+
+		for _, obj := range si.informer.informer.Informer().GetStore().List() {
+			is.Objects++
+			if u, ok := obj.(*unstructured.Unstructured); ok {
+				if data, err := u.MarshalJSON(); err == nil {
+					is.Bytes += int64(len(data))
+				}
+			}
+		}
+
+		stats.Objects += is.Objects
+		stats.Bytes += is.Bytes
+		stats.Informers = append(stats.Informers, is)
+	}
+
+	sort.Slice(stats.Informers, func(i, j int) bool {
+		a, b := stats.Informers[i], stats.Informers[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.APIVersion != b.APIVersion {
+			return a.APIVersion < b.APIVersion
+		}
+		return a.Namespace < b.Namespace
+	})
+
+	return stats
+}
+
+// cacheStatsHandler reports the informers run by the cache.
+type cacheStatsHandler struct {
+	cache  *InformerCache
+	logger log.Logger
+}
+
+var _ http.Handler = (*cacheStatsHandler)(nil)
+
+func newCacheStatsHandler(c *InformerCache, logger log.Logger) *cacheStatsHandler {
+	return &cacheStatsHandler{
+		cache:  c,
+		logger: logger,
+	}
+}
+
+func (h *cacheStatsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "cache stats must be retrieved with GET", h.logger)
+		return
+	}
+
+	stats := h.cache.stats()
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if err := json.NewEncoder(w).Encode(&stats); err != nil {
+		h.logger.Errorf("encoding response: %v", err)
+	}
+}
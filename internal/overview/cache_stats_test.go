@@ -0,0 +1,62 @@
+package overview
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twosson/kubeapt/internal/log"
+)
+
+func Test_cacheStatsHandler(t *testing.T) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	c := newEvictionTestCache(t, stopCh)
+
+	_, err := c.Retrieve(context.Background(), CacheKey{Namespace: "default", APIVersion: "apps/v1", Kind: "Deployment"})
+	require.NoError(t, err)
+
+	h := newCacheStatsHandler(c, log.NopLogger())
+
+	ts := httptest.NewServer(h)
+	defer ts.Close()
+
+	res, err := http.Get(ts.URL)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var stats cacheStats
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&stats))
+
+	require.Len(t, stats.Informers, 1)
+
+	informer := stats.Informers[0]
+	assert.Equal(t, "default", informer.Namespace)
+	assert.Equal(t, "apps/v1", informer.APIVersion)
+	assert.Equal(t, "Deployment", informer.Kind)
+	assert.True(t, informer.Synced)
+	assert.Equal(t, 1, informer.Objects)
+	assert.True(t, informer.Bytes > 0)
+
+	assert.Equal(t, 1, stats.Objects)
+	assert.Equal(t, informer.Bytes, stats.Bytes)
+	assert.Equal(t, defaultMaxInformers, stats.MaxInformers)
+	assert.Equal(t, defaultInformerIdleTTL.String(), stats.IdleTTL)
+}
+
+func Test_cacheStatsHandler_method(t *testing.T) {
+	h := newCacheStatsHandler(nil, log.NopLogger())
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/cache", nil)
+	h.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
@@ -1,6 +1,7 @@
 package overview
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"testing"
@@ -70,6 +71,53 @@ func TestMemoryCache_Retrieve(t *testing.T) {
 				Namespace: "default",
 			}, expectedLen: 4,
 		},
+		{
+			name: "all namespaces, apiVersion, kind",
+			key: CacheKey{
+				Namespace:  AllNamespaces,
+				APIVersion: "foo/v1",
+				Kind:       "Kind",
+			},
+			expectedLen: 3,
+		},
+		{
+			name: "all namespaces, apiVersion, kind, name",
+			key: CacheKey{
+				Namespace:  AllNamespaces,
+				APIVersion: "foo/v1",
+				Kind:       "Kind",
+				Name:       "foo1",
+			},
+			expectedLen: 2,
+		},
+		{
+			name: "ns, apiVersion, kind, label selector",
+			key: CacheKey{
+				Namespace:     "default",
+				APIVersion:    "foo/v1",
+				Kind:          "Kind",
+				LabelSelector: "app=checkout",
+			},
+			expectedLen: 1,
+		},
+		{
+			name: "all namespaces, label selector",
+			key: CacheKey{
+				Namespace:     AllNamespaces,
+				LabelSelector: "app=checkout",
+			},
+			expectedLen: 2,
+		},
+		{
+			name: "ns, apiVersion, kind, field selector",
+			key: CacheKey{
+				Namespace:     "default",
+				APIVersion:    "foo/v1",
+				Kind:          "Kind",
+				FieldSelector: "metadata.name!=foo1",
+			},
+			expectedLen: 1,
+		},
 	}
 
 	for _, tc := range cases {
@@ -81,7 +129,7 @@ func TestMemoryCache_Retrieve(t *testing.T) {
 				require.NoError(t, err)
 			}
 
-			objs, err := c.Retrieve(tc.key)
+			objs, err := c.Retrieve(context.Background(), tc.key)
 			require.NoError(t, err)
 			assert.Len(t, objs, tc.expectedLen)
 		})
@@ -142,7 +190,7 @@ func TestMemoryCache_Events(t *testing.T) {
 				}
 			}
 
-			events, err := c.Events(o)
+			events, err := c.Events(context.Background(), o)
 			require.NoError(t, err)
 
 			assert.Len(t, events, tc.expected)
@@ -156,14 +204,17 @@ func genObjectsSeed() []*unstructured.Unstructured {
 
 	type source struct {
 		ns, apiVersion, kind, name string
+		labels                     map[string]string
 	}
 
+	checkout := map[string]string{"app": "checkout"}
+
 	sources := []source{
-		{"app-1", "foo/v1", "Kind", "foo1"},
-		{"default", "foo/v1", "Kind", "foo1"},
-		{"default", "foo/v1", "Kind", "foo2"},
-		{"default", "foo/v1", "Other", "other1"},
-		{"default", "bar/v1", "Bar", "bar1"},
+		{"app-1", "foo/v1", "Kind", "foo1", checkout},
+		{"default", "foo/v1", "Kind", "foo1", checkout},
+		{"default", "foo/v1", "Kind", "foo2", nil},
+		{"default", "foo/v1", "Other", "other1", nil},
+		{"default", "bar/v1", "Bar", "bar1", nil},
 	}
 
 	for _, src := range sources {
@@ -172,6 +223,9 @@ func genObjectsSeed() []*unstructured.Unstructured {
 		o.SetAPIVersion(src.apiVersion)
 		o.SetKind(src.kind)
 		o.SetName(src.name)
+		if src.labels != nil {
+			o.SetLabels(src.labels)
+		}
 
 		objects = append(objects, o)
 	}
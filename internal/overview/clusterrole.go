@@ -0,0 +1,69 @@
+package overview
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/twosson/kubeapt/pkg/content"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/kubernetes/pkg/apis/rbac"
+)
+
+type ClusterRoleSummary struct{}
+
+var _ View = (*ClusterRoleSummary)(nil)
+
+func NewClusterRoleSummary(prefix, namespace string, c clock.Clock) View {
+	return &ClusterRoleSummary{}
+}
+
+func (crs *ClusterRoleSummary) Content(ctx context.Context, object runtime.Object, c Cache) ([]content.Content, error) {
+	clusterRole, err := retrieveClusterRole(object)
+	if err != nil {
+		return nil, err
+	}
+
+	detail, err := printClusterRoleSummary(clusterRole)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := content.NewSummary("Details", []content.Section{detail})
+	return []content.Content{
+		&summary,
+	}, nil
+}
+
+type ClusterRoleRule struct{}
+
+var _ View = (*ClusterRoleRule)(nil)
+
+func NewClusterRoleRule(prefix, namespace string, c clock.Clock) View {
+	return &ClusterRoleRule{}
+}
+
+func (crr *ClusterRoleRule) Content(ctx context.Context, object runtime.Object, c Cache) ([]content.Content, error) {
+	clusterRole, err := retrieveClusterRole(object)
+	if err != nil {
+		return nil, err
+	}
+
+	rulesTable, err := printPolicyRules(clusterRole.Rules, "No rules are configured for this Cluster Role")
+	if err != nil {
+		return nil, err
+	}
+
+	return []content.Content{
+		&rulesTable,
+	}, nil
+}
+
+func retrieveClusterRole(object runtime.Object) (*rbac.ClusterRole, error) {
+	clusterRole, ok := object.(*rbac.ClusterRole)
+	if !ok {
+		return nil, errors.Errorf("expected object to be a ClusterRole, it was %T", object)
+	}
+
+	return clusterRole, nil
+}
@@ -0,0 +1,71 @@
+package overview
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/twosson/kubeapt/pkg/content"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/kubernetes/pkg/apis/rbac"
+)
+
+type ClusterRoleBindingSummary struct {
+	prefix string
+}
+
+var _ View = (*ClusterRoleBindingSummary)(nil)
+
+func NewClusterRoleBindingSummary(prefix, namespace string, c clock.Clock) View {
+	return &ClusterRoleBindingSummary{prefix: prefix}
+}
+
+func (crbs *ClusterRoleBindingSummary) Content(ctx context.Context, object runtime.Object, c Cache) ([]content.Content, error) {
+	clusterRoleBinding, err := retrieveClusterRoleBinding(object)
+	if err != nil {
+		return nil, err
+	}
+
+	detail, err := printClusterRoleBindingSummary(crbs.prefix, clusterRoleBinding)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := content.NewSummary("Details", []content.Section{detail})
+	return []content.Content{
+		&summary,
+	}, nil
+}
+
+type ClusterRoleBindingSubjects struct{}
+
+var _ View = (*ClusterRoleBindingSubjects)(nil)
+
+func NewClusterRoleBindingSubjects(prefix, namespace string, c clock.Clock) View {
+	return &ClusterRoleBindingSubjects{}
+}
+
+func (crbs *ClusterRoleBindingSubjects) Content(ctx context.Context, object runtime.Object, c Cache) ([]content.Content, error) {
+	clusterRoleBinding, err := retrieveClusterRoleBinding(object)
+	if err != nil {
+		return nil, err
+	}
+
+	subjectsTable, err := printSubjects(clusterRoleBinding.Subjects, "No subjects are configured for this ClusterRoleBinding")
+	if err != nil {
+		return nil, err
+	}
+
+	return []content.Content{
+		&subjectsTable,
+	}, nil
+}
+
+func retrieveClusterRoleBinding(object runtime.Object) (*rbac.ClusterRoleBinding, error) {
+	clusterRoleBinding, ok := object.(*rbac.ClusterRoleBinding)
+	if !ok {
+		return nil, errors.Errorf("expected object to be a ClusterRoleBinding, it was %T", object)
+	}
+
+	return clusterRoleBinding, nil
+}
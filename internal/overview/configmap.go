@@ -3,7 +3,7 @@ package overview
 import (
 	"context"
 	"github.com/pkg/errors"
-	"github.com/twosson/kubeapt/internal/content"
+	"github.com/twosson/kubeapt/pkg/content"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/clock"
 	"k8s.io/kubernetes/pkg/apis/core"
@@ -4,7 +4,7 @@ import (
 	"context"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"github.com/twosson/kubeapt/internal/content"
+	"github.com/twosson/kubeapt/pkg/content"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/clock"
 	"k8s.io/kubernetes/pkg/apis/core"
@@ -13,7 +13,7 @@ import (
 )
 
 func TestConfigMapDetails_InvalidObject(t *testing.T) {
-	cm := NewConfigMapDetails("prefix", "ns", clock.NewFakeClock(time.Now()))
+	cm := NewConfigMapDetails(testPrefix, "ns", clock.NewFakeClock(time.Now()))
 	ctx := context.Background()
 
 	object := &unstructured.Unstructured{}
@@ -23,7 +23,7 @@ func TestConfigMapDetails_InvalidObject(t *testing.T) {
 }
 
 func TestConfigMapDetails(t *testing.T) {
-	cm := NewConfigMapDetails("prefix", "ns", clock.NewFakeClock(time.Now()))
+	cm := NewConfigMapDetails(testPrefix, "ns", clock.NewFakeClock(time.Now()))
 
 	ctx := context.Background()
 	object := &core.ConfigMap{
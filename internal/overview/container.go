@@ -3,7 +3,7 @@ package overview
 import (
 	"context"
 	"github.com/pkg/errors"
-	"github.com/twosson/kubeapt/internal/content"
+	"github.com/twosson/kubeapt/pkg/content"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/clock"
 	"k8s.io/kubernetes/pkg/apis/apps"
@@ -12,12 +12,14 @@ import (
 	"k8s.io/kubernetes/pkg/apis/extensions"
 )
 
-type ContainerSummary struct{}
+type ContainerSummary struct {
+	prefix string
+}
 
 var _ View = (*ContainerSummary)(nil)
 
 func NewContainerSummary(prefix, namespace string, c clock.Clock) View {
-	return &ContainerSummary{}
+	return &ContainerSummary{prefix: prefix}
 }
 
 func (js *ContainerSummary) Content(ctx context.Context, object runtime.Object, c Cache) ([]content.Content, error) {
@@ -26,7 +28,7 @@ func (js *ContainerSummary) Content(ctx context.Context, object runtime.Object,
 		return nil, err
 	}
 
-	contents, err := printPodTemplate(podTemplate, nil)
+	contents, err := printPodTemplate(js.prefix, podTemplate, nil)
 	if err != nil {
 		return nil, err
 	}
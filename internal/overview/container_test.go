@@ -4,7 +4,7 @@ import (
 	"context"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"github.com/twosson/kubeapt/internal/content"
+	"github.com/twosson/kubeapt/pkg/content"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/clock"
 	"k8s.io/kubernetes/pkg/apis/apps"
@@ -16,7 +16,7 @@ import (
 )
 
 func TestContainerSummary_invalid_object(t *testing.T) {
-	assertViewInvalidObject(t, NewContainerSummary("prefix", "ns", clock.NewFakeClock(time.Now())))
+	assertViewInvalidObject(t, NewContainerSummary(testPrefix, "ns", clock.NewFakeClock(time.Now())))
 }
 
 func TestContainerSummary(t *testing.T) {
@@ -27,7 +27,7 @@ func TestContainerSummary(t *testing.T) {
 	require.True(t, ok)
 	cache := NewMemoryCache()
 
-	v := NewContainerSummary("prefix", "ns", clock.NewFakeClock(time.Now()))
+	v := NewContainerSummary(testPrefix, "ns", clock.NewFakeClock(time.Now()))
 
 	got, err := v.Content(ctx, cronJob, cache)
 	require.NoError(t, err)
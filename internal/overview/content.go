@@ -1,12 +1,13 @@
 package overview
 
 import (
-	"github.com/twosson/kubeapt/internal/content"
+	"github.com/twosson/kubeapt/pkg/content"
 )
 
 type ContentResponse struct {
-	Title string    `json:"title,omitempty"`
-	Views []Content `json:"views,omitempty"`
+	Title   string    `json:"title,omitempty"`
+	Views   []Content `json:"views,omitempty"`
+	Actions []Action  `json:"actions,omitempty"`
 }
 
 var emptyContentResponse = ContentResponse{}
@@ -2,7 +2,7 @@ package overview
 
 import (
 	"context"
-	"github.com/twosson/kubeapt/internal/content"
+	"github.com/twosson/kubeapt/pkg/content"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sort"
 
@@ -19,14 +19,20 @@ type lookupFunc func(namespace, prefix string, cell interface{}) content.Text
 func loadObjects(ctx context.Context, cache Cache, namespace string, fields map[string]string, cacheKeys []CacheKey) ([]*unstructured.Unstructured, error) {
 	var objects []*unstructured.Unstructured
 
+	selectors := listSelectorsFromContext(ctx)
+
 	for _, cacheKey := range cacheKeys {
 		cacheKey.Namespace = namespace
 
 		if name, ok := fields["name"]; ok && name != "" {
 			cacheKey.Name = name
+		} else {
+			// Lists are filtered by the selectors in the request.
+			cacheKey.LabelSelector = selectors.label
+			cacheKey.FieldSelector = selectors.field
 		}
 
-		cacheObjects, err := cache.Retrieve(cacheKey)
+		cacheObjects, err := cache.Retrieve(ctx, cacheKey)
 		if err != nil {
 			return nil, err
 		}
@@ -36,6 +42,9 @@ func loadObjects(ctx context.Context, cache Cache, namespace string, fields map[
 
 	sort.SliceStable(objects, func(i, j int) bool {
 		a, b := objects[i], objects[j]
+		if a.GetNamespace() != b.GetNamespace() {
+			return a.GetNamespace() < b.GetNamespace()
+		}
 		return a.GetName() < b.GetName()
 	})
 
@@ -52,8 +61,8 @@ func translateTimestamp(timestamp metav1.Time, c clock.Clock) string {
 	return duration.ShortHumanDuration(c.Since(timestamp.Time))
 }
 
-func eventsForObject(object *unstructured.Unstructured, cache Cache, prefix, namespace string, cl clock.Clock) (*content.Table, error) {
-	eventObjects, err := cache.Events(object)
+func eventsForObject(ctx context.Context, object *unstructured.Unstructured, cache Cache, prefix, namespace string, cl clock.Clock) (*content.Table, error) {
+	eventObjects, err := cache.Events(ctx, object)
 	if err != nil {
 		return nil, err
 	}
@@ -3,7 +3,7 @@ package overview
 import (
 	"context"
 	"github.com/pkg/errors"
-	"github.com/twosson/kubeapt/internal/content"
+	"github.com/twosson/kubeapt/pkg/content"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -26,11 +26,11 @@ func (rss *CronJobSummary) Content(ctx context.Context, object runtime.Object, c
 		return nil, err
 	}
 
-	return rss.summary(cronJob, c)
+	return rss.summary(ctx, cronJob, c)
 }
 
-func (rss *CronJobSummary) summary(cronJob *batch.CronJob, c Cache) ([]content.Content, error) {
-	jobs, err := listJobs(cronJob.GetNamespace(), cronJob.GetUID(), c)
+func (rss *CronJobSummary) summary(ctx context.Context, cronJob *batch.CronJob, c Cache) ([]content.Content, error) {
+	jobs, err := listJobs(ctx, cronJob.GetNamespace(), cronJob.GetUID(), c)
 	if err != nil {
 		return nil, err
 	}
@@ -48,12 +48,14 @@ func (rss *CronJobSummary) summary(cronJob *batch.CronJob, c Cache) ([]content.C
 	return contents, nil
 }
 
-type CronJobJobs struct{}
+type CronJobJobs struct {
+	prefix string
+}
 
 var _ View = (*CronJobJobs)(nil)
 
 func NewCronJobJobs(prefix, namespace string, c clock.Clock) View {
-	return &CronJobJobs{}
+	return &CronJobJobs{prefix: prefix}
 }
 
 func (j *CronJobJobs) Content(ctx context.Context, object runtime.Object, c Cache) ([]content.Content, error) {
@@ -62,11 +64,11 @@ func (j *CronJobJobs) Content(ctx context.Context, object runtime.Object, c Cach
 		return nil, err
 	}
 
-	return j.jobs(cronJob, c)
+	return j.jobs(ctx, cronJob, c)
 }
 
-func (j *CronJobJobs) jobs(cronJob *batch.CronJob, c Cache) ([]content.Content, error) {
-	jobs, err := listJobs(cronJob.GetNamespace(), cronJob.GetUID(), c)
+func (j *CronJobJobs) jobs(ctx context.Context, cronJob *batch.CronJob, c Cache) ([]content.Content, error) {
+	jobs, err := listJobs(ctx, cronJob.GetNamespace(), cronJob.GetUID(), c)
 	if err != nil {
 		return nil, err
 	}
@@ -85,7 +87,7 @@ func (j *CronJobJobs) jobs(cronJob *batch.CronJob, c Cache) ([]content.Content,
 	err = printContentObject(
 		"Active Jobs",
 		"ns",
-		"prefix",
+		j.prefix,
 		"No active jobs",
 		jobTransforms,
 		&active,
@@ -98,7 +100,7 @@ func (j *CronJobJobs) jobs(cronJob *batch.CronJob, c Cache) ([]content.Content,
 	err = printContentObject(
 		"Inactive Jobs",
 		"ns",
-		"prefix",
+		j.prefix,
 		"No inactive jobs",
 		jobTransforms,
 		&inactive,
@@ -120,14 +122,14 @@ func retrieveCronJob(object runtime.Object) (*batch.CronJob, error) {
 	return replicaSet, nil
 }
 
-func listJobs(namespace string, uid types.UID, c Cache) ([]*batch.Job, error) {
+func listJobs(ctx context.Context, namespace string, uid types.UID, c Cache) ([]*batch.Job, error) {
 	key := CacheKey{
 		Namespace:  namespace,
 		APIVersion: "batch/v1",
 		Kind:       "Job",
 	}
 
-	jobs, err := loadJobs(key, c)
+	jobs, err := loadJobs(ctx, key, c)
 	if err != nil {
 		return nil, err
 	}
@@ -145,8 +147,8 @@ func listJobs(namespace string, uid types.UID, c Cache) ([]*batch.Job, error) {
 	return owned, nil
 }
 
-func loadJobs(key CacheKey, c Cache) ([]*batch.Job, error) {
-	objects, err := c.Retrieve(key)
+func loadJobs(ctx context.Context, key CacheKey, c Cache) ([]*batch.Job, error) {
+	objects, err := c.Retrieve(ctx, key)
 	if err != nil {
 		return nil, err
 	}
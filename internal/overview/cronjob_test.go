@@ -4,18 +4,18 @@ import (
 	"context"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"github.com/twosson/kubeapt/internal/content"
+	"github.com/twosson/kubeapt/pkg/content"
 	"k8s.io/apimachinery/pkg/util/clock"
 	"testing"
 	"time"
 )
 
 func TestCronJobSummary_InvalidObject(t *testing.T) {
-	assertViewInvalidObject(t, NewCronJobSummary("prefix", "ns", clock.NewFakeClock(time.Now())))
+	assertViewInvalidObject(t, NewCronJobSummary(testPrefix, "ns", clock.NewFakeClock(time.Now())))
 }
 
 func TestCronJobSummary(t *testing.T) {
-	s := NewCronJobSummary("prefix", "ns", clock.NewFakeClock(time.Now()))
+	s := NewCronJobSummary(testPrefix, "ns", clock.NewFakeClock(time.Now()))
 
 	ctx := context.Background()
 	cache := NewMemoryCache()
@@ -55,7 +55,7 @@ func TestCronJobSummary(t *testing.T) {
 }
 
 func TestCronJobJobs(t *testing.T) {
-	cjj := NewCronJobJobs("prefix", "ns", clock.NewFakeClock(time.Now()))
+	cjj := NewCronJobJobs(testPrefix, "ns", clock.NewFakeClock(time.Now()))
 
 	ctx := context.Background()
 	cache := NewMemoryCache()
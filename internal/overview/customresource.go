@@ -0,0 +1,387 @@
+package overview
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/twosson/kubeapt/internal/apt"
+	"github.com/twosson/kubeapt/internal/cluster"
+	"github.com/twosson/kubeapt/pkg/content"
+	apiextv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+var crdCacheKey = CacheKey{
+	APIVersion: "apiextensions.k8s.io/v1beta1",
+	Kind:       "CustomResourceDefinition",
+}
+
+const customResourcesPath = "/custom-resources"
+
+// CustomResourcesDescriber describes custom resources. The resources it
+// describes are discovered from the CustomResourceDefinitions in the cluster.
+type CustomResourcesDescriber struct {
+	*baseDescriber
+
+	path  string
+	title string
+}
+
+var _ Describer = (*CustomResourcesDescriber)(nil)
+
+// NewCustomResourcesDescriber creates an instance of CustomResourcesDescriber.
+func NewCustomResourcesDescriber(p, title string) *CustomResourcesDescriber {
+	return &CustomResourcesDescriber{
+		baseDescriber: newBaseDescriber(),
+		path:          p,
+		title:         title,
+	}
+}
+
+// Describe creates a list of custom resources for every CRD.
+func (d *CustomResourcesDescriber) Describe(ctx context.Context, prefix, namespace string, clusterClient cluster.ClientInterface, options DescriberOptions) (ContentResponse, error) {
+	crds, err := listCustomResourceDefinitions(ctx, options.Cache)
+	if err != nil {
+		return emptyContentResponse, err
+	}
+
+	var contents []content.Content
+
+	for _, crd := range crds {
+		tbl, err := d.list(ctx, prefix, crd, namespace, options.Cache)
+		if err != nil {
+			return emptyContentResponse, err
+		}
+
+		contents = append(contents, tbl)
+	}
+
+	return ContentResponse{
+		Views: []Content{
+			{Contents: contents, Title: d.title},
+		},
+	}, nil
+}
+
+// PathFilters returns path filters for the custom resources section, a custom
+// resource list, and a custom resource object.
+func (d *CustomResourcesDescriber) PathFilters(namespace string) []pathFilter {
+	return []pathFilter{
+		*newPathFilter(d.path, d),
+		*newPathFilter(path.Join(d.path, "(?P<crd>[^/]+)"), &customResourceListDescriber{parent: d}),
+		*newPathFilter(path.Join(d.path, "(?P<crd>[^/]+)", "(?P<name>[^/]+)"), &customResourceObjectDescriber{parent: d}),
+	}
+}
+
+func (d *CustomResourcesDescriber) list(ctx context.Context, prefix string, crd *apiextv1beta1.CustomResourceDefinition, namespace string, c Cache) (*content.Table, error) {
+	objects, err := loadCustomResources(ctx, crd, namespace, "", c)
+	if err != nil {
+		return nil, err
+	}
+
+	title := crd.Spec.Names.Kind
+	emptyMessage := fmt.Sprintf("Namespace %s does not have any %s", namespace, title)
+	if namespace == AllNamespaces {
+		emptyMessage = fmt.Sprintf("Cluster does not have any %s", title)
+	}
+
+	tbl := content.NewTable(title, emptyMessage)
+
+	withNamespace := showNamespace(namespace, objects)
+
+	columns := crdListColumns(crd)
+	if withNamespace {
+		tbl.Columns = append(tbl.Columns, tableCol("Namespace"))
+	}
+	tbl.Columns = append(tbl.Columns, tableCol("Name"))
+	for _, column := range columns {
+		tbl.Columns = append(tbl.Columns, tableCol(column.Name))
+	}
+
+	for _, object := range objects {
+		var name content.Text = content.NewLinkText(object.GetName(), customResourcePath(prefix, crd.Name, object.GetName()))
+		row := content.TableRow{
+			"Name": namespacedText(name, namespace, object.GetNamespace()),
+		}
+		if withNamespace {
+			row["Namespace"] = content.NewStringText(object.GetNamespace())
+		}
+
+		for _, column := range columns {
+			value, err := printCustomResourceColumn(object, column, d.clock())
+			if err != nil {
+				return nil, err
+			}
+
+			row[column.Name] = content.NewStringText(value)
+		}
+
+		tbl.AddRow(row)
+	}
+
+	return &tbl, nil
+}
+
+type customResourceListDescriber struct {
+	parent *CustomResourcesDescriber
+}
+
+func (d *customResourceListDescriber) Describe(ctx context.Context, prefix, namespace string, clusterClient cluster.ClientInterface, options DescriberOptions) (ContentResponse, error) {
+	crd, err := getCustomResourceDefinition(ctx, options.Fields["crd"], options.Cache)
+	if err != nil {
+		return emptyContentResponse, err
+	}
+
+	tbl, err := d.parent.list(ctx, prefix, crd, namespace, options.Cache)
+	if err != nil {
+		return emptyContentResponse, err
+	}
+
+	return ContentResponse{
+		Views: []Content{
+			{Contents: []content.Content{tbl}},
+		},
+	}, nil
+}
+
+func (d *customResourceListDescriber) PathFilters(namespace string) []pathFilter {
+	return nil
+}
+
+type customResourceObjectDescriber struct {
+	parent *CustomResourcesDescriber
+}
+
+func (d *customResourceObjectDescriber) Describe(ctx context.Context, prefix, namespace string, clusterClient cluster.ClientInterface, options DescriberOptions) (ContentResponse, error) {
+	crd, err := getCustomResourceDefinition(ctx, options.Fields["crd"], options.Cache)
+	if err != nil {
+		return emptyContentResponse, err
+	}
+
+	objects, err := loadCustomResources(ctx, crd, namespace, options.Fields["name"], options.Cache)
+	if err != nil {
+		return emptyContentResponse, err
+	}
+
+	if len(objects) != 1 {
+		return emptyContentResponse, errors.Errorf("expected exactly one object")
+	}
+
+	object := objects[0]
+
+	cl := d.parent.clock()
+
+	section, err := printCustomResourceSummary(crd, object, cl)
+	if err != nil {
+		return emptyContentResponse, err
+	}
+
+	summary := content.NewSummary("Details", []content.Section{section})
+
+	events, err := eventsForObject(ctx, object, options.Cache, prefix, namespace, cl)
+	if err != nil {
+		return emptyContentResponse, err
+	}
+
+	return ContentResponse{
+		Title: fmt.Sprintf("%s: %s", crd.Spec.Names.Kind, object.GetName()),
+		Views: []Content{
+			{
+				Contents: []content.Content{&summary, events},
+				Title:    "Summary",
+			},
+		},
+	}, nil
+}
+
+func (d *customResourceObjectDescriber) PathFilters(namespace string) []pathFilter {
+	return nil
+}
+
+func printCustomResourceSummary(crd *apiextv1beta1.CustomResourceDefinition, object *unstructured.Unstructured, c clock.Clock) (content.Section, error) {
+	section := content.NewSection()
+
+	section.AddText("Name", object.GetName())
+	if object.GetNamespace() != "" {
+		section.AddText("Namespace", object.GetNamespace())
+	}
+	section.AddLabels("Labels", object.GetLabels())
+	section.AddList("Annotations", object.GetAnnotations())
+
+	creationTimestamp := object.GetCreationTimestamp()
+	section.AddTimestamp("Creation Time", formatTime(&creationTimestamp))
+
+	for _, column := range crd.Spec.AdditionalPrinterColumns {
+		value, err := printCustomResourceColumn(object, column, c)
+		if err != nil {
+			return content.Section{}, err
+		}
+
+		section.AddText(column.Name, value)
+	}
+
+	return section, nil
+}
+
+// crdListColumns returns the columns shown when listing the custom resources for
+// a CRD. Columns with a non-zero priority are only shown in the detail view. If
+// the CRD does not define any printer columns, an age column is used.
+func crdListColumns(crd *apiextv1beta1.CustomResourceDefinition) []apiextv1beta1.CustomResourceColumnDefinition {
+	var columns []apiextv1beta1.CustomResourceColumnDefinition
+	for _, column := range crd.Spec.AdditionalPrinterColumns {
+		if column.Priority == 0 {
+			columns = append(columns, column)
+		}
+	}
+
+	if len(columns) == 0 {
+		columns = append(columns, apiextv1beta1.CustomResourceColumnDefinition{
+			Name:     "Age",
+			Type:     "date",
+			JSONPath: ".metadata.creationTimestamp",
+		})
+	}
+
+	return columns
+}
+
+// printCustomResourceColumn evaluates the JSONPath for a printer column against
+// an object.
+func printCustomResourceColumn(object *unstructured.Unstructured, column apiextv1beta1.CustomResourceColumnDefinition, c clock.Clock) (string, error) {
+	jp := jsonpath.New(column.Name)
+	jp.AllowMissingKeys(true)
+
+	if err := jp.Parse(fmt.Sprintf("{%s}", column.JSONPath)); err != nil {
+		return "", errors.Wrapf(err, "parsing JSONPath for column %q", column.Name)
+	}
+
+	var buf bytes.Buffer
+	if err := jp.Execute(&buf, object.Object); err != nil {
+		return "", errors.Wrapf(err, "evaluating JSONPath for column %q", column.Name)
+	}
+
+	value := strings.TrimSpace(buf.String())
+	if value == "" {
+		return "<none>", nil
+	}
+
+	if column.Type == "date" {
+		var t metav1.Time
+		if err := t.UnmarshalQueryParameter(value); err != nil {
+			return value, nil
+		}
+		return translateTimestamp(t, c), nil
+	}
+
+	return value, nil
+}
+
+// customResourcePath returns the link to a custom resource shown by the
+// overview handler served from prefix.
+func customResourcePath(prefix, crdName, name string) string {
+	return path.Join(apt.LinkRoot(prefix), customResourcesPath, crdName, name)
+}
+
+// crdAPIVersion returns the API version used to retrieve the custom resources
+// for a CRD.
+func crdAPIVersion(crd *apiextv1beta1.CustomResourceDefinition) string {
+	version := crd.Spec.Version
+	for _, v := range crd.Spec.Versions {
+		if v.Storage {
+			version = v.Name
+			break
+		}
+	}
+
+	return path.Join(crd.Spec.Group, version)
+}
+
+func loadCustomResources(ctx context.Context, crd *apiextv1beta1.CustomResourceDefinition, namespace, name string, c Cache) ([]*unstructured.Unstructured, error) {
+	key := CacheKey{
+		APIVersion: crdAPIVersion(crd),
+		Kind:       crd.Spec.Names.Kind,
+		Name:       name,
+	}
+
+	if crd.Spec.Scope == apiextv1beta1.NamespaceScoped {
+		key.Namespace = namespace
+	}
+
+	objects, err := c.Retrieve(ctx, key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "retrieving %s", crd.Spec.Names.Plural)
+	}
+
+	sort.SliceStable(objects, func(i, j int) bool {
+		a, b := objects[i], objects[j]
+		if a.GetNamespace() != b.GetNamespace() {
+			return a.GetNamespace() < b.GetNamespace()
+		}
+		return a.GetName() < b.GetName()
+	})
+
+	return objects, nil
+}
+
+// listCustomResourceDefinitions lists CRDs sorted by name.
+func listCustomResourceDefinitions(ctx context.Context, c Cache) ([]*apiextv1beta1.CustomResourceDefinition, error) {
+	objects, err := c.Retrieve(ctx, crdCacheKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "retrieving custom resource definitions")
+	}
+
+	var list []*apiextv1beta1.CustomResourceDefinition
+
+	for _, object := range objects {
+		crd := &apiextv1beta1.CustomResourceDefinition{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, crd); err != nil {
+			return nil, errors.Wrap(err, "converting unstructured custom resource definition")
+		}
+
+		list = append(list, crd)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+
+	return list, nil
+}
+
+func getCustomResourceDefinition(ctx context.Context, name string, c Cache) (*apiextv1beta1.CustomResourceDefinition, error) {
+	crds, err := listCustomResourceDefinitions(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, crd := range crds {
+		if crd.Name == name {
+			return crd, nil
+		}
+	}
+
+	return nil, contentNotFound
+}
+
+// customResourceNavigation creates navigation entries for CRDs.
+func customResourceNavigation(root string, crds []*apiextv1beta1.CustomResourceDefinition) []*apt.Navigation {
+	var entries []*apt.Navigation
+
+	for _, crd := range crds {
+		entries = append(entries, &apt.Navigation{
+			Title: crd.Spec.Names.Kind,
+			Path:  path.Join(root, customResourcesPath, crd.Name),
+		})
+	}
+
+	return entries
+}
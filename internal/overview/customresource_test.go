@@ -0,0 +1,190 @@
+package overview
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twosson/kubeapt/pkg/content"
+	apiextv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/clock"
+)
+
+func newCRDCache(t *testing.T) *MemoryCache {
+	c := NewMemoryCache()
+
+	crd := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "apiextensions.k8s.io/v1beta1",
+			"kind":       "CustomResourceDefinition",
+			"metadata": map[string]interface{}{
+				"name": "crontabs.stable.example.com",
+			},
+			"spec": map[string]interface{}{
+				"group":   "stable.example.com",
+				"version": "v1",
+				"scope":   "Namespaced",
+				"names": map[string]interface{}{
+					"plural":   "crontabs",
+					"singular": "crontab",
+					"kind":     "CronTab",
+				},
+				"additionalPrinterColumns": []interface{}{
+					map[string]interface{}{
+						"name":     "Spec",
+						"type":     "string",
+						"JSONPath": ".spec.cronSpec",
+					},
+					map[string]interface{}{
+						"name":     "Replicas",
+						"type":     "integer",
+						"JSONPath": ".spec.replicas",
+						"priority": int64(1),
+					},
+				},
+			},
+		},
+	}
+	require.NoError(t, c.Store(crd))
+
+	for _, name := range []string{"b-crontab", "a-crontab"} {
+		cr := newUnstructured("stable.example.com/v1", "CronTab", "default", name)
+		cr.Object["spec"] = map[string]interface{}{
+			"cronSpec": "* * * * */5",
+			"replicas": int64(1),
+		}
+		require.NoError(t, c.Store(cr))
+	}
+
+	return c
+}
+
+func TestCustomResourcesDescriber_list(t *testing.T) {
+	c := newCRDCache(t)
+
+	d := NewCustomResourcesDescriber(customResourcesPath, "Custom Resources")
+	ld := &customResourceListDescriber{parent: d}
+
+	options := DescriberOptions{
+		Cache:  c,
+		Fields: map[string]string{"crd": "crontabs.stable.example.com"},
+	}
+
+	got, err := ld.Describe(context.Background(), testPrefix, "default", nil, options)
+	require.NoError(t, err)
+
+	require.Len(t, got.Views, 1)
+	require.Len(t, got.Views[0].Contents, 1)
+
+	tbl, ok := got.Views[0].Contents[0].(*content.Table)
+	require.True(t, ok)
+
+	assert.Equal(t, "CronTab", tbl.Title)
+	assert.Equal(t, tableCols("Name", "Spec"), tbl.Columns)
+
+	expectedRows := []content.TableRow{
+		{
+			"Name": content.NewLinkText("a-crontab", "/content/overview/custom-resources/crontabs.stable.example.com/a-crontab"),
+			"Spec": content.NewStringText("* * * * */5"),
+		},
+		{
+			"Name": content.NewLinkText("b-crontab", "/content/overview/custom-resources/crontabs.stable.example.com/b-crontab"),
+			"Spec": content.NewStringText("* * * * */5"),
+		},
+	}
+	assert.Equal(t, expectedRows, tbl.Rows)
+}
+
+func TestCustomResourcesDescriber_list_unknown_crd(t *testing.T) {
+	c := newCRDCache(t)
+
+	d := NewCustomResourcesDescriber(customResourcesPath, "Custom Resources")
+	ld := &customResourceListDescriber{parent: d}
+
+	options := DescriberOptions{
+		Cache:  c,
+		Fields: map[string]string{"crd": "missing.stable.example.com"},
+	}
+
+	_, err := ld.Describe(context.Background(), testPrefix, "default", nil, options)
+	require.Equal(t, contentNotFound, err)
+}
+
+func TestCustomResourcesDescriber_object(t *testing.T) {
+	c := newCRDCache(t)
+
+	d := NewCustomResourcesDescriber(customResourcesPath, "Custom Resources")
+	od := &customResourceObjectDescriber{parent: d}
+
+	options := DescriberOptions{
+		Cache: c,
+		Fields: map[string]string{
+			"crd":  "crontabs.stable.example.com",
+			"name": "a-crontab",
+		},
+	}
+
+	got, err := od.Describe(context.Background(), testPrefix, "default", nil, options)
+	require.NoError(t, err)
+
+	assert.Equal(t, "CronTab: a-crontab", got.Title)
+	require.Len(t, got.Views, 1)
+	require.Len(t, got.Views[0].Contents, 2)
+
+	summary, ok := got.Views[0].Contents[0].(*content.Summary)
+	require.True(t, ok)
+	require.Len(t, summary.Sections, 1)
+
+	data := map[string]content.Item{}
+	for _, item := range summary.Sections[0].Items {
+		data[item.Label] = item
+	}
+
+	assert.Equal(t, content.TextItem("Name", "a-crontab"), data["Name"])
+	assert.Equal(t, content.TextItem("Namespace", "default"), data["Namespace"])
+	assert.Equal(t, content.TextItem("Spec", "* * * * */5"), data["Spec"])
+	assert.Equal(t, content.TextItem("Replicas", "1"), data["Replicas"])
+}
+
+func Test_printCustomResourceColumn_date(t *testing.T) {
+	now := time.Unix(1547211430, 0)
+	cl := clock.NewFakeClock(now)
+
+	object := newUnstructured("stable.example.com/v1", "CronTab", "default", "crontab")
+	object.SetCreationTimestamp(metav1.NewTime(now.Add(-2 * time.Hour)))
+
+	columns := crdListColumns(&apiextv1beta1.CustomResourceDefinition{})
+	require.Len(t, columns, 1)
+
+	got, err := printCustomResourceColumn(object, columns[0], cl)
+	require.NoError(t, err)
+
+	assert.Equal(t, "2h", got)
+}
+
+func Test_customResourceNavigation(t *testing.T) {
+	c := newCRDCache(t)
+
+	crds, err := listCustomResourceDefinitions(context.Background(), c)
+	require.NoError(t, err)
+
+	got, err := navigationEntries("/content/overview", crds)
+	require.NoError(t, err)
+
+	var crNav []string
+	for _, child := range got.Children {
+		if child.Title != "Custom Resources" {
+			continue
+		}
+
+		for _, crdChild := range child.Children {
+			crNav = append(crNav, crdChild.Path)
+		}
+	}
+
+	assert.Equal(t, []string{"/content/overview/custom-resources/crontabs.stable.example.com"}, crNav)
+}
@@ -0,0 +1,175 @@
+package overview
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/twosson/kubeapt/internal/cluster"
+	"github.com/twosson/kubeapt/pkg/content"
+	apiextv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// CustomResourceDefinitionsDescriber describes CRDs. CRDs are not part of the
+// client scheme, so they can't be described with a Resource.
+type CustomResourceDefinitionsDescriber struct {
+	*baseDescriber
+
+	path  string
+	title string
+}
+
+var _ Describer = (*CustomResourceDefinitionsDescriber)(nil)
+
+// NewCustomResourceDefinitionsDescriber creates an instance of CustomResourceDefinitionsDescriber.
+func NewCustomResourceDefinitionsDescriber(p, title string) *CustomResourceDefinitionsDescriber {
+	return &CustomResourceDefinitionsDescriber{
+		baseDescriber: newBaseDescriber(),
+		path:          p,
+		title:         title,
+	}
+}
+
+// Describe creates a list of CRDs.
+func (d *CustomResourceDefinitionsDescriber) Describe(ctx context.Context, prefix, namespace string, clusterClient cluster.ClientInterface, options DescriberOptions) (ContentResponse, error) {
+	crds, err := listCustomResourceDefinitions(ctx, options.Cache)
+	if err != nil {
+		return emptyContentResponse, err
+	}
+
+	tbl := content.NewTable(d.title, "Cluster does not have any Custom Resource Definitions")
+	tbl.Columns = tableCols("Name", "Group", "Versions", "Scope", "Kind", "Age")
+
+	cl := d.clock()
+
+	for _, crd := range crds {
+		tbl.AddRow(content.TableRow{
+			"Name":     content.NewLinkText(crd.Name, gvkPath(prefix, crdCacheKey.APIVersion, crdCacheKey.Kind, crd.Name)),
+			"Group":    content.NewStringText(crd.Spec.Group),
+			"Versions": content.NewStringText(strings.Join(crdVersions(crd), ", ")),
+			"Scope":    content.NewStringText(string(crd.Spec.Scope)),
+			"Kind":     content.NewStringText(crd.Spec.Names.Kind),
+			"Age":      content.NewStringText(translateTimestamp(crd.CreationTimestamp, cl)),
+		})
+	}
+
+	return ContentResponse{
+		Views: []Content{
+			{Contents: []content.Content{&tbl}},
+		},
+	}, nil
+}
+
+// PathFilters returns path filters for the CRD list and CRD objects.
+func (d *CustomResourceDefinitionsDescriber) PathFilters(namespace string) []pathFilter {
+	return []pathFilter{
+		*newPathFilter(d.path, d),
+		*newPathFilter(path.Join(d.path, "(?P<name>.*?)"), &customResourceDefinitionDescriber{parent: d}),
+	}
+}
+
+type customResourceDefinitionDescriber struct {
+	parent *CustomResourceDefinitionsDescriber
+}
+
+func (d *customResourceDefinitionDescriber) Describe(ctx context.Context, prefix, namespace string, clusterClient cluster.ClientInterface, options DescriberOptions) (ContentResponse, error) {
+	crd, err := getCustomResourceDefinition(ctx, options.Fields["name"], options.Cache)
+	if err != nil {
+		return emptyContentResponse, err
+	}
+
+	cl := d.parent.clock()
+
+	detail := printCustomResourceDefinitionSummary(prefix, crd)
+	summary := content.NewSummary("Details", []content.Section{detail})
+
+	columns := printCustomResourceDefinitionColumns(crd)
+
+	m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(crd)
+	if err != nil {
+		return emptyContentResponse, err
+	}
+
+	events, err := eventsForObject(ctx, &unstructured.Unstructured{Object: m}, options.Cache, prefix, namespace, cl)
+	if err != nil {
+		return emptyContentResponse, err
+	}
+
+	return ContentResponse{
+		Title: fmt.Sprintf("Custom Resource Definition: %s", crd.Name),
+		Views: []Content{
+			{
+				Contents: []content.Content{&summary, &columns, events},
+				Title:    "Summary",
+			},
+		},
+	}, nil
+}
+
+func (d *customResourceDefinitionDescriber) PathFilters(namespace string) []pathFilter {
+	return nil
+}
+
+func printCustomResourceDefinitionSummary(prefix string, crd *apiextv1beta1.CustomResourceDefinition) content.Section {
+	section := content.NewSection()
+	section.AddText("Name", crd.Name)
+
+	section.AddLabels("Labels", crd.GetLabels())
+	section.AddList("Annotations", crd.GetAnnotations())
+	section.AddTimestamp("Creation Time", formatTime(&crd.CreationTimestamp))
+
+	section.AddText("Group", crd.Spec.Group)
+	section.AddText("Versions", strings.Join(crdVersions(crd), ", "))
+	section.AddText("Scope", string(crd.Spec.Scope))
+	section.AddText("Kind", crd.Spec.Names.Kind)
+	section.AddText("Plural", crd.Spec.Names.Plural)
+	section.AddText("Short Names", stringOrNone(strings.Join(crd.Spec.Names.ShortNames, ", ")))
+
+	established := "False"
+	for _, condition := range crd.Status.Conditions {
+		if condition.Type == apiextv1beta1.Established {
+			established = string(condition.Status)
+		}
+	}
+	section.AddText("Established", established)
+
+	section.AddLink("Custom Resources", crd.Spec.Names.Kind, customResourcePath(prefix, crd.Name, ""))
+
+	return section
+}
+
+func printCustomResourceDefinitionColumns(crd *apiextv1beta1.CustomResourceDefinition) content.Table {
+	table := content.NewTable("Printer Columns", "No printer columns are configured for this Custom Resource Definition")
+	table.Columns = tableCols("Name", "Type", "JSONPath", "Priority", "Description")
+
+	for _, column := range crd.Spec.AdditionalPrinterColumns {
+		table.AddRow(content.TableRow{
+			"Name":        content.NewStringText(column.Name),
+			"Type":        content.NewStringText(column.Type),
+			"JSONPath":    content.NewStringText(column.JSONPath),
+			"Priority":    content.NewStringText(fmt.Sprintf("%d", column.Priority)),
+			"Description": content.NewStringText(column.Description),
+		})
+	}
+
+	return table
+}
+
+// crdVersions returns the served versions for a CRD.
+func crdVersions(crd *apiextv1beta1.CustomResourceDefinition) []string {
+	if len(crd.Spec.Versions) == 0 {
+		return []string{crd.Spec.Version}
+	}
+
+	var versions []string
+	for _, version := range crd.Spec.Versions {
+		if version.Served {
+			versions = append(versions, version.Name)
+		}
+	}
+
+	return versions
+}
@@ -3,7 +3,7 @@ package overview
 import (
 	"context"
 	"github.com/pkg/errors"
-	"github.com/twosson/kubeapt/internal/content"
+	"github.com/twosson/kubeapt/pkg/content"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/clock"
 	"k8s.io/kubernetes/pkg/apis/extensions"
@@ -23,11 +23,11 @@ func (rss *DaemonSetSummary) Content(ctx context.Context, object runtime.Object,
 		return nil, err
 	}
 
-	return rss.summary(replicaSet, c)
+	return rss.summary(ctx, replicaSet, c)
 }
 
-func (rss *DaemonSetSummary) summary(replicaSet *extensions.DaemonSet, c Cache) ([]content.Content, error) {
-	pods, err := listPods(replicaSet.GetNamespace(), replicaSet.Spec.Selector, replicaSet.GetUID(), c)
+func (rss *DaemonSetSummary) summary(ctx context.Context, replicaSet *extensions.DaemonSet, c Cache) ([]content.Content, error) {
+	pods, err := listPods(ctx, replicaSet.GetNamespace(), replicaSet.Spec.Selector, replicaSet.GetUID(), c)
 	if err != nil {
 		return nil, err
 	}
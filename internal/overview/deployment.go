@@ -3,7 +3,7 @@ package overview
 import (
 	"context"
 	"github.com/pkg/errors"
-	"github.com/twosson/kubeapt/internal/content"
+	"github.com/twosson/kubeapt/pkg/content"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -15,12 +15,14 @@ import (
 	"sort"
 )
 
-type DeploymentSummary struct{}
+type DeploymentSummary struct {
+	prefix string
+}
 
 var _ View = (*DeploymentSummary)(nil)
 
 func NewDeploymentSummary(prefix, namespace string, c clock.Clock) View {
-	return &DeploymentSummary{}
+	return &DeploymentSummary{prefix: prefix}
 }
 
 func (ds *DeploymentSummary) Content(ctx context.Context, object runtime.Object, c Cache) ([]content.Content, error) {
@@ -29,11 +31,16 @@ func (ds *DeploymentSummary) Content(ctx context.Context, object runtime.Object,
 		return nil, err
 	}
 
-	return ds.summary(deployment)
+	return ds.summary(ctx, deployment, c)
 }
 
-func (ds *DeploymentSummary) summary(deployment *extensions.Deployment) ([]content.Content, error) {
-	section, err := printDeploymentSummary(deployment)
+func (ds *DeploymentSummary) summary(ctx context.Context, deployment *extensions.Deployment, c Cache) ([]content.Content, error) {
+	hpas, err := findManagingHorizontalPodAutoscalers(ctx, c, deployment.GetNamespace(), "Deployment", deployment.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	section, err := printDeploymentSummary(ds.prefix, deployment, hpas)
 	if err != nil {
 		return nil, err
 	}
@@ -46,12 +53,14 @@ func (ds *DeploymentSummary) summary(deployment *extensions.Deployment) ([]conte
 	return contents, nil
 }
 
-type DeploymentReplicaSets struct{}
+type DeploymentReplicaSets struct {
+	prefix string
+}
 
 var _ View = (*DeploymentReplicaSets)(nil)
 
 func NewDeploymentReplicaSets(prefix, namespace string, c clock.Clock) View {
-	return &DeploymentReplicaSets{}
+	return &DeploymentReplicaSets{prefix: prefix}
 }
 
 func (drs *DeploymentReplicaSets) Content(ctx context.Context, object runtime.Object, c Cache) ([]content.Content, error) {
@@ -62,7 +71,7 @@ func (drs *DeploymentReplicaSets) Content(ctx context.Context, object runtime.Ob
 		return nil, errors.Wrap(err, "retrieving deployment")
 	}
 
-	replicaSetContent, err := drs.replicaSets(deployment, c)
+	replicaSetContent, err := drs.replicaSets(ctx, deployment, c)
 	if err != nil {
 		return nil, errors.Wrap(err, "rendering replicasets")
 	}
@@ -71,10 +80,10 @@ func (drs *DeploymentReplicaSets) Content(ctx context.Context, object runtime.Ob
 	return contents, nil
 }
 
-func (drs *DeploymentReplicaSets) replicaSets(deployment *extensions.Deployment, c Cache) ([]content.Content, error) {
+func (drs *DeploymentReplicaSets) replicaSets(ctx context.Context, deployment *extensions.Deployment, c Cache) ([]content.Content, error) {
 	contents := []content.Content{}
 
-	replicaSets, err := listReplicaSets(deployment, c)
+	replicaSets, err := listReplicaSets(ctx, deployment, c)
 	if err != nil {
 		return nil, err
 	}
@@ -85,7 +94,7 @@ func (drs *DeploymentReplicaSets) replicaSets(deployment *extensions.Deployment,
 		err = printContentObject(
 			"New Replica Set",
 			"",
-			"",
+			drs.prefix,
 			"This Deployment does not have a current Replica",
 			replicaSetTransforms,
 			newReplicaSet,
@@ -104,7 +113,7 @@ func (drs *DeploymentReplicaSets) replicaSets(deployment *extensions.Deployment,
 	err = printContentObject(
 		"Old Replica Sets",
 		"",
-		"",
+		drs.prefix,
 		"This Deployment does not have any old Replicas",
 		replicaSetTransforms,
 		oldList,
@@ -124,7 +133,7 @@ func printContentObject(title, namespace, prefix, emptyMessage string, transform
 
 	otf := summaryFunc(title, emptyMessage, transforms)
 	transformed := otf(namespace, prefix, contents)
-	return printObject(object, transformed)
+	return printObject(object, false, transformed)
 }
 
 func retrieveDeployment(object runtime.Object) (*extensions.Deployment, error) {
@@ -136,14 +145,14 @@ func retrieveDeployment(object runtime.Object) (*extensions.Deployment, error) {
 	return deployment, nil
 }
 
-func listReplicaSets(deployment *extensions.Deployment, c Cache) ([]*extensions.ReplicaSet, error) {
+func listReplicaSets(ctx context.Context, deployment *extensions.Deployment, c Cache) ([]*extensions.ReplicaSet, error) {
 	key := CacheKey{
 		Namespace:  deployment.GetNamespace(),
 		APIVersion: deployment.APIVersion,
 		Kind:       "ReplicaSet",
 	}
 
-	replicaSets, err := loadReplicaSets(key, c, deployment.Spec.Selector)
+	replicaSets, err := loadReplicaSets(ctx, key, c, deployment.Spec.Selector)
 	if err != nil {
 		return nil, err
 	}
@@ -158,8 +167,8 @@ func listReplicaSets(deployment *extensions.Deployment, c Cache) ([]*extensions.
 	return owned, nil
 }
 
-func loadReplicaSets(key CacheKey, c Cache, selector *metav1.LabelSelector) ([]*extensions.ReplicaSet, error) {
-	objects, err := c.Retrieve(key)
+func loadReplicaSets(ctx context.Context, key CacheKey, c Cache, selector *metav1.LabelSelector) ([]*extensions.ReplicaSet, error) {
+	objects, err := c.Retrieve(ctx, key)
 	if err != nil {
 		return nil, err
 	}
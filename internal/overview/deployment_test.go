@@ -4,7 +4,7 @@ import (
 	"context"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"github.com/twosson/kubeapt/internal/content"
+	"github.com/twosson/kubeapt/pkg/content"
 	"io/ioutil"
 	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
@@ -26,11 +26,11 @@ import (
 )
 
 func TestDeploymentSummary_InvalidObject(t *testing.T) {
-	assertViewInvalidObject(t, NewDeploymentSummary("prefix", "ns", clock.NewFakeClock(time.Now())))
+	assertViewInvalidObject(t, NewDeploymentSummary(testPrefix, "ns", clock.NewFakeClock(time.Now())))
 }
 
 func TestDeploymentSummary(t *testing.T) {
-	ds := NewDeploymentSummary("prefix", "ns", clock.NewFakeClock(time.Now()))
+	ds := NewDeploymentSummary(testPrefix, "ns", clock.NewFakeClock(time.Now()))
 
 	ctx := context.Background()
 
@@ -111,8 +111,38 @@ func TestDeploymentSummary(t *testing.T) {
 	assert.Equal(t, expected, contents)
 }
 
+func TestDeploymentSummary_managedBy(t *testing.T) {
+	ds := NewDeploymentSummary(testPrefix, "ns", clock.NewFakeClock(time.Now()))
+
+	cache := NewMemoryCache()
+	storeObject(t, cache, newTestHPA("web", "Deployment", "web"))
+
+	object := &extensions.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web",
+			Namespace: "default",
+		},
+		Spec: extensions.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+		},
+	}
+
+	contents, err := ds.Content(context.Background(), object, cache)
+	require.NoError(t, err)
+	require.Len(t, contents, 1)
+
+	summary, ok := contents[0].(*content.Summary)
+	require.True(t, ok)
+	require.Len(t, summary.Sections, 1)
+
+	items := summary.Sections[0].Items
+	assert.Equal(t,
+		content.LinkItem("Managed By", "HPA web", "/content/overview/workloads/horizontal-pod-autoscalers/web"),
+		items[len(items)-1])
+}
+
 func TestDeploymentReplicaSets(t *testing.T) {
-	drs := NewDeploymentReplicaSets("prefix", "ns", clock.NewFakeClock(time.Now()))
+	drs := NewDeploymentReplicaSets(testPrefix, "ns", clock.NewFakeClock(time.Now()))
 
 	ctx := context.Background()
 
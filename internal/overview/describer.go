@@ -4,10 +4,11 @@ import (
 	"context"
 	"fmt"
 	"github.com/twosson/kubeapt/internal/cluster"
-	"github.com/twosson/kubeapt/internal/content"
+	"github.com/twosson/kubeapt/pkg/content"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"net/url"
 	"reflect"
 
 	"github.com/pkg/errors"
@@ -61,6 +62,9 @@ type ListDescriber struct {
 	objectType          func() interface{}
 	cacheKey            CacheKey
 	objectTransformFunc ObjectTransformFunc
+
+	// columns are added to the list table.
+	columns []ResourceColumn
 }
 
 func NewListDescriber(p, title string, cacheKey CacheKey, listType, objectType func() interface{}, otf ObjectTransformFunc) *ListDescriber {
@@ -111,11 +115,14 @@ func (d *ListDescriber) Describe(ctx context.Context, prefix, namespace string,
 			list)
 	}
 
-	otf := d.objectTransformFunc(namespace, prefix, &contents)
-	if err := printObject(listObject, otf); err != nil {
+	otf := withResourceColumns(ctx, options.Cache, d.objectTransformFunc, d.columns)(namespace, prefix, &contents)
+	if err := printObject(listObject, showNamespace(namespace, objects), otf); err != nil {
 		return emptyContentResponse, err
 	}
 
+	setTableFilters(contents, listSelectorsFromContext(ctx))
+	applyTableOptions(contents, tableOptionsFromContext(ctx))
+
 	return ContentResponse{
 		Views: []Content{
 			{
@@ -140,6 +147,10 @@ type ObjectDescriber struct {
 	objectType func() interface{}
 	loaderFunc LoaderFunc
 	sections   []ContentSection
+
+	// resourcePath and actions advertise the actions for the object.
+	resourcePath string
+	actions      []string
 }
 
 func NewObjectDescriber(p, baseTitle string, loaderFunc LoaderFunc, objectType func() interface{}, sections []ContentSection) *ObjectDescriber {
@@ -192,7 +203,8 @@ func (d *ObjectDescriber) Describe(ctx context.Context, prefix, namespace string
 	}
 
 	cr := ContentResponse{
-		Title: title,
+		Title:   title,
+		Actions: objectActions(prefix, d.resourcePath, d.actions, newObject),
 	}
 
 	cl := &clock.RealClock{}
@@ -203,7 +215,17 @@ func (d *ObjectDescriber) Describe(ctx context.Context, prefix, namespace string
 			view := viewFactory(prefix, namespace, cl)
 			viewContent, err := view.Content(ctx, newObject, options.Cache)
 			if err != nil {
-				return emptyContentResponse, err
+				// Views with objects which are still loading, or which
+				// the user can't list, are explained in place, so the
+				// rest of the object can be shown.
+				switch {
+				case isLoading(err):
+					viewContent = []content.Content{loadingContent(err)}
+				case isForbidden(err):
+					viewContent = []content.Content{forbiddenContent(err)}
+				default:
+					return emptyContentResponse, err
+				}
 			}
 
 			contents = append(contents, viewContent...)
@@ -257,11 +279,29 @@ func copyObjectMeta(to interface{}, from *unstructured.Unstructured) error {
 	return nil
 }
 
-func printObject(object runtime.Object, transformFunc func(*metav1beta1.Table) error) error {
+// showNamespace reports whether a list of objects needs a namespace column.
+// It does when objects from every namespace are listed, unless they are
+// cluster scoped.
+func showNamespace(namespace string, objects []*unstructured.Unstructured) bool {
+	if namespace != AllNamespaces {
+		return false
+	}
+
+	for _, object := range objects {
+		if object.GetNamespace() != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func printObject(object runtime.Object, withNamespace bool, transformFunc func(*metav1beta1.Table) error) error {
 	options := kprinters.PrintOptions{
-		Wide:       true,
-		ShowLabels: true,
-		WithKind:   true,
+		Wide:          true,
+		ShowLabels:    true,
+		WithKind:      true,
+		WithNamespace: withNamespace,
 	}
 
 	decoder := scheme.Codecs.UniversalDecoder()
@@ -298,9 +338,25 @@ func printContentTable(title, namespace, prefix, emptyMessage string, tbl *metav
 
 	transforms := buildTransforms(m)
 
+	namespaceColumn := -1
+	if namespace == AllNamespaces {
+		for pos, header := range headers {
+			if header == "Namespace" {
+				namespaceColumn = pos
+			}
+		}
+	}
+
 	for _, row := range tbl.Rows {
 		contentRow := content.TableRow{}
 
+		// Objects listed from every namespace are described in their own
+		// namespace.
+		rowNamespace := namespace
+		if namespaceColumn >= 0 {
+			rowNamespace = fmt.Sprintf("%v", row.Cells[namespaceColumn])
+		}
+
 		for pos, header := range headers {
 			cell := row.Cells[pos]
 
@@ -308,7 +364,7 @@ func printContentTable(title, namespace, prefix, emptyMessage string, tbl *metav
 			if !ok {
 				contentRow[header] = content.NewStringText(fmt.Sprintf("%v", cell))
 			} else {
-				contentRow[header] = c(namespace, prefix, cell)
+				contentRow[header] = namespacedText(c(rowNamespace, prefix, cell), namespace, rowNamespace)
 			}
 		}
 
@@ -318,6 +374,26 @@ func printContentTable(title, namespace, prefix, emptyMessage string, tbl *metav
 	return &contentTable, nil
 }
 
+// namespacedText qualifies links to objects listed from every namespace with
+// the namespace of the object.
+func namespacedText(text content.Text, namespace, objectNamespace string) content.Text {
+	link, ok := text.(*content.LinkText)
+	if !ok || namespace != AllNamespaces || objectNamespace == AllNamespaces {
+		return text
+	}
+
+	u, err := url.Parse(link.Ref)
+	if err != nil {
+		return text
+	}
+
+	query := u.Query()
+	query.Set("namespace", objectNamespace)
+	u.RawQuery = query.Encode()
+
+	return content.NewLinkText(link.Text, u.String())
+}
+
 // SectionDescriber is a wrapper to combine content from multiple describers.
 type SectionDescriber struct {
 	path       string
@@ -337,10 +413,27 @@ func NewSectionDescriber(p, title string, describers ...Describer) *SectionDescr
 // Describe generates content.
 func (d *SectionDescriber) Describe(ctx context.Context, prefix, namespace string, clusterClient cluster.ClientInterface, options DescriberOptions) (ContentResponse, error) {
 	var contents []content.Content
+	var forbiddenErr error
+	forbiddenCount := 0
+
+	if starter, ok := options.Cache.(informerStarter); ok {
+		starter.startInformers(ctx, d.cacheKeys(namespace))
+	}
 
 	for _, child := range d.describers {
 		cResponse, err := child.Describe(ctx, prefix, namespace, clusterClient, options)
 		if err != nil {
+			// Content the user can't list is left out of the section. The
+			// section is forbidden if none of its content can be listed.
+			if isForbidden(err) {
+				forbiddenErr = err
+				forbiddenCount++
+				continue
+			}
+			if isLoading(err) {
+				contents = append(contents, loadingContent(err))
+				continue
+			}
 			return emptyContentResponse, err
 		}
 
@@ -353,8 +446,18 @@ func (d *SectionDescriber) Describe(ctx context.Context, prefix, namespace strin
 		}
 	}
 
+	if len(d.describers) > 0 && forbiddenCount == len(d.describers) {
+		return emptyContentResponse, forbiddenErr
+	}
+
 	if len(contents) == 0 {
-		tbl := content.NewTable(d.title, fmt.Sprintf("Namespace %s does not have any resources of this type", namespace))
+		emptyMessage := fmt.Sprintf("Namespace %s does not have any resources of this type", namespace)
+		if namespace == AllNamespaces {
+			emptyMessage = "Cluster does not have any resources of this type"
+		}
+		selectors := listSelectorsFromContext(ctx)
+		tbl := content.NewTable(d.title, selectors.emptyMessage(emptyMessage))
+		tbl.Filters = selectors.tableFilters()
 		contents = append(contents, &tbl)
 	}
 
@@ -367,6 +470,28 @@ func (d *SectionDescriber) Describe(ctx context.Context, prefix, namespace strin
 	return cr, nil
 }
 
+// cacheKeys returns the keys for the objects listed by the section's
+// describers.
+func (d *SectionDescriber) cacheKeys(namespace string) []CacheKey {
+	var keys []CacheKey
+	for _, child := range d.describers {
+		switch t := child.(type) {
+		case *Resource:
+			key := t.CacheKey
+			key.Namespace = namespace
+			keys = append(keys, key)
+		case *ListDescriber:
+			key := t.cacheKey
+			key.Namespace = namespace
+			keys = append(keys, key)
+		case *SectionDescriber:
+			keys = append(keys, t.cacheKeys(namespace)...)
+		}
+	}
+
+	return keys
+}
+
 func (d *SectionDescriber) PathFilters(namespace string) []pathFilter {
 	pathFilters := []pathFilter{
 		*newPathFilter(d.path, d),
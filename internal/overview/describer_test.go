@@ -3,7 +3,7 @@ package overview
 import (
 	"context"
 	"github.com/twosson/kubeapt/internal/cluster/fake"
-	"github.com/twosson/kubeapt/internal/content"
+	"github.com/twosson/kubeapt/pkg/content"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	metav1beta1 "k8s.io/apimachinery/pkg/apis/meta/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -207,7 +207,7 @@ func TestSectionDescriber(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 
-			got, err := tc.d.Describe(ctx, "/prefix", namespace, clusterClient, options)
+			got, err := tc.d.Describe(ctx, testPrefix, namespace, clusterClient, options)
 			require.NoError(t, err)
 
 			assert.Equal(t, tc.expected, got)
@@ -215,3 +215,39 @@ func TestSectionDescriber(t *testing.T) {
 	}
 
 }
+
+func Test_printContentTable_allNamespaces(t *testing.T) {
+	tbl := &metav1beta1.Table{
+		ColumnDefinitions: []metav1beta1.TableColumnDefinition{
+			{Name: "Namespace"},
+			{Name: "Name"},
+		},
+		Rows: []metav1beta1.TableRow{
+			{Cells: []interface{}{"app-1", "service1"}},
+			{Cells: []interface{}{"default", "service2"}},
+		},
+	}
+
+	transforms := map[string]lookupFunc{
+		"Name": resourceLink("discovery-and-load-balancing", "services"),
+	}
+
+	contentTable, err := printContentTable("Services", AllNamespaces, testPrefix, "", tbl, transforms)
+	require.NoError(t, err)
+
+	expected := content.NewTable("Services", "")
+	expected.Columns = []content.TableColumn{
+		{Name: "Namespace", Accessor: "Namespace"},
+		{Name: "Name", Accessor: "Name"},
+	}
+	expected.AddRow(content.TableRow{
+		"Namespace": content.NewStringText("app-1"),
+		"Name":      content.NewLinkText("service1", "/content/overview/discovery-and-load-balancing/services/service1?namespace=app-1"),
+	})
+	expected.AddRow(content.TableRow{
+		"Namespace": content.NewStringText("default"),
+		"Name":      content.NewLinkText("service2", "/content/overview/discovery-and-load-balancing/services/service2?namespace=default"),
+	})
+
+	assert.Equal(t, expected, *contentTable)
+}
@@ -4,7 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/twosson/kubeapt/internal/cluster"
-	"github.com/twosson/kubeapt/internal/content"
+	"github.com/twosson/kubeapt/pkg/content"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/kubernetes/pkg/apis/core"
 	"sort"
@@ -34,7 +34,7 @@ func (el *EventList) Content(ctx context.Context, object runtime.Object, c Cache
 	if err != nil {
 		return nil, err
 	}
-	eventObjects, err := c.Events(&unstructured.Unstructured{Object: m})
+	eventObjects, err := c.Events(ctx, &unstructured.Unstructured{Object: m})
 	if err != nil {
 		return nil, err
 	}
@@ -110,6 +110,8 @@ func (d *EventsDescriber) Describe(ctx context.Context, prefix, namespace string
 	}
 
 	contents = append(contents, &t)
+	setTableFilters(contents, listSelectorsFromContext(ctx))
+	applyTableOptions(contents, tableOptionsFromContext(ctx))
 
 	return ContentResponse{
 		Views: []Content{
@@ -5,7 +5,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/twosson/kubeapt/internal/cluster/fake"
-	"github.com/twosson/kubeapt/internal/content"
+	"github.com/twosson/kubeapt/pkg/content"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/clock"
@@ -31,7 +31,7 @@ func TestEventsDescriber(t *testing.T) {
 
 	ctx := context.Background()
 	d := NewEventsDescriber("/events")
-	cResponse, err := d.Describe(ctx, "/prefix", namespace, clusterClient, options)
+	cResponse, err := d.Describe(ctx, testPrefix, namespace, clusterClient, options)
 	require.NoError(t, err)
 
 	table := content.NewTable("Events", "Namespace Events does not contain any events")
@@ -7,10 +7,13 @@ import (
 	"github.com/twosson/kubeapt/internal/cluster"
 	"k8s.io/api/extensions/v1beta1"
 	"k8s.io/kubernetes/pkg/apis/apps"
+	"k8s.io/kubernetes/pkg/apis/autoscaling"
 	"k8s.io/kubernetes/pkg/apis/batch"
 	"k8s.io/kubernetes/pkg/apis/core"
 	"k8s.io/kubernetes/pkg/apis/extensions"
+	"k8s.io/kubernetes/pkg/apis/networking"
 	"k8s.io/kubernetes/pkg/apis/rbac"
+	"k8s.io/kubernetes/pkg/apis/storage"
 	"regexp"
 	"sync"
 )
@@ -82,6 +85,8 @@ var (
 		ObjectType: &extensions.DaemonSet{},
 		Titles:     ResourceTitle{List: "Daemon Sets", Object: "Daemon Set"},
 		Transforms: daemonSetTransforms,
+		Columns:    workloadUsageColumns,
+		Actions:    []string{actionRollback},
 		Sections: []ContentSection{
 			{
 				Title: "Summary",
@@ -98,6 +103,7 @@ var (
 					newWorkloadInspectorView,
 				},
 			},
+			historySection,
 		},
 	})
 
@@ -108,6 +114,8 @@ var (
 		ObjectType: &extensions.Deployment{},
 		Titles:     ResourceTitle{List: "Deployments", Object: "Deployment"},
 		Transforms: deploymentTransforms,
+		Columns:    workloadUsageColumns,
+		Actions:    []string{actionScale, actionRestart, actionPause, actionResume, actionRollback, actionDelete},
 		Sections: []ContentSection{
 			{
 				Title: "Summary",
@@ -124,6 +132,33 @@ var (
 					newWorkloadInspectorView,
 				},
 			},
+			historySection,
+		},
+	})
+
+	workloadsHorizontalPodAutoscalers = NewResource(ResourceOptions{
+		Path:       "/workloads/horizontal-pod-autoscalers",
+		CacheKey:   hpaCacheKey,
+		ListType:   &autoscaling.HorizontalPodAutoscalerList{},
+		ObjectType: &autoscaling.HorizontalPodAutoscaler{},
+		Titles:     ResourceTitle{List: "Horizontal Pod Autoscalers", Object: "Horizontal Pod Autoscaler"},
+		Transforms: horizontalPodAutoscalerTransforms,
+		Sections: []ContentSection{
+			{
+				Title: "Summary",
+				Views: []ViewFactory{
+					NewHorizontalPodAutoscalerSummary,
+					NewHorizontalPodAutoscalerMetrics,
+					NewHorizontalPodAutoscalerConditions,
+					NewEventList,
+				},
+			},
+			{
+				Title: "Resource Viewer",
+				Views: []ViewFactory{
+					newWorkloadInspectorView,
+				},
+			},
 		},
 	})
 
@@ -154,14 +189,17 @@ var (
 		ObjectType: &core.Pod{},
 		Titles:     ResourceTitle{List: "Pods", Object: "Pod"},
 		Transforms: podTransforms,
+		Columns:    podUsageColumns,
 		Sections: []ContentSection{
 			{
 				Title: "Summary",
 				Views: []ViewFactory{
 					NewPodSummary,
+					NewPodUsage,
 					NewPodContainer,
 					NewPodCondition,
 					NewPodVolume,
+					NewPodNetworkPolicies,
 					NewEventList,
 				},
 			},
@@ -181,6 +219,8 @@ var (
 		ObjectType: &extensions.ReplicaSet{},
 		Titles:     ResourceTitle{List: "Replica Sets", Object: "Replica Set"},
 		Transforms: replicaSetTransforms,
+		Columns:    workloadUsageColumns,
+		Actions:    []string{actionScale, actionDelete},
 		Sections: []ContentSection{
 			{
 				Title: "Summary",
@@ -207,6 +247,8 @@ var (
 		ObjectType: &core.ReplicationController{},
 		Titles:     ResourceTitle{List: "Replication Controllers", Object: "Replication Controller"},
 		Transforms: replicationControllerTransforms,
+		Columns:    workloadUsageColumns,
+		Actions:    []string{actionScale, actionDelete},
 		Sections: []ContentSection{
 			{
 				Title: "Summary",
@@ -232,6 +274,8 @@ var (
 		ObjectType: &apps.StatefulSet{},
 		Titles:     ResourceTitle{List: "Stateful Sets", Object: "Stateful Set"},
 		Transforms: statefulSetTransforms,
+		Columns:    workloadUsageColumns,
+		Actions:    []string{actionScale, actionRestart, actionRollback, actionDelete},
 		Sections: []ContentSection{
 			{
 				Title: "Summary",
@@ -248,6 +292,7 @@ var (
 					newWorkloadInspectorView,
 				},
 			},
+			historySection,
 		},
 	})
 
@@ -257,6 +302,7 @@ var (
 		workloadsCronJobs,
 		workloadsDaemonSets,
 		workloadsDeployments,
+		workloadsHorizontalPodAutoscalers,
 		workloadsJobs,
 		workloadsPods,
 		workloadsReplicaSets,
@@ -289,6 +335,25 @@ var (
 		},
 	})
 
+	dlbNetworkPolicies = NewResource(ResourceOptions{
+		Path:       "/discovery-and-load-balancing/network-policies",
+		CacheKey:   CacheKey{APIVersion: "networking.k8s.io/v1", Kind: "NetworkPolicy"},
+		ListType:   &networking.NetworkPolicyList{},
+		ObjectType: &networking.NetworkPolicy{},
+		Titles:     ResourceTitle{List: "Network Policies", Object: "Network Policy"},
+		Transforms: networkPolicyTransforms,
+		Sections: []ContentSection{
+			{
+				Title: "Summary",
+				Views: []ViewFactory{
+					NewNetworkPolicySummary,
+					NewNetworkPolicyRules,
+					NewEventList,
+				},
+			},
+		},
+	})
+
 	dlbServices = NewResource(ResourceOptions{
 		Path:       "/discovery-and-load-balancing/services",
 		CacheKey:   CacheKey{APIVersion: "v1", Kind: "Service"},
@@ -319,6 +384,7 @@ var (
 		"/discovery-and-load-balancing",
 		"Discovery and Load Balancing",
 		dlbIngresses,
+		dlbNetworkPolicies,
 		dlbServices,
 	)
 
@@ -401,8 +467,8 @@ var (
 		csServiceAccounts,
 	)
 
-	customResourcesDescriber = NewSectionDescriber(
-		"/custom-resources",
+	customResourcesDescriber = NewCustomResourcesDescriber(
+		customResourcesPath,
 		"Custom Resources",
 	)
 
@@ -449,6 +515,150 @@ var (
 		rbacRoleBindings,
 	)
 
+	clusterNodes = NewResource(ResourceOptions{
+		Path:          "/cluster/nodes",
+		CacheKey:      CacheKey{APIVersion: "v1", Kind: "Node"},
+		ListType:      &core.NodeList{},
+		ObjectType:    &core.Node{},
+		Titles:        ResourceTitle{List: "Nodes", Object: "Node"},
+		Transforms:    nodeTransforms,
+		Columns:       nodeColumns,
+		ClusterScoped: true,
+		Sections: []ContentSection{
+			{
+				Title: "Summary",
+				Views: []ViewFactory{
+					NewNodeSummary,
+					NewNodeSystemInfo,
+					NewNodeCondition,
+					NewNodeResources,
+					NewNodeUsage,
+					NewEventList,
+				},
+			},
+			{
+				Title: "Pods",
+				Views: []ViewFactory{
+					NewNodePods,
+				},
+			},
+		},
+	})
+
+	clusterNamespaces = NewResource(ResourceOptions{
+		Path:          "/cluster/namespaces",
+		CacheKey:      CacheKey{APIVersion: "v1", Kind: "Namespace"},
+		ListType:      &core.NamespaceList{},
+		ObjectType:    &core.Namespace{},
+		Titles:        ResourceTitle{List: "Namespaces", Object: "Namespace"},
+		Transforms:    namespaceTransforms,
+		ClusterScoped: true,
+		Sections: []ContentSection{
+			{
+				Title: "Summary",
+				Views: []ViewFactory{
+					NewNamespaceSummary,
+					NewEventList,
+				},
+			},
+		},
+	})
+
+	clusterPersistentVolumes = NewResource(ResourceOptions{
+		Path:          "/cluster/persistent-volumes",
+		CacheKey:      CacheKey{APIVersion: "v1", Kind: "PersistentVolume"},
+		ListType:      &core.PersistentVolumeList{},
+		ObjectType:    &core.PersistentVolume{},
+		Titles:        ResourceTitle{List: "Persistent Volumes", Object: "Persistent Volume"},
+		Transforms:    persistentVolumeTransforms,
+		ClusterScoped: true,
+		Sections: []ContentSection{
+			{
+				Title: "Summary",
+				Views: []ViewFactory{
+					NewPersistentVolumeSummary,
+					NewEventList,
+				},
+			},
+		},
+	})
+
+	clusterStorageClasses = NewResource(ResourceOptions{
+		Path:          "/cluster/storage-classes",
+		CacheKey:      CacheKey{APIVersion: "storage.k8s.io/v1", Kind: "StorageClass"},
+		ListType:      &storage.StorageClassList{},
+		ObjectType:    &storage.StorageClass{},
+		Titles:        ResourceTitle{List: "Storage Classes", Object: "Storage Class"},
+		Transforms:    storageClassTransforms,
+		ClusterScoped: true,
+		Sections: []ContentSection{
+			{
+				Title: "Summary",
+				Views: []ViewFactory{
+					NewStorageClassSummary,
+					NewEventList,
+				},
+			},
+		},
+	})
+
+	clusterClusterRoles = NewResource(ResourceOptions{
+		Path:          "/cluster/cluster-roles",
+		CacheKey:      CacheKey{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRole"},
+		ListType:      &rbac.ClusterRoleList{},
+		ObjectType:    &rbac.ClusterRole{},
+		Titles:        ResourceTitle{List: "Cluster Roles", Object: "Cluster Role"},
+		Transforms:    clusterRoleTransforms,
+		ClusterScoped: true,
+		Sections: []ContentSection{
+			{
+				Title: "Summary",
+				Views: []ViewFactory{
+					NewClusterRoleSummary,
+					NewClusterRoleRule,
+					NewEventList,
+				},
+			},
+		},
+	})
+
+	clusterClusterRoleBindings = NewResource(ResourceOptions{
+		Path:          "/cluster/cluster-role-bindings",
+		CacheKey:      CacheKey{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRoleBinding"},
+		ListType:      &rbac.ClusterRoleBindingList{},
+		ObjectType:    &rbac.ClusterRoleBinding{},
+		Titles:        ResourceTitle{List: "Cluster Role Bindings", Object: "Cluster Role Binding"},
+		Transforms:    clusterRoleBindingTransforms,
+		ClusterScoped: true,
+		Sections: []ContentSection{
+			{
+				Title: "Summary",
+				Views: []ViewFactory{
+					NewClusterRoleBindingSummary,
+					NewClusterRoleBindingSubjects,
+					NewEventList,
+				},
+			},
+		},
+	})
+
+	clusterCustomResourceDefinitions = NewCustomResourceDefinitionsDescriber(
+		"/cluster/custom-resource-definitions",
+		"Custom Resource Definitions",
+	)
+
+	clusterDescriber = NewSectionDescriber(
+		"/cluster",
+		"Cluster",
+		clusterNodes,
+		clusterNamespaces,
+		clusterPersistentVolumes,
+		clusterStorageClasses,
+		clusterClusterRoles,
+		clusterClusterRoleBindings,
+		clusterCustomResourceDefinitions,
+	)
+
 	rootDescriber = NewSectionDescriber(
 		"/",
 		"Overview",
@@ -457,6 +667,7 @@ var (
 		configAndStorageDescriber,
 		customResourcesDescriber,
 		rbacDescriber,
+		clusterDescriber,
 	)
 
 	eventsDescriber = NewResource(ResourceOptions{
@@ -479,6 +690,9 @@ type realGenerator struct {
 	cache         Cache
 	pathFilters   []pathFilter
 	clusterClient cluster.ClientInterface
+	// metrics samples usage for views and columns. Usage isn't shown if it
+	// is nil.
+	metrics *metricsSampler
 
 	mu sync.Mutex
 }
@@ -495,6 +709,14 @@ func (g *realGenerator) Generate(ctx context.Context, path, prefix, namespace st
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
+	// Content waits for the cache to sync once per request, rather than once
+	// for each kind it retrieves.
+	ctx = withSyncDeadline(ctx)
+
+	if g.metrics != nil {
+		ctx = withMetrics(ctx, g.metrics)
+	}
+
 	for _, pf := range g.pathFilters {
 		if !pf.Match(path) {
 			continue
@@ -508,6 +730,12 @@ func (g *realGenerator) Generate(ctx context.Context, path, prefix, namespace st
 
 		cResponse, err := pf.describer.Describe(ctx, prefix, namespace, g.clusterClient, options)
 		if err != nil {
+			if isForbidden(err) {
+				return forbiddenContentResponse(err), nil
+			}
+			if isLoading(err) {
+				return loadingContentResponse(err), nil
+			}
 			return emptyContentResponse, err
 		}
 
@@ -6,7 +6,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/twosson/kubeapt/internal/cluster"
 	"github.com/twosson/kubeapt/internal/cluster/fake"
-	"github.com/twosson/kubeapt/internal/content"
+	"github.com/twosson/kubeapt/pkg/content"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"testing"
@@ -86,7 +86,7 @@ func Test_realGenerator_Generate(t *testing.T) {
 			g := newGenerator(cache, pathFilters, clusterClient)
 
 			ctx := context.Background()
-			cResponse, err := g.Generate(ctx, tc.path, "/prefix", "default")
+			cResponse, err := g.Generate(ctx, tc.path, testPrefix, "default")
 			if tc.isErr {
 				require.Error(t, err)
 				return
@@ -137,7 +137,7 @@ func (c *spyCache) isSatisfied() bool {
 	return true
 }
 
-func (c *spyCache) Retrieve(key CacheKey) ([]*unstructured.Unstructured, error) {
+func (c *spyCache) Retrieve(ctx context.Context, key CacheKey) ([]*unstructured.Unstructured, error) {
 	c.used[key] = true
 
 	objs := c.store[key]
@@ -150,7 +150,7 @@ func (c *spyCache) Delete(obj *unstructured.Unstructured) error {
 	return nil
 }
 
-func (c *spyCache) Events(obj *unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+func (c *spyCache) Events(ctx context.Context, obj *unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
 	return []*unstructured.Unstructured{}, nil
 }
 
@@ -1,13 +1,13 @@
 package overview
 
 import (
+	"context"
 	"github.com/gorilla/mux"
 	"github.com/twosson/kubeapt/internal/log"
 	"k8s.io/apimachinery/pkg/util/json"
 	"net/http"
-	"strconv"
+	"path"
 	"strings"
-	"time"
 )
 
 type errorMessage struct {
@@ -40,43 +40,123 @@ type handler struct {
 	mux       *mux.Router
 	generator generator
 	streamFn  streamFn
+
+	namespaces       *namespaceNotifier
+	currentNamespace func() string
+
+	// done is closed when the module stops.
+	done <-chan struct{}
 }
 
 var _ http.Handler = (*handler)(nil)
 
-func newHandler(prefix string, g generator, sfn streamFn, logger log.Logger) *handler {
+type handlerOpt func(h *handler)
+
+// handlerNamespaceOpt configures how a handler follows the module's
+// namespace. Requests without a namespace use the current namespace, and
+// content streams are closed when the namespace changes.
+func handlerNamespaceOpt(namespaces *namespaceNotifier, currentNamespace func() string) handlerOpt {
+	return func(h *handler) {
+		h.namespaces = namespaces
+		h.currentNamespace = currentNamespace
+	}
+}
+
+// handlerDoneOpt closes content streams when done is closed. Streams are
+// closed when the module stops, e.g. because the cluster was switched, so
+// clients reopen them from the new module rather than showing content from
+// the old cluster.
+func handlerDoneOpt(done <-chan struct{}) handlerOpt {
+	return func(h *handler) {
+		h.done = done
+	}
+}
+
+// newHandler creates a handler for content. Streamed content is
+// regenerated when the notifier signals that cached objects changed.
+func newHandler(prefix string, g generator, n *cacheNotifier, sfn streamFn, logger log.Logger, opts ...handlerOpt) *handler {
 	router := mux.NewRouter().StrictSlash(true)
 
+	h := &handler{
+		mux:       router,
+		generator: g,
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
 	router.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 
 		ctx := log.WithLoggerContext(r.Context(), logger)
+		ctx = withFullManifest(ctx, r.URL.Query().Get("manifest") == "full")
+		fromRevision, toRevision := revisionDiffFromQuery(r.URL.Query())
+		ctx = withRevisionDiff(ctx, fromRevision, toRevision)
+
+		selectors, err := listSelectorsFromQuery(r.URL.Query())
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, err.Error(), logger)
+			return
+		}
+		ctx = withListSelectors(ctx, selectors)
+
+		options, err := tableOptionsFromQuery(r.URL.Query())
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, err.Error(), logger)
+			return
+		}
+		ctx = withTableOptions(ctx, options)
+
 		path := strings.TrimPrefix(r.URL.Path, prefix)
 		namespace := r.URL.Query().Get("namespace")
+		if namespace == "" && h.currentNamespace != nil {
+			namespace = h.currentNamespace()
+		}
 		poll := r.URL.Query().Get("poll")
 
 		logger.With("path", path, "namespace", namespace, "poll", poll).Debugf("called")
 
 		if poll != "" {
-			var eventTimeout time.Duration
-			timeout, err := strconv.Atoi(poll)
-			if err != nil {
-				eventTimeout = defaultEventTimeout
-			} else {
-				eventTimeout = time.Duration(timeout) * time.Second
+			if h.done != nil {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithCancel(ctx)
+				defer cancel()
+
+				go func() {
+					select {
+					case <-h.done:
+						cancel()
+					case <-ctx.Done():
+					}
+				}()
 			}
 
+			// Informers used by the stream are kept running until it closes.
+			ctx, refs := withInformerRefs(ctx)
+			defer refs.release()
+
 			cs := contentStreamer{
-				generator:    g,
-				w:            w,
-				path:         path,
-				prefix:       prefix,
-				namespace:    namespace,
-				streamFn:     stream,
-				eventTimeout: eventTimeout,
-				logger:       logger,
+				generator: g,
+				w:         w,
+				path:      path,
+				prefix:    prefix,
+				namespace: namespace,
+				streamFn:  sfn,
+				debounce:  defaultStreamDebounce,
+				refresh:   streamRefresh(poll),
+				logger:    logger,
+			}
+
+			if n != nil {
+				cs.updates = n.Subscribe(ctx, namespaceFilter(namespace))
+			}
+
+			if h.namespaces != nil {
+				cs.namespaces = h.namespaces.Subscribe(ctx)
 			}
 
 			cs.content(ctx)
+			return
 		}
 
 		w.Header().Set("Content-Type", "application/json; charset=utf-8")
@@ -99,10 +179,13 @@ func newHandler(prefix string, g generator, sfn streamFn, logger log.Logger) *ha
 		}
 	})
 
-	return &handler{
-		mux:       router,
-		generator: g,
-	}
+	return h
+}
+
+// handle registers a handler for a path relative to the handler's prefix.
+// Paths can contain route variables.
+func (h *handler) handle(prefix, p string, handler http.Handler) {
+	h.mux.Handle(path.Join(prefix, p), handler)
 }
 
 func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -5,14 +5,15 @@ import (
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"github.com/twosson/kubeapt/internal/content"
 	"github.com/twosson/kubeapt/internal/log"
+	"github.com/twosson/kubeapt/pkg/content"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 )
 
 func Test_handler_routes(t *testing.T) {
@@ -42,6 +43,14 @@ func Test_handler_routes(t *testing.T) {
 			expectedCode: http.StatusInternalServerError,
 			expectedBody: `{"error":{"code":500,"message":"broken"}}`,
 		},
+		{
+			name:         "invalid field selector",
+			path:         "/api/real",
+			values:       url.Values{"fieldSelector": []string{"status.phase"}},
+			generator:    newStubbedGenerator([]content.Content{dynamicContent}, nil),
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"error":{"code":400,"message":"invalid field selector: invalid selector: 'status.phase'; can't understand 'status.phase'"}}`,
+		},
 		{
 			name:         "GET invalid path",
 			path:         "/api/missing",
@@ -53,7 +62,7 @@ func Test_handler_routes(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			h := newHandler("/api", tc.generator, stubStream, log.NopLogger())
+			h := newHandler("/api", tc.generator, nil, stubStream, log.NopLogger())
 
 			ts := httptest.NewServer(h)
 			defer ts.Close()
@@ -127,10 +136,37 @@ func TestHandlerContext(t *testing.T) {
 		return emptyContentResponse, nil
 	})
 
-	h := newHandler("/api", g, stubStream, logger)
+	h := newHandler("/api", g, nil, stubStream, logger)
 	w := httptest.NewRecorder()
 	r, err := http.NewRequest("GET", "/api", nil)
 	require.NoError(t, err)
 
 	h.ServeHTTP(w, r)
 }
+
+func TestHandler_stream_closed_when_done(t *testing.T) {
+	blockingStream := func(ctx context.Context, w http.ResponseWriter, ch chan []byte) {
+		<-ctx.Done()
+	}
+
+	done := make(chan struct{})
+	h := newHandler("/api", newStubbedGenerator(nil, nil), nil, blockingStream, log.NopLogger(),
+		handlerDoneOpt(done))
+
+	r := httptest.NewRequest(http.MethodGet, "/api/real?poll=1", nil)
+	w := httptest.NewRecorder()
+
+	served := make(chan struct{})
+	go func() {
+		h.ServeHTTP(w, r)
+		close(served)
+	}()
+
+	close(done)
+
+	select {
+	case <-served:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the stream to close")
+	}
+}
@@ -0,0 +1,328 @@
+package overview
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/twosson/kubeapt/pkg/content"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/kubernetes/pkg/apis/extensions"
+)
+
+const (
+	// revisionAnnotation is set on a Deployment's ReplicaSets by the
+	// deployment controller.
+	revisionAnnotation = "deployment.kubernetes.io/revision"
+
+	// changeCauseAnnotation records the command which changed an object. It
+	// is copied to the object's revisions.
+	changeCauseAnnotation = "kubernetes.io/change-cause"
+)
+
+// historySection shows the revisions of a workload.
+var historySection = ContentSection{
+	Title: "History",
+	Views: []ViewFactory{
+		NewRevisionHistory,
+	},
+}
+
+type revisionDiffKey struct{}
+
+type revisionDiff struct {
+	from int64
+	to   int64
+}
+
+// withRevisionDiff returns a context which selects the revisions compared
+// by the history view. Zero selects the default revisions.
+func withRevisionDiff(ctx context.Context, from, to int64) context.Context {
+	return context.WithValue(ctx, revisionDiffKey{}, revisionDiff{from: from, to: to})
+}
+
+func revisionDiffFromContext(ctx context.Context) (int64, int64) {
+	rd, _ := ctx.Value(revisionDiffKey{}).(revisionDiff)
+	return rd.from, rd.to
+}
+
+// revisionDiffFromQuery reads the fromRevision and toRevision query
+// parameters. Missing or invalid values select the default revisions.
+func revisionDiffFromQuery(values url.Values) (int64, int64) {
+	from, _ := strconv.ParseInt(values.Get("fromRevision"), 10, 64)
+	to, _ := strconv.ParseInt(values.Get("toRevision"), 10, 64)
+	return from, to
+}
+
+// revision is a revision of a workload's pod template. Deployment revisions
+// are stored in ReplicaSets, and DaemonSet and StatefulSet revisions are
+// stored in ControllerRevisions.
+type revision struct {
+	Number            int64
+	Name              string
+	Kind              string
+	ChangeCause       string
+	CreationTimestamp metav1.Time
+	Template          map[string]interface{}
+}
+
+// listRevisions returns the revisions of a workload ordered by revision
+// number.
+func listRevisions(ctx context.Context, c Cache, kind string, owner metav1.Object) ([]revision, error) {
+	var revisions []revision
+	var err error
+
+	switch kind {
+	case "Deployment":
+		revisions, err = replicaSetRevisions(ctx, c, owner)
+	case "DaemonSet", "StatefulSet":
+		revisions, err = controllerRevisions(ctx, c, owner)
+	default:
+		return nil, errors.Errorf("%s does not have revisions", kind)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Number < revisions[j].Number
+	})
+
+	return revisions, nil
+}
+
+func replicaSetRevisions(ctx context.Context, c Cache, owner metav1.Object) ([]revision, error) {
+	objects, err := ownedObjects(ctx, c, "ReplicaSet", owner)
+	if err != nil {
+		return nil, err
+	}
+
+	var revisions []revision
+	for _, object := range objects {
+		number, err := strconv.ParseInt(object.GetAnnotations()[revisionAnnotation], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		template, _, err := unstructured.NestedMap(object.Object, "spec", "template")
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading template for ReplicaSet %s", object.GetName())
+		}
+
+		// The pod template hash is added by the deployment controller, so
+		// it isn't part of the Deployment's template.
+		unstructured.RemoveNestedField(template, "metadata", "labels", extensions.DefaultDeploymentUniqueLabelKey)
+
+		revisions = append(revisions, newRevision(object, number, template))
+	}
+
+	return revisions, nil
+}
+
+func controllerRevisions(ctx context.Context, c Cache, owner metav1.Object) ([]revision, error) {
+	objects, err := ownedObjects(ctx, c, "ControllerRevision", owner)
+	if err != nil {
+		return nil, err
+	}
+
+	var revisions []revision
+	for _, object := range objects {
+		number, _, err := unstructured.NestedInt64(object.Object, "revision")
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading revision for ControllerRevision %s", object.GetName())
+		}
+
+		// The revision data is a patch which replaces the template.
+		template, _, err := unstructured.NestedMap(object.Object, "data", "spec", "template")
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading template for ControllerRevision %s", object.GetName())
+		}
+		delete(template, "$patch")
+
+		revisions = append(revisions, newRevision(object, number, template))
+	}
+
+	return revisions, nil
+}
+
+func newRevision(object *unstructured.Unstructured, number int64, template map[string]interface{}) revision {
+	return revision{
+		Number:            number,
+		Name:              object.GetName(),
+		Kind:              object.GetKind(),
+		ChangeCause:       object.GetAnnotations()[changeCauseAnnotation],
+		CreationTimestamp: object.GetCreationTimestamp(),
+		Template:          template,
+	}
+}
+
+// ownedObjects returns the objects of a kind in the apps/v1 group which are
+// controlled by owner.
+func ownedObjects(ctx context.Context, c Cache, kind string, owner metav1.Object) ([]*unstructured.Unstructured, error) {
+	key := CacheKey{
+		Namespace:  owner.GetNamespace(),
+		APIVersion: "apps/v1",
+		Kind:       kind,
+	}
+
+	objects, err := c.Retrieve(ctx, key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "retrieving %s", kind)
+	}
+
+	var owned []*unstructured.Unstructured
+	for _, object := range objects {
+		if metav1.IsControlledBy(object, owner) {
+			owned = append(owned, object)
+		}
+	}
+
+	return owned, nil
+}
+
+func findRevision(revisions []revision, number int64) (revision, bool) {
+	for _, r := range revisions {
+		if r.Number == number {
+			return r, true
+		}
+	}
+
+	return revision{}, false
+}
+
+// templateDiff creates a unified diff between the templates of two revisions.
+func templateDiff(from, to revision) (string, error) {
+	a, err := yaml.Marshal(from.Template)
+	if err != nil {
+		return "", errors.Wrapf(err, "marshaling template for revision %d", from.Number)
+	}
+
+	b, err := yaml.Marshal(to.Template)
+	if err != nil {
+		return "", errors.Wrapf(err, "marshaling template for revision %d", to.Number)
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(a)),
+		B:        difflib.SplitLines(string(b)),
+		FromFile: fmt.Sprintf("revision %d", from.Number),
+		ToFile:   fmt.Sprintf("revision %d", to.Number),
+		Context:  3,
+	})
+}
+
+// RevisionHistory lists the revisions of a Deployment, DaemonSet or
+// StatefulSet, and shows the pod template diff between two revisions. By
+// default, the latest revision is compared with the one before it.
+type RevisionHistory struct {
+	prefix string
+	clock  clock.Clock
+}
+
+var _ View = (*RevisionHistory)(nil)
+
+// NewRevisionHistory creates an instance of RevisionHistory.
+func NewRevisionHistory(prefix, namespace string, c clock.Clock) View {
+	return &RevisionHistory{prefix: prefix, clock: c}
+}
+
+// Content lists the revisions of an object.
+func (rh *RevisionHistory) Content(ctx context.Context, object runtime.Object, c Cache) ([]content.Content, error) {
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		return nil, errors.Wrap(err, "accessing object metadata")
+	}
+
+	typeAccessor, err := meta.TypeAccessor(object)
+	if err != nil {
+		return nil, errors.Wrap(err, "accessing object type")
+	}
+
+	revisions, err := listRevisions(ctx, c, typeAccessor.GetKind(), accessor)
+	if err != nil {
+		return nil, err
+	}
+
+	table := content.NewTable("Revisions", "This object does not have any revisions")
+	table.Columns = tableCols("Revision", "Name", "Change Cause", "Age")
+
+	// Newest revisions are listed first.
+	for i := len(revisions) - 1; i >= 0; i-- {
+		r := revisions[i]
+
+		var name content.Text = content.NewStringText(r.Name)
+		if r.Kind == "ReplicaSet" {
+			name = content.NewLinkText(r.Name, gvkPath(rh.prefix, "extensions/v1beta1", "ReplicaSet", r.Name))
+		}
+
+		changeCause := r.ChangeCause
+		if changeCause == "" {
+			changeCause = "<none>"
+		}
+
+		table.AddRow(content.TableRow{
+			"Revision":     content.NewStringText(strconv.FormatInt(r.Number, 10)),
+			"Name":         name,
+			"Change Cause": content.NewStringText(changeCause),
+			"Age":          content.NewStringText(translateTimestamp(r.CreationTimestamp, rh.clock)),
+		})
+	}
+
+	contents := []content.Content{&table}
+
+	diff, err := rh.diff(ctx, revisions)
+	if err != nil {
+		return nil, err
+	}
+	if diff != nil {
+		contents = append(contents, diff)
+	}
+
+	return contents, nil
+}
+
+// diff compares the revisions selected in the context. It returns nil if
+// the revisions don't exist.
+func (rh *RevisionHistory) diff(ctx context.Context, revisions []revision) (*content.Code, error) {
+	if len(revisions) < 2 {
+		return nil, nil
+	}
+
+	fromNumber, toNumber := revisionDiffFromContext(ctx)
+	if fromNumber == 0 {
+		fromNumber = revisions[len(revisions)-2].Number
+	}
+	if toNumber == 0 {
+		toNumber = revisions[len(revisions)-1].Number
+	}
+
+	from, ok := findRevision(revisions, fromNumber)
+	if !ok {
+		return nil, nil
+	}
+
+	to, ok := findRevision(revisions, toNumber)
+	if !ok {
+		return nil, nil
+	}
+
+	data, err := templateDiff(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	title := fmt.Sprintf("Changes from revision %d to revision %d", from.Number, to.Number)
+	code := content.NewCode(title, "diff", data)
+
+	return &code, nil
+}
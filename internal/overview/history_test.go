@@ -0,0 +1,203 @@
+package overview
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twosson/kubeapt/pkg/content"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/kubernetes/pkg/apis/extensions"
+)
+
+func newOwnedUnstructured(kind, name string, owner metav1.Object) *unstructured.Unstructured {
+	u := newUnstructured("apps/v1", kind, owner.GetNamespace(), name)
+	isController := true
+	u.SetOwnerReferences([]metav1.OwnerReference{
+		{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Name:       owner.GetName(),
+			UID:        owner.GetUID(),
+			Controller: &isController,
+		},
+	})
+
+	return u
+}
+
+func newRevisionReplicaSet(name, revision, image, changeCause string, owner metav1.Object) *unstructured.Unstructured {
+	u := newOwnedUnstructured("ReplicaSet", name, owner)
+	annotations := map[string]string{revisionAnnotation: revision}
+	if changeCause != "" {
+		annotations[changeCauseAnnotation] = changeCause
+	}
+	u.SetAnnotations(annotations)
+	u.Object["spec"] = map[string]interface{}{
+		"template": map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"labels": map[string]interface{}{
+					"app": "app",
+					extensions.DefaultDeploymentUniqueLabelKey: name,
+				},
+			},
+			"spec": map[string]interface{}{
+				"containers": []interface{}{
+					map[string]interface{}{"name": "app", "image": image},
+				},
+			},
+		},
+	}
+
+	return u
+}
+
+func newRevisionCache(t *testing.T, owner metav1.Object) Cache {
+	c := NewMemoryCache()
+
+	deployment := newUnstructured("apps/v1", "Deployment", owner.GetNamespace(), owner.GetName())
+	deployment.SetUID(owner.GetUID())
+	require.NoError(t, c.Store(deployment))
+
+	objects := []*unstructured.Unstructured{
+		newRevisionReplicaSet("rs-2", "2", "app:v2", "kubectl set image deployment/deployment app=app:v2", owner),
+		newRevisionReplicaSet("rs-1", "1", "app:v1", "", owner),
+		newRevisionReplicaSet("rs-3", "3", "app:v3", "", owner),
+		newRevisionReplicaSet("other", "1", "other:v1", "", &metav1.ObjectMeta{Namespace: "default", UID: "other"}),
+	}
+	for _, object := range objects {
+		require.NoError(t, c.Store(object))
+	}
+
+	return c
+}
+
+func Test_listRevisions_replicaSets(t *testing.T) {
+	owner := &metav1.ObjectMeta{Namespace: "default", Name: "deployment", UID: "deployment"}
+	c := newRevisionCache(t, owner)
+
+	revisions, err := listRevisions(context.Background(), c, "Deployment", owner)
+	require.NoError(t, err)
+
+	require.Len(t, revisions, 3)
+	for i, name := range []string{"rs-1", "rs-2", "rs-3"} {
+		assert.Equal(t, int64(i+1), revisions[i].Number)
+		assert.Equal(t, name, revisions[i].Name)
+	}
+
+	assert.Equal(t, "kubectl set image deployment/deployment app=app:v2", revisions[1].ChangeCause)
+
+	labels, _, err := unstructured.NestedStringMap(revisions[0].Template, "metadata", "labels")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"app": "app"}, labels)
+}
+
+func Test_listRevisions_controllerRevisions(t *testing.T) {
+	owner := &metav1.ObjectMeta{Namespace: "default", Name: "ds", UID: "ds"}
+
+	c := NewMemoryCache()
+	for i, image := range []string{"app:v1", "app:v2"} {
+		cr := newOwnedUnstructured("ControllerRevision", image, owner)
+		cr.Object["revision"] = int64(i + 1)
+		cr.Object["data"] = map[string]interface{}{
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"$patch": "replace",
+					"spec": map[string]interface{}{
+						"containers": []interface{}{
+							map[string]interface{}{"name": "app", "image": image},
+						},
+					},
+				},
+			},
+		}
+		require.NoError(t, c.Store(cr))
+	}
+
+	revisions, err := listRevisions(context.Background(), c, "DaemonSet", owner)
+	require.NoError(t, err)
+
+	require.Len(t, revisions, 2)
+	assert.Equal(t, int64(2), revisions[1].Number)
+	assert.Equal(t, "ControllerRevision", revisions[1].Kind)
+	assert.NotContains(t, revisions[1].Template, "$patch")
+}
+
+func Test_listRevisions_unsupported(t *testing.T) {
+	_, err := listRevisions(context.Background(), NewMemoryCache(), "Pod", &metav1.ObjectMeta{})
+	require.Error(t, err)
+}
+
+func TestRevisionHistory(t *testing.T) {
+	deployment := &extensions.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "deployment", UID: types.UID("deployment")},
+	}
+	c := newRevisionCache(t, deployment)
+
+	cases := []struct {
+		name          string
+		from          int64
+		to            int64
+		expectedTitle string
+		expectedDiff  string
+	}{
+		{
+			name:          "latest changes",
+			expectedTitle: "Changes from revision 2 to revision 3",
+			expectedDiff:  "-  - image: app:v2\n+  - image: app:v3\n",
+		},
+		{
+			name:          "selected revisions",
+			from:          1,
+			to:            3,
+			expectedTitle: "Changes from revision 1 to revision 3",
+			expectedDiff:  "-  - image: app:v1\n+  - image: app:v3\n",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := withRevisionDiff(context.Background(), tc.from, tc.to)
+
+			view := NewRevisionHistory("/api", "default", clock.NewFakeClock(time.Now()))
+			contents, err := view.Content(ctx, deployment, c)
+			require.NoError(t, err)
+			require.Len(t, contents, 2)
+
+			table, ok := contents[0].(*content.Table)
+			require.True(t, ok)
+			require.Len(t, table.Rows, 3)
+			assert.Equal(t, content.NewStringText("3"), table.Rows[0]["Revision"])
+			assert.Equal(t, content.NewLinkText("rs-3", "/content/overview/workloads/replica-sets/rs-3"), table.Rows[0]["Name"])
+			assert.Equal(t, content.NewStringText("kubectl set image deployment/deployment app=app:v2"), table.Rows[1]["Change Cause"])
+			assert.Equal(t, content.NewStringText("<none>"), table.Rows[2]["Change Cause"])
+
+			code, ok := contents[1].(*content.Code)
+			require.True(t, ok)
+			assert.Equal(t, tc.expectedTitle, code.Title)
+			assert.Equal(t, "diff", code.Language)
+			assert.Contains(t, code.Data, tc.expectedDiff)
+		})
+	}
+}
+
+func TestRevisionHistory_missingRevision(t *testing.T) {
+	deployment := &extensions.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "deployment", UID: types.UID("deployment")},
+	}
+	c := newRevisionCache(t, deployment)
+
+	ctx := withRevisionDiff(context.Background(), 9, 0)
+
+	view := NewRevisionHistory("/api", "default", clock.NewFakeClock(time.Now()))
+	contents, err := view.Content(ctx, deployment, c)
+	require.NoError(t, err)
+	assert.Len(t, contents, 1)
+}
@@ -0,0 +1,308 @@
+package overview
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/twosson/kubeapt/pkg/content"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/kubernetes/pkg/apis/apps"
+	"k8s.io/kubernetes/pkg/apis/autoscaling"
+	"k8s.io/kubernetes/pkg/apis/extensions"
+	"time"
+)
+
+// hpaCacheKey retrieves horizontal pod autoscalers as autoscaling/v2beta1,
+// which includes the metrics of autoscalers created as autoscaling/v1.
+var hpaCacheKey = CacheKey{APIVersion: "autoscaling/v2beta1", Kind: "HorizontalPodAutoscaler"}
+
+type HorizontalPodAutoscalerSummary struct {
+	prefix string
+}
+
+var _ View = (*HorizontalPodAutoscalerSummary)(nil)
+
+func NewHorizontalPodAutoscalerSummary(prefix, namespace string, c clock.Clock) View {
+	return &HorizontalPodAutoscalerSummary{prefix: prefix}
+}
+
+func (hs *HorizontalPodAutoscalerSummary) Content(ctx context.Context, object runtime.Object, c Cache) ([]content.Content, error) {
+	hpa, err := retrieveHorizontalPodAutoscaler(object)
+	if err != nil {
+		return nil, err
+	}
+
+	detail, err := printHorizontalPodAutoscalerSummary(hs.prefix, hpa)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := content.NewSummary("Details", []content.Section{detail})
+	return []content.Content{
+		&summary,
+	}, nil
+}
+
+type HorizontalPodAutoscalerMetrics struct{}
+
+var _ View = (*HorizontalPodAutoscalerMetrics)(nil)
+
+func NewHorizontalPodAutoscalerMetrics(prefix, namespace string, c clock.Clock) View {
+	return &HorizontalPodAutoscalerMetrics{}
+}
+
+func (hm *HorizontalPodAutoscalerMetrics) Content(ctx context.Context, object runtime.Object, c Cache) ([]content.Content, error) {
+	hpa, err := retrieveHorizontalPodAutoscaler(object)
+	if err != nil {
+		return nil, err
+	}
+
+	table := content.NewTable("Metrics", "This Horizontal Pod Autoscaler does not have any metrics")
+	table.Columns = tableCols("Type", "Name", "Target", "Current")
+
+	for _, metric := range hpa.Spec.Metrics {
+		name, target := describeMetricSpec(metric)
+		current := "<unknown>"
+		if status := findMetricStatus(metric, hpa.Status.CurrentMetrics); status != nil {
+			current = describeMetricStatus(*status)
+		}
+
+		table.AddRow(content.TableRow{
+			"Type":    content.NewStringText(string(metric.Type)),
+			"Name":    content.NewStringText(name),
+			"Target":  content.NewStringText(target),
+			"Current": content.NewStringText(current),
+		})
+	}
+
+	return []content.Content{&table}, nil
+}
+
+type HorizontalPodAutoscalerConditions struct{}
+
+var _ View = (*HorizontalPodAutoscalerConditions)(nil)
+
+func NewHorizontalPodAutoscalerConditions(prefix, namespace string, c clock.Clock) View {
+	return &HorizontalPodAutoscalerConditions{}
+}
+
+func (hc *HorizontalPodAutoscalerConditions) Content(ctx context.Context, object runtime.Object, c Cache) ([]content.Content, error) {
+	hpa, err := retrieveHorizontalPodAutoscaler(object)
+	if err != nil {
+		return nil, err
+	}
+
+	table := content.NewTable("Conditions", "No conditions")
+	table.Columns = tableCols("Type", "Status", "Last transition time", "Reason", "Message")
+
+	for _, condition := range hpa.Status.Conditions {
+		lastTransitionTime := condition.LastTransitionTime.UTC().Format(time.RFC3339)
+
+		table.AddRow(content.TableRow{
+			"Type":                 content.NewStringText(string(condition.Type)),
+			"Status":               content.NewStringText(string(condition.Status)),
+			"Last transition time": content.NewTimeText(lastTransitionTime),
+			"Reason":               content.NewStringText(condition.Reason),
+			"Message":              content.NewStringText(condition.Message),
+		})
+	}
+
+	return []content.Content{&table}, nil
+}
+
+// describeMetricSpec returns the name of a metric and the value targeted for it.
+func describeMetricSpec(metric autoscaling.MetricSpec) (string, string) {
+	switch {
+	case metric.Resource != nil:
+		r := metric.Resource
+		switch {
+		case r.TargetAverageUtilization != nil:
+			return string(r.Name), fmt.Sprintf("%d%% (of request)", *r.TargetAverageUtilization)
+		case r.TargetAverageValue != nil:
+			return string(r.Name), r.TargetAverageValue.String()
+		}
+		return string(r.Name), "<unset>"
+	case metric.Pods != nil:
+		return metric.Pods.MetricName, metric.Pods.TargetAverageValue.String()
+	case metric.Object != nil:
+		o := metric.Object
+		return fmt.Sprintf("%s on %s/%s", o.MetricName, o.Target.Kind, o.Target.Name), o.TargetValue.String()
+	case metric.External != nil:
+		e := metric.External
+		switch {
+		case e.TargetValue != nil:
+			return e.MetricName, e.TargetValue.String()
+		case e.TargetAverageValue != nil:
+			return e.MetricName, fmt.Sprintf("%s (avg)", e.TargetAverageValue.String())
+		}
+		return e.MetricName, "<unset>"
+	default:
+		return "<unknown>", "<unknown>"
+	}
+}
+
+// describeMetricStatus returns the current value of a metric.
+func describeMetricStatus(status autoscaling.MetricStatus) string {
+	switch {
+	case status.Resource != nil:
+		r := status.Resource
+		if r.CurrentAverageUtilization != nil {
+			return fmt.Sprintf("%d%% (%s)", *r.CurrentAverageUtilization, r.CurrentAverageValue.String())
+		}
+		return r.CurrentAverageValue.String()
+	case status.Pods != nil:
+		return status.Pods.CurrentAverageValue.String()
+	case status.Object != nil:
+		return status.Object.CurrentValue.String()
+	case status.External != nil:
+		e := status.External
+		if e.CurrentAverageValue != nil {
+			return fmt.Sprintf("%s (avg)", e.CurrentAverageValue.String())
+		}
+		return e.CurrentValue.String()
+	default:
+		return "<unknown>"
+	}
+}
+
+// findMetricStatus returns the status reported for a metric, if any.
+func findMetricStatus(metric autoscaling.MetricSpec, statuses []autoscaling.MetricStatus) *autoscaling.MetricStatus {
+	for i := range statuses {
+		status := &statuses[i]
+		if status.Type != metric.Type {
+			continue
+		}
+
+		switch {
+		case metric.Resource != nil && status.Resource != nil:
+			if metric.Resource.Name == status.Resource.Name {
+				return status
+			}
+		case metric.Pods != nil && status.Pods != nil:
+			if metric.Pods.MetricName == status.Pods.MetricName {
+				return status
+			}
+		case metric.Object != nil && status.Object != nil:
+			if metric.Object.MetricName == status.Object.MetricName &&
+				metric.Object.Target == status.Object.Target {
+				return status
+			}
+		case metric.External != nil && status.External != nil:
+			if metric.External.MetricName == status.External.MetricName {
+				return status
+			}
+		}
+	}
+
+	return nil
+}
+
+func retrieveHorizontalPodAutoscaler(object runtime.Object) (*autoscaling.HorizontalPodAutoscaler, error) {
+	hpa, ok := object.(*autoscaling.HorizontalPodAutoscaler)
+	if !ok {
+		return nil, errors.Errorf("expected object to be a HorizontalPodAutoscaler, it was %T", object)
+	}
+
+	return hpa, nil
+}
+
+// findHorizontalPodAutoscalers returns the horizontal pod autoscalers in a
+// namespace which scale an object.
+func findHorizontalPodAutoscalers(ctx context.Context, c Cache, namespace, kind, name string) ([]*autoscaling.HorizontalPodAutoscaler, error) {
+	if c == nil {
+		return nil, errors.New("nil cache")
+	}
+
+	key := hpaCacheKey
+	key.Namespace = namespace
+
+	ul, err := c.Retrieve(ctx, key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "retrieving horizontal pod autoscalers: %v", key)
+	}
+
+	var hpas []*autoscaling.HorizontalPodAutoscaler
+	for _, u := range ul {
+		hpa := &autoscaling.HorizontalPodAutoscaler{}
+		err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, hpa)
+		if err != nil {
+			return nil, errors.Wrap(err, "converting unstructured horizontal pod autoscaler")
+		}
+		if err := copyObjectMeta(hpa, u); err != nil {
+			return nil, errors.Wrap(err, "copying object metadata")
+		}
+
+		// Don't compare APIVersion - there may be several aliases
+		ref := hpa.Spec.ScaleTargetRef
+		if ref.Kind != kind || ref.Name != name {
+			continue
+		}
+
+		hpas = append(hpas, hpa)
+	}
+
+	return hpas, nil
+}
+
+// findManagingHorizontalPodAutoscalers returns the horizontal pod
+// autoscalers which scale an object. Autoscalers are extra detail for
+// workloads, so if the user can't list them, or they are still loading, none
+// are returned rather than hiding the workload's content.
+func findManagingHorizontalPodAutoscalers(ctx context.Context, c Cache, namespace, kind, name string) ([]*autoscaling.HorizontalPodAutoscaler, error) {
+	hpas, err := findHorizontalPodAutoscalers(ctx, c, namespace, kind, name)
+	if err != nil {
+		if isForbidden(err) || isLoading(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return hpas, nil
+}
+
+// findScaleTarget returns the deployment or stateful set a horizontal pod
+// autoscaler scales. Other targets aren't shown, so nil is returned for them.
+func findScaleTarget(ctx context.Context, hpa *autoscaling.HorizontalPodAutoscaler, c Cache) (runtime.Object, error) {
+	if hpa == nil {
+		return nil, errors.New("nil horizontal pod autoscaler")
+	}
+	if c == nil {
+		return nil, errors.New("nil cache")
+	}
+
+	ref := hpa.Spec.ScaleTargetRef
+
+	var object runtime.Object
+	switch ref.Kind {
+	case "Deployment":
+		object = &extensions.Deployment{}
+	case "StatefulSet":
+		object = &apps.StatefulSet{}
+	default:
+		return nil, nil
+	}
+
+	key := CacheKey{
+		Namespace:  hpa.Namespace,
+		APIVersion: "apps/v1",
+		Kind:       ref.Kind,
+		Name:       ref.Name,
+	}
+
+	ul, err := c.Retrieve(ctx, key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "retrieving scale target: %v", key)
+	}
+	for _, u := range ul {
+		err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, object)
+		if err != nil {
+			return nil, errors.Wrapf(err, "converting unstructured %s", ref.Kind)
+		}
+		if err := copyObjectMeta(object, u); err != nil {
+			return nil, errors.Wrap(err, "copying object metadata")
+		}
+		return object, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,273 @@
+package overview
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twosson/kubeapt/pkg/content"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta1 "k8s.io/api/autoscaling/v2beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/kubernetes/pkg/apis/autoscaling"
+	"k8s.io/kubernetes/pkg/apis/core"
+	"testing"
+	"time"
+)
+
+func storeObject(t *testing.T, c Cache, object runtime.Object) {
+	m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	require.NoError(t, err)
+
+	require.NoError(t, c.Store(&unstructured.Unstructured{Object: m}))
+}
+
+func newTestHPA(name, kind, target string) *autoscalingv2beta1.HorizontalPodAutoscaler {
+	return &autoscalingv2beta1.HorizontalPodAutoscaler{
+		TypeMeta: metav1.TypeMeta{APIVersion: "autoscaling/v2beta1", Kind: "HorizontalPodAutoscaler"},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			UID:       types.UID(name),
+		},
+		Spec: autoscalingv2beta1.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2beta1.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       kind,
+				Name:       target,
+			},
+			MaxReplicas: 10,
+		},
+	}
+}
+
+func TestHorizontalPodAutoscalerSummary_InvalidObject(t *testing.T) {
+	assertViewInvalidObject(t, NewHorizontalPodAutoscalerSummary(testPrefix, "ns", clock.NewFakeClock(time.Now())))
+}
+
+func TestHorizontalPodAutoscalerMetrics_InvalidObject(t *testing.T) {
+	assertViewInvalidObject(t, NewHorizontalPodAutoscalerMetrics(testPrefix, "ns", clock.NewFakeClock(time.Now())))
+}
+
+func TestHorizontalPodAutoscalerConditions_InvalidObject(t *testing.T) {
+	assertViewInvalidObject(t, NewHorizontalPodAutoscalerConditions(testPrefix, "ns", clock.NewFakeClock(time.Now())))
+}
+
+func TestHorizontalPodAutoscalerSummary(t *testing.T) {
+	v := NewHorizontalPodAutoscalerSummary(testPrefix, "ns", clock.NewFakeClock(time.Now()))
+
+	minReplicas := int32(2)
+	hpa := &autoscaling.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "web",
+			CreationTimestamp: metav1.Time{
+				Time: time.Unix(1539603521, 0),
+			},
+		},
+		Spec: autoscaling.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscaling.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       "web",
+			},
+			MinReplicas: &minReplicas,
+			MaxReplicas: 10,
+		},
+		Status: autoscaling.HorizontalPodAutoscalerStatus{
+			CurrentReplicas: 3,
+			DesiredReplicas: 4,
+		},
+	}
+
+	got, err := v.Content(context.Background(), hpa, NewMemoryCache())
+	require.NoError(t, err)
+
+	details := content.NewSummary("Details", []content.Section{
+		{
+			Items: []content.Item{
+				content.TextItem("Name", "web"),
+				content.TextItem("Namespace", "default"),
+				content.LabelsItem("Labels", map[string]string{}),
+				content.LabelsItem("Annotations", map[string]string{}),
+				content.TimeItem("Creation Time", "2018-10-15T11:38:41Z"),
+				content.LinkItem("Scale Target", "Deployment/web", "/content/overview/workloads/deployments/web"),
+				content.TextItem("Min Replicas", "2"),
+				content.TextItem("Max Replicas", "10"),
+				content.TextItem("Replicas", "3 current / 4 desired"),
+				content.TimeItem("Last Scale Time", "-"),
+			},
+		},
+	})
+
+	assert.Equal(t, []content.Content{&details}, got)
+}
+
+func TestHorizontalPodAutoscalerMetrics(t *testing.T) {
+	v := NewHorizontalPodAutoscalerMetrics(testPrefix, "ns", clock.NewFakeClock(time.Now()))
+
+	targetUtilization, currentUtilization := int32(50), int32(20)
+	hpa := &autoscaling.HorizontalPodAutoscaler{
+		Spec: autoscaling.HorizontalPodAutoscalerSpec{
+			Metrics: []autoscaling.MetricSpec{
+				{
+					Type: autoscaling.ResourceMetricSourceType,
+					Resource: &autoscaling.ResourceMetricSource{
+						Name:                     core.ResourceCPU,
+						TargetAverageUtilization: &targetUtilization,
+					},
+				},
+				{
+					Type: autoscaling.PodsMetricSourceType,
+					Pods: &autoscaling.PodsMetricSource{
+						MetricName:         "requests_per_second",
+						TargetAverageValue: resource.MustParse("1k"),
+					},
+				},
+			},
+		},
+		Status: autoscaling.HorizontalPodAutoscalerStatus{
+			CurrentMetrics: []autoscaling.MetricStatus{
+				{
+					Type: autoscaling.ResourceMetricSourceType,
+					Resource: &autoscaling.ResourceMetricStatus{
+						Name:                      core.ResourceCPU,
+						CurrentAverageUtilization: &currentUtilization,
+						CurrentAverageValue:       resource.MustParse("100m"),
+					},
+				},
+			},
+		},
+	}
+
+	got, err := v.Content(context.Background(), hpa, NewMemoryCache())
+	require.NoError(t, err)
+
+	table := content.NewTable("Metrics", "This Horizontal Pod Autoscaler does not have any metrics")
+	table.Columns = tableCols("Type", "Name", "Target", "Current")
+	table.AddRow(content.TableRow{
+		"Type":    content.NewStringText("Resource"),
+		"Name":    content.NewStringText("cpu"),
+		"Target":  content.NewStringText("50% (of request)"),
+		"Current": content.NewStringText("20% (100m)"),
+	})
+	table.AddRow(content.TableRow{
+		"Type":    content.NewStringText("Pods"),
+		"Name":    content.NewStringText("requests_per_second"),
+		"Target":  content.NewStringText("1k"),
+		"Current": content.NewStringText("<unknown>"),
+	})
+
+	assert.Equal(t, []content.Content{&table}, got)
+}
+
+func Test_findHorizontalPodAutoscalers(t *testing.T) {
+	cache := NewMemoryCache()
+	storeObject(t, cache, newTestHPA("web", "Deployment", "web"))
+	storeObject(t, cache, newTestHPA("db", "StatefulSet", "web"))
+
+	hpas, err := findHorizontalPodAutoscalers(context.Background(), cache, "default", "Deployment", "web")
+	require.NoError(t, err)
+	require.Len(t, hpas, 1)
+	assert.Equal(t, "web", hpas[0].Name)
+	assert.Equal(t, int32(10), hpas[0].Spec.MaxReplicas)
+
+	hpas, err = findHorizontalPodAutoscalers(context.Background(), cache, "default", "Deployment", "api")
+	require.NoError(t, err)
+	assert.Empty(t, hpas)
+}
+
+func Test_findManagingHorizontalPodAutoscalers(t *testing.T) {
+	key := hpaCacheKey
+	key.Namespace = "default"
+
+	cases := []struct {
+		name  string
+		err   error
+		isErr bool
+	}{
+		{
+			name: "forbidden",
+			err:  &forbiddenError{resource: "horizontalpodautoscalers", namespace: "default"},
+		},
+		{
+			name: "loading",
+			err:  &loadingError{resource: "horizontalpodautoscalers", namespace: "default"},
+		},
+		{
+			name:  "other errors",
+			err:   errors.New("failed"),
+			isErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cache := newSpyCache()
+			cache.spyRetrieve(key, nil, tc.err)
+
+			hpas, err := findManagingHorizontalPodAutoscalers(context.Background(), cache, "default", "Deployment", "web")
+			if tc.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Empty(t, hpas)
+		})
+	}
+}
+
+func Test_statusForHorizontalPodAutoscaler(t *testing.T) {
+	hpa := &autoscaling.HorizontalPodAutoscaler{
+		Status: autoscaling.HorizontalPodAutoscalerStatus{
+			Conditions: []autoscaling.HorizontalPodAutoscalerCondition{
+				{Type: autoscaling.AbleToScale, Status: core.ConditionTrue},
+				{Type: autoscaling.ScalingLimited, Status: core.ConditionFalse},
+			},
+		},
+	}
+	assert.Equal(t, content.NodeStatusOK, statusForHorizontalPodAutoscaler(hpa))
+
+	hpa.Status.Conditions[0].Status = core.ConditionFalse
+	assert.Equal(t, content.NodeStatusWarning, statusForHorizontalPodAutoscaler(hpa))
+}
+
+func Test_workloadInspectorView_horizontalPodAutoscaler(t *testing.T) {
+	cache := NewMemoryCache()
+
+	deployment := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "web",
+			UID:       types.UID("deployment"),
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+		},
+	}
+	storeObject(t, cache, deployment)
+	storeObject(t, cache, newTestHPA("web", "Deployment", "web"))
+
+	hpas, err := findHorizontalPodAutoscalers(context.Background(), cache, "default", "Deployment", "web")
+	require.NoError(t, err)
+	require.Len(t, hpas, 1)
+
+	v := newWorkloadInspectorView(testPrefix, "ns", clock.NewFakeClock(time.Now()))
+	got, err := v.Content(context.Background(), hpas[0], cache)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+
+	dag, ok := got[0].(*content.DAG)
+	require.True(t, ok)
+
+	require.Contains(t, dag.Nodes, "web")
+	assert.Equal(t, "HorizontalPodAutoscaler", dag.Nodes["web"].Kind)
+	require.Contains(t, dag.Nodes, "deployment")
+	assert.Equal(t, []content.Edge{{Type: content.EdgeTypeExplicit, Node: "deployment"}}, dag.Edges["web"])
+}
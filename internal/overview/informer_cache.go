@@ -2,6 +2,7 @@ package overview
 
 import (
 	"context"
+	"github.com/twosson/kubeapt/internal/cluster"
 	"github.com/twosson/kubeapt/internal/log"
 	"github.com/twosson/kubeapt/third_party/dynamic/dynamicinformer"
 	"k8s.io/client-go/informers"
@@ -12,7 +13,9 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -20,6 +23,20 @@ import (
 	"k8s.io/client-go/tools/cache"
 )
 
+const (
+	// defaultSyncTimeout is how long a retrieval waits for a new informer
+	// to sync before reporting that objects are still loading.
+	defaultSyncTimeout = 5 * time.Second
+
+	// defaultInformerIdleTTL is how long an informer which isn't used is
+	// kept running.
+	defaultInformerIdleTTL = 10 * time.Minute
+
+	// defaultMaxInformers is the number of informers which can run before
+	// the least recently used informer is stopped.
+	defaultMaxInformers = 100
+)
+
 // InformerCacheOpt is an option for configuring memory cache.
 type InformerCacheOpt func(*InformerCache)
 
@@ -40,6 +57,49 @@ func InformerCacheLoggerOpt(logger log.Logger) InformerCacheOpt {
 	}
 }
 
+// InformerCacheAccessOpt sets a client which checks whether objects can be
+// listed before an informer is created for them.
+func InformerCacheAccessOpt(access cluster.AccessInterface) InformerCacheOpt {
+	return func(c *InformerCache) {
+		c.access = access
+	}
+}
+
+// InformerCacheSyncTimeoutOpt sets how long a retrieval waits for a new
+// informer to sync. The informer keeps syncing after the timeout.
+func InformerCacheSyncTimeoutOpt(timeout time.Duration) InformerCacheOpt {
+	return func(c *InformerCache) {
+		c.syncTimeout = timeout
+	}
+}
+
+// InformerCacheNamespacesOpt sets the namespaces objects in every namespace
+// are retrieved from when neither the objects nor namespaces can be listed
+// across the cluster, e.g. the current namespace.
+func InformerCacheNamespacesOpt(namespaces func() []string) InformerCacheOpt {
+	return func(c *InformerCache) {
+		c.fallbackNamespaces = namespaces
+	}
+}
+
+// InformerCacheIdleTTLOpt sets how long an informer which isn't used is kept
+// running. Informers used by open content streams are kept regardless. A
+// TTL of zero keeps informers running until the cache is stopped.
+func InformerCacheIdleTTLOpt(ttl time.Duration) InformerCacheOpt {
+	return func(c *InformerCache) {
+		c.idleTTL = ttl
+	}
+}
+
+// InformerCacheMaxInformersOpt sets the number of informers which can run.
+// The least recently used informer which isn't used by a content stream is
+// stopped to make room for a new one. Zero means there is no limit.
+func InformerCacheMaxInformersOpt(max int) InformerCacheOpt {
+	return func(c *InformerCache) {
+		c.maxInformers = max
+	}
+}
+
 type informerKey struct {
 	namespace string
 	gvk       schema.GroupVersionKind
@@ -49,8 +109,19 @@ type informerKey struct {
 type InformerCache struct {
 	client     dynamic.Interface
 	restMapper meta.RESTMapper
-	informers  map[informerKey]informers.GenericInformer
-	logger     log.Logger
+	informers  map[informerKey]*cachedInformer
+	// syncing holds informers which have been started but haven't synced.
+	syncing      map[informerKey]*cachedInformer
+	forbidden    map[informerKey]bool
+	access       cluster.AccessInterface
+	logger       log.Logger
+	syncTimeout  time.Duration
+	idleTTL      time.Duration
+	maxInformers int
+	now          func() time.Time
+	// fallbackNamespaces returns the namespaces to list by when the
+	// cluster's namespaces can't be listed.
+	fallbackNamespaces func() []string
 
 	mu             sync.RWMutex
 	internalNotify chan CacheNotification
@@ -63,10 +134,16 @@ var _ Cache = (*InformerCache)(nil)
 // NewInformerCache creates a new InformerCache.
 func NewInformerCache(stopCh <-chan struct{}, client dynamic.Interface, restMapper meta.RESTMapper, opts ...InformerCacheOpt) *InformerCache {
 	c := &InformerCache{
-		client:     client,
-		restMapper: restMapper,
-		stopCh:     stopCh,
-		informers:  make(map[informerKey]informers.GenericInformer),
+		client:       client,
+		restMapper:   restMapper,
+		stopCh:       stopCh,
+		informers:    make(map[informerKey]*cachedInformer),
+		syncing:      make(map[informerKey]*cachedInformer),
+		forbidden:    make(map[informerKey]bool),
+		syncTimeout:  defaultSyncTimeout,
+		idleTTL:      defaultInformerIdleTTL,
+		maxInformers: defaultMaxInformers,
+		now:          time.Now,
 	}
 
 	for _, opt := range opts {
@@ -80,6 +157,9 @@ func NewInformerCache(stopCh <-chan struct{}, client dynamic.Interface, restMapp
 		c.internalNotify = make(chan CacheNotification)
 		go c.runNotifyHandler()
 	}
+	if c.stopCh != nil && c.idleTTL > 0 {
+		go c.runEvictor()
+	}
 	return c
 }
 
@@ -115,64 +195,241 @@ func (c *InformerCache) runNotifyHandler() {
 	}
 }
 
-func (c *InformerCache) informerForKey(ck CacheKey) (informers.GenericInformer, error) {
-	var namespace = ck.Namespace
-	if namespace == "" {
-		namespace = "default"
+// scopedNamespace returns the namespace used to look up objects described by
+// a cache key. Cluster scoped objects do not have a namespace, and namespaced
+// objects are looked up in the default namespace if none is specified.
+func scopedNamespace(restMapping *meta.RESTMapping, namespace string) string {
+	switch {
+	case restMapping.Scope.Name() == meta.RESTScopeNameRoot:
+		return ""
+	case namespace == "":
+		return "default"
+	default:
+		return namespace
 	}
+}
 
-	gvk := schema.FromAPIVersionAndKind(ck.APIVersion, ck.Kind)
+// informer returns an informer for objects of a kind in a namespace, creating
+// it if needed. An existing cluster wide informer is used for namespaced
+// lookups, since it already watches the namespace. New informers are waited
+// on until they sync, the context is done, or the sync timeout passes. A
+// loadingError is returned if the informer hasn't synced; it keeps syncing
+// in the background and a notification is sent once it has. The informer is
+// referenced by the context's informerRefs, if it has any.
+func (c *InformerCache) informer(ctx context.Context, gvk schema.GroupVersionKind, gvr schema.GroupVersionResource, namespace string) (informers.GenericInformer, error) {
 	key := informerKey{
 		namespace: namespace,
 		gvk:       gvk,
 	}
+	clusterKey := informerKey{
+		gvk: gvk,
+	}
 
 	{
 		// Fastpath
 		c.mu.RLock()
-		informer, ok := c.informers[key]
+		ci, ok := c.informers[key]
+		if !ok {
+			ci, ok = c.informers[clusterKey]
+		}
 		c.mu.RUnlock()
 		if ok {
-			return informer, nil
+			c.use(ctx, ci)
+			if startingInformers(ctx) {
+				return nil, errInformerStarted
+			}
+			return ci.informer, nil
 		}
 	}
 
 	c.mu.Lock()
-	defer c.mu.Unlock()
 
-	if informer, ok := c.informers[key]; ok {
-		return informer, nil
+	ci, ok := c.informers[key]
+	if !ok {
+		ci, ok = c.informers[clusterKey]
+	}
+	if ok {
+		c.mu.Unlock()
+		c.use(ctx, ci)
+		if startingInformers(ctx) {
+			return nil, errInformerStarted
+		}
+		return ci.informer, nil
 	}
 
-	// Create a new informer here
-	restMapping, err := c.restMapper.RESTMapping(gvk.GroupKind())
-	if err != nil {
-		return nil, errors.Wrapf(err, "mapping %v", gvk.String())
+	ci, ok = c.syncing[key]
+	if !ok {
+		if c.maxInformers > 0 && len(c.informers)+len(c.syncing) >= c.maxInformers {
+			if evicted, ok := c.evictLeastRecentlyUsed(); ok {
+				defer c.notifyEvicted(evicted)
+			}
+		}
+
+		// Create a new informer here
+		gi := dynamicinformer.NewFilteredDynamicInformer(c.client, gvr, namespace, 180*time.Second, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, nil)
+		ci = newCachedInformer(gi, c.stopCh)
+
+		// Install handlers, start fetching resources
+		informer := gi.Informer()
+		c.installHandler(informer)
+		go informer.Run(ci.done()) // (as in dynamicSharedInformerFactory.Start())
+
+		c.syncing[key] = ci
+		go c.waitForSync(key, ci)
 	}
-	gvr := restMapping.Resource
-	gi := dynamicinformer.NewFilteredDynamicInformer(c.client, gvr, namespace, 180*time.Second, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, nil)
 
-	// Install handlers, start fetching resources
-	informer := gi.Informer()
-	c.installHandler(informer)
-	go informer.Run(c.stopCh) // (as in dynamicSharedInformerFactory.Start())
+	c.mu.Unlock()
+
+	c.use(ctx, ci)
+
+	if startingInformers(ctx) {
+		return nil, errInformerStarted
+	}
 
 	// Until upstream issue in the wait package is resolved, we *must* ensure that the
 	// stopCh passed to WaitForCacheSync is closed to avoid leaking goroutines spawned within.
 	// We create a new channel for this purpose, as we do not want to cancel our factories and watches.
 	// See https://github.com/kubernetes/kubernetes/pull/71326
-	ctx, cancel := channelContext(c.stopCh)
+	ctx, cancel := c.syncContext(ctx)
 	defer cancel()
 
-	// Block until cache is synced or context is closed via c.stopCh.
-	// Note the context must be closed even after uninterrupted return
-	// to ensure cleanup of resources.
-	if !cache.WaitForCacheSync(ctx.Done(), informer.HasSynced) {
-		return nil, errors.New("shutdown requested")
+	go func() {
+		select {
+		case <-ci.done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	if !cache.WaitForCacheSync(ctx.Done(), ci.informer.Informer().HasSynced) {
+		if ci.stopped() {
+			return nil, errors.New("shutdown requested")
+		}
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		// The informer may have synced after waiting stopped.
+		if _, ok := c.syncing[key]; !ok {
+			return ci.informer, nil
+		}
+		ci.loading = true
+
+		return nil, &loadingError{resource: gvr.Resource, namespace: namespace}
 	}
-	c.informers[key] = gi
 
-	return gi, nil
+	return ci.informer, nil
+}
+
+// syncContext returns a context which is done when waiting for an informer
+// to sync should stop. Retrievals with a shared sync deadline stop waiting
+// one sync timeout after the first of them started waiting.
+func (c *InformerCache) syncContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	sd, ok := ctx.Value(syncDeadlineKey{}).(*syncDeadline)
+	if !ok {
+		return context.WithTimeout(ctx, c.syncTimeout)
+	}
+
+	sd.once.Do(func() {
+		sd.deadline = time.Now().Add(c.syncTimeout)
+	})
+	return context.WithDeadline(ctx, sd.deadline)
+}
+
+// startInformers starts the informers needed to retrieve objects for keys
+// without waiting for them to sync. Content which retrieves several kinds
+// starts them all first, so they sync together rather than one after
+// another.
+func (c *InformerCache) startInformers(ctx context.Context, keys []CacheKey) {
+	ctx = context.WithValue(ctx, startInformersKey{}, true)
+
+	for _, key := range keys {
+		if _, err := c.Retrieve(ctx, key); err != nil && errors.Cause(err) != errInformerStarted {
+			c.logger.With("key", key).Debugf("starting informer: %v", err)
+		}
+	}
+}
+
+// waitForSync moves an informer from the syncing informers once it has
+// synced. A notification is sent if content was shown as loading, so it is
+// refreshed.
+func (c *InformerCache) waitForSync(key informerKey, ci *cachedInformer) {
+	// Block until cache is synced or the informer is stopped.
+	if !cache.WaitForCacheSync(ci.done(), ci.informer.Informer().HasSynced) {
+		return
+	}
+
+	c.mu.Lock()
+	if c.syncing[key] != ci {
+		// The informer was evicted while it was syncing.
+		c.mu.Unlock()
+		return
+	}
+	delete(c.syncing, key)
+	c.informers[key] = ci
+	loading := ci.loading
+	c.mu.Unlock()
+
+	if !loading {
+		return
+	}
+
+	c.notify(CacheNotification{
+		CacheKey: CacheKey{
+			Namespace:  key.namespace,
+			APIVersion: key.gvk.GroupVersion().String(),
+			Kind:       key.gvk.Kind,
+		},
+		Action: CacheUpdate,
+	})
+}
+
+// canList reports whether objects of a resource can be listed in a
+// namespace. An empty namespace checks for a cluster wide list. Informers
+// for resources which can't be listed never sync, so callers check before
+// creating one.
+func (c *InformerCache) canList(gvk schema.GroupVersionKind, gvr schema.GroupVersionResource, namespace string) (bool, error) {
+	key := informerKey{
+		namespace: namespace,
+		gvk:       gvk,
+	}
+
+	c.mu.RLock()
+	_, hasInformer := c.informers[key]
+	_, hasClusterInformer := c.informers[informerKey{gvk: gvk}]
+	_, isSyncing := c.syncing[key]
+	forbidden := c.forbidden[key]
+	c.mu.RUnlock()
+
+	if hasInformer || hasClusterInformer || isSyncing {
+		return true, nil
+	}
+	if forbidden {
+		return false, nil
+	}
+
+	if c.access != nil {
+		allowed, err := listAllowed(c.access, gvr, namespace)
+		if err == nil {
+			return allowed, nil
+		}
+		c.logger.With("resource", gvr.String(), "namespace", namespace).
+			Debugf("reviewing access failed, listing instead: %v", err)
+	}
+
+	// Denials found by listing are remembered.
+	_, err := c.client.Resource(gvr).Namespace(namespace).List(metav1.ListOptions{Limit: 1})
+	if err != nil {
+		if apierrors.IsForbidden(err) || apierrors.IsUnauthorized(err) {
+			c.mu.Lock()
+			c.forbidden[key] = true
+			c.mu.Unlock()
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "listing %s", gvr.String())
+	}
+
+	return true, nil
 }
 
 // keyForObject returns a CacheKey representing a runtime.Object
@@ -198,23 +455,37 @@ func (c *InformerCache) sendNotification(obj interface{}, action CacheAction) er
 		return nil
 	}
 
+	// Objects deleted while the informer was disconnected are wrapped.
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
 	cacheKey, err := keyForObject(obj)
 	if err != nil {
 		return errors.Wrapf(err, "creating cache key")
 	}
 
-	notification := CacheNotification{
+	u, _ := obj.(*unstructured.Unstructured)
+
+	c.notify(CacheNotification{
 		CacheKey: cacheKey,
 		Action:   action,
+		Object:   u,
+	})
+	return nil
+}
+
+// notify sends a notification on via the runNotifyHandler goroutine.
+func (c *InformerCache) notify(notification CacheNotification) {
+	if c.internalNotify == nil {
+		return
 	}
 
-	// Send notification on via runNotifyHandler goroutine
 	select {
 	case c.internalNotify <- notification:
 	case <-c.stopCh:
 		c.logger.Debugf("notification channel closed")
 	}
-	return nil
 }
 
 // installHandler installs an event handler on the supplied informer
@@ -261,8 +532,11 @@ func channelContext(parentCh <-chan struct{}) (context.Context, context.CancelFu
 }
 
 // Retrieve retrieves an object or list of objects from the cluster via cache.
-// Blocks if cache needs to be synced.
-func (c *InformerCache) Retrieve(key CacheKey) ([]*unstructured.Unstructured, error) {
+// Objects in every namespace are retrieved if the key's namespace is
+// AllNamespaces. Blocks until the cache is synced, the context is done, or
+// the sync timeout passes. A loadingError is returned if the cache hasn't
+// synced.
+func (c *InformerCache) Retrieve(ctx context.Context, key CacheKey) ([]*unstructured.Unstructured, error) {
 	if c.restMapper == nil {
 		return nil, errors.New("missing RESTMapper")
 	}
@@ -270,9 +544,28 @@ func (c *InformerCache) Retrieve(key CacheKey) ([]*unstructured.Unstructured, er
 		return nil, errors.New("kind is required")
 	}
 
-	// NOTE: This blocks when setting up new informers
-	// TODO: Pass context for timeout
-	gi, err := c.informerForKey(key)
+	if key.Namespace == AllNamespaces {
+		return c.retrieveAllNamespaces(ctx, key)
+	}
+
+	gvk := schema.FromAPIVersionAndKind(key.APIVersion, key.Kind)
+
+	restMapping, err := c.restMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, errors.Wrapf(err, "mapping %v", gvk.String())
+	}
+
+	namespace := scopedNamespace(restMapping, key.Namespace)
+
+	ok, err := c.canList(gvk, restMapping.Resource, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, &forbiddenError{resource: restMapping.Resource.Resource, namespace: namespace}
+	}
+
+	gi, err := c.informer(ctx, gvk, restMapping.Resource, namespace)
 	if err != nil {
 		return nil, err
 	}
@@ -280,26 +573,21 @@ func (c *InformerCache) Retrieve(key CacheKey) ([]*unstructured.Unstructured, er
 	// Handle list operation
 	if key.Name == "" {
 		// c.logger.With("key", key, "gvk", gvk, "resource", restMapping.Resource).Debugf("listing all objects")
-		objs, err := gi.Lister().List(labels.Everything())
+		labelSelector, fieldSelector, err := cacheKeySelectors(key)
 		if err != nil {
-			return nil, errors.Wrapf(err, "listing")
-		}
-
-		ret := make([]*unstructured.Unstructured, len(objs))
-		for i, obj := range objs {
-			u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
-			if err != nil {
-				return nil, errors.Wrapf(err, "converting %T to unstructured", obj)
-			}
-			ret[i] = &unstructured.Unstructured{Object: u}
+			return nil, err
 		}
-		return ret, nil
+		return listInformer(gi, namespace, labelSelector, fieldSelector)
 	}
 
 	// Handle get operation
 	// c.logger.With("key", key, "gvk", gvk, "resource", restMapping.Resource).Debugf("getting single object: %v", key.Name)
-	lister := gi.Lister().ByNamespace(key.Namespace)
-	obj, err := lister.Get(key.Name)
+	var obj runtime.Object
+	if namespace == "" {
+		obj, err = gi.Lister().Get(key.Name)
+	} else {
+		obj, err = gi.Lister().ByNamespace(namespace).Get(key.Name)
+	}
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			return nil, nil
@@ -315,6 +603,201 @@ func (c *InformerCache) Retrieve(key CacheKey) ([]*unstructured.Unstructured, er
 	}, nil
 }
 
+// retrieveAllNamespaces retrieves objects in every namespace. A single
+// cluster wide informer is used if objects can be listed across the
+// cluster. Otherwise, objects are retrieved from each namespace which can
+// be listed.
+func (c *InformerCache) retrieveAllNamespaces(ctx context.Context, key CacheKey) ([]*unstructured.Unstructured, error) {
+	gvk := schema.FromAPIVersionAndKind(key.APIVersion, key.Kind)
+
+	restMapping, err := c.restMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, errors.Wrapf(err, "mapping %v", gvk.String())
+	}
+
+	if restMapping.Scope.Name() == meta.RESTScopeNameRoot {
+		key.Namespace = ""
+		return c.Retrieve(ctx, key)
+	}
+
+	labelSelector, fieldSelector, err := cacheKeySelectors(key)
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := c.canList(gvk, restMapping.Resource, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []*unstructured.Unstructured
+
+	if ok {
+		gi, err := c.informer(ctx, gvk, restMapping.Resource, "")
+		if err != nil {
+			return nil, err
+		}
+
+		objects, err = listInformer(gi, "", labelSelector, fieldSelector)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		c.logger.With("gvk", gvk.String()).Debugf("unable to list across the cluster, listing by namespace")
+
+		namespaces, err := c.namespaceNames(ctx)
+		if err != nil {
+			if isForbidden(err) {
+				return nil, &forbiddenError{resource: restMapping.Resource.Resource}
+			}
+			return nil, err
+		}
+
+		listed := 0
+		for _, namespace := range namespaces {
+			ok, err := c.canList(gvk, restMapping.Resource, namespace)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+			listed++
+
+			gi, err := c.informer(ctx, gvk, restMapping.Resource, namespace)
+			if err == errInformerStarted {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			namespaceObjects, err := listInformer(gi, namespace, labelSelector, fieldSelector)
+			if err != nil {
+				return nil, err
+			}
+
+			objects = append(objects, namespaceObjects...)
+		}
+
+		if listed == 0 {
+			return nil, &forbiddenError{resource: restMapping.Resource.Resource}
+		}
+		if startingInformers(ctx) {
+			return nil, errInformerStarted
+		}
+	}
+
+	if key.Name == "" {
+		return objects, nil
+	}
+
+	var named []*unstructured.Unstructured
+	for _, object := range objects {
+		if object.GetName() == key.Name {
+			named = append(named, object)
+		}
+	}
+
+	return named, nil
+}
+
+// namespaceNames returns the names of the namespaces objects are listed
+// from when they can't be listed across the cluster. Users who can't list
+// namespaces either are limited to the fallback namespaces.
+func (c *InformerCache) namespaceNames(ctx context.Context) ([]string, error) {
+	namespaces, err := c.Retrieve(ctx, CacheKey{APIVersion: "v1", Kind: "Namespace"})
+	if err != nil {
+		if isForbidden(err) && c.fallbackNamespaces != nil {
+			c.logger.Debugf("unable to list namespaces, listing by fallback namespaces")
+			return uniqueNamespaces(c.fallbackNamespaces()), nil
+		}
+		if isForbidden(err) {
+			return nil, err
+		}
+		return nil, errors.Wrap(err, "retrieving namespaces")
+	}
+
+	var names []string
+	for _, namespace := range namespaces {
+		names = append(names, namespace.GetName())
+	}
+
+	return names, nil
+}
+
+// uniqueNamespaces returns namespaces without empty or repeated names.
+func uniqueNamespaces(namespaces []string) []string {
+	seen := make(map[string]bool)
+
+	var names []string
+	for _, namespace := range namespaces {
+		if namespace == "" || namespace == AllNamespaces || seen[namespace] {
+			continue
+		}
+		seen[namespace] = true
+		names = append(names, namespace)
+	}
+
+	return names
+}
+
+// listInformer lists the objects in an informer which match selectors.
+// Objects are limited to a namespace unless it is empty. Labels are matched
+// by the lister, which uses the informer's namespace index.
+func listInformer(gi informers.GenericInformer, namespace string, labelSelector labels.Selector, fieldSelector fields.Selector) ([]*unstructured.Unstructured, error) {
+	var objs []runtime.Object
+	var err error
+	if namespace == "" {
+		objs, err = gi.Lister().List(labelSelector)
+	} else {
+		objs, err = gi.Lister().ByNamespace(namespace).List(labelSelector)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "listing")
+	}
+
+	ret := make([]*unstructured.Unstructured, len(objs))
+	for i, obj := range objs {
+		u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return nil, errors.Wrapf(err, "converting %T to unstructured", obj)
+		}
+		ret[i] = &unstructured.Unstructured{Object: u}
+	}
+	return filterFields(ret, fieldSelector), nil
+}
+
+// cachedObject returns an object held by a running informer. Unlike
+// Retrieve, informers aren't created or waited on, so objects which aren't
+// already cached aren't found.
+func (c *InformerCache) cachedObject(key CacheKey) (*unstructured.Unstructured, bool) {
+	gvk := schema.FromAPIVersionAndKind(key.APIVersion, key.Kind)
+
+	c.mu.RLock()
+	ci, ok := c.informers[informerKey{namespace: key.Namespace, gvk: gvk}]
+	if !ok {
+		ci, ok = c.informers[informerKey{gvk: gvk}]
+	}
+	c.mu.RUnlock()
+	if !ok {
+		return nil, false
+	}
+
+	storeKey := key.Name
+	if key.Namespace != "" {
+		storeKey = key.Namespace + "/" + key.Name
+	}
+
+	obj, found, err := ci.informer.Informer().GetStore().GetByKey(storeKey)
+	if err != nil || !found {
+		return nil, false
+	}
+
+	u, ok := obj.(*unstructured.Unstructured)
+	return u, ok
+}
+
 // Store is not implemented
 func (c *InformerCache) Store(obj *unstructured.Unstructured) error {
 	return errors.New("not implemented: Store")
@@ -328,17 +811,29 @@ func (c *InformerCache) Delete(obj *unstructured.Unstructured) error {
 // Returns events related to the specified object.
 // TODO consider reworking this to use EventExpansion.Search(), which
 //      utilizes FieldSelectors (involvedObject.uid)
-func (c *InformerCache) getEvents(u *unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+func (c *InformerCache) getEvents(ctx context.Context, u *unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
 	var events []*unstructured.Unstructured
 
+	// Events for cluster scoped objects aren't in the object's namespace.
+	// They are usually recorded in the default namespace, but can be in
+	// any, so every namespace is searched.
+	namespace := u.GetNamespace()
+	if namespace == "" {
+		namespace = AllNamespaces
+	}
+
 	var eventKey = CacheKey{
-		Namespace:  u.GetNamespace(),
+		Namespace:  namespace,
 		APIVersion: "v1",
 		Kind:       "Event",
 	}
 
-	allEvents, err := c.Retrieve(eventKey)
+	allEvents, err := c.Retrieve(ctx, eventKey)
 	if err != nil {
+		// Objects are shown without events if events can't be listed.
+		if isForbidden(err) {
+			return nil, nil
+		}
 		return nil, err
 	}
 
@@ -361,6 +856,6 @@ func (c *InformerCache) getEvents(u *unstructured.Unstructured) ([]*unstructured
 }
 
 // Events returns events for an object.
-func (c *InformerCache) Events(u *unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
-	return c.getEvents(u)
+func (c *InformerCache) Events(ctx context.Context, u *unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+	return c.getEvents(ctx, u)
 }
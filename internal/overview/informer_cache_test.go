@@ -1,6 +1,7 @@
 package overview
 
 import (
+	"context"
 	"github.com/twosson/kubeapt/internal/cluster/fake"
 	"github.com/twosson/kubeapt/internal/log"
 	"sync/atomic"
@@ -9,10 +10,12 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	clienttesting "k8s.io/client-go/testing"
 )
 
 var resources = []*metav1.APIResourceList{
@@ -69,6 +72,33 @@ var resources = []*metav1.APIResourceList{
 				Verbs:        metav1.Verbs{"list", "watch"},
 				Categories:   []string{"all"},
 			},
+			metav1.APIResource{
+				Name:         "namespaces",
+				SingularName: "namespace",
+				Group:        "",
+				Version:      "v1",
+				Kind:         "Namespace",
+				Namespaced:   false,
+				Verbs:        metav1.Verbs{"list", "watch"},
+			},
+			metav1.APIResource{
+				Name:         "events",
+				SingularName: "event",
+				Group:        "",
+				Version:      "v1",
+				Kind:         "Event",
+				Namespaced:   true,
+				Verbs:        metav1.Verbs{"list", "watch"},
+			},
+			metav1.APIResource{
+				Name:         "nodes",
+				SingularName: "node",
+				Group:        "",
+				Version:      "v1",
+				Kind:         "Node",
+				Namespaced:   false,
+				Verbs:        metav1.Verbs{"list", "watch"},
+			},
 		},
 	},
 	{
@@ -191,6 +221,36 @@ func TestInformerCache_Retrieve(t *testing.T) {
 			},
 			expectedLen: 0,
 		},
+		{
+			name: "ns, apiVersion, kind, label selector",
+			key: CacheKey{
+				Namespace:     "default",
+				APIVersion:    "foo/v1",
+				Kind:          "Kind",
+				LabelSelector: "app=checkout",
+			},
+			expectedLen: 1,
+		},
+		{
+			name: "all namespaces, apiVersion, kind, label selector",
+			key: CacheKey{
+				Namespace:     AllNamespaces,
+				APIVersion:    "foo/v1",
+				Kind:          "Kind",
+				LabelSelector: "app=checkout",
+			},
+			expectedLen: 2,
+		},
+		{
+			name: "ns, apiVersion, kind, field selector",
+			key: CacheKey{
+				Namespace:     "default",
+				APIVersion:    "foo/v1",
+				Kind:          "Kind",
+				FieldSelector: "metadata.name=foo2",
+			},
+			expectedLen: 1,
+		},
 	}
 
 	for _, tc := range cases {
@@ -198,7 +258,7 @@ func TestInformerCache_Retrieve(t *testing.T) {
 			c, cancel, err := newCache(t, objects)
 			require.NoError(t, err)
 
-			objs, err := c.Retrieve(tc.key)
+			objs, err := c.Retrieve(context.Background(), tc.key)
 			hadErr := (err != nil)
 			assert.Equalf(t, tc.expectErr, hadErr, "error mismatch: %v", err)
 			assert.Len(t, objs, tc.expectedLen)
@@ -207,6 +267,179 @@ func TestInformerCache_Retrieve(t *testing.T) {
 	}
 }
 
+func TestInformerCache_Retrieve_cluster_scoped(t *testing.T) {
+	objects := []runtime.Object{
+		newUnstructured("v1", "Node", "", "node1"),
+		newUnstructured("v1", "Node", "", "node2"),
+	}
+
+	cases := []struct {
+		name        string
+		key         CacheKey
+		expectedLen int
+	}{
+		{
+			name:        "list without namespace",
+			key:         CacheKey{APIVersion: "v1", Kind: "Node"},
+			expectedLen: 2,
+		},
+		{
+			name:        "list with namespace",
+			key:         CacheKey{Namespace: "default", APIVersion: "v1", Kind: "Node"},
+			expectedLen: 2,
+		},
+		{
+			name:        "get without namespace",
+			key:         CacheKey{APIVersion: "v1", Kind: "Node", Name: "node1"},
+			expectedLen: 1,
+		},
+		{
+			name:        "get with namespace",
+			key:         CacheKey{Namespace: "default", APIVersion: "v1", Kind: "Node", Name: "node2"},
+			expectedLen: 1,
+		},
+		{
+			name:        "not found",
+			key:         CacheKey{APIVersion: "v1", Kind: "Node", Name: "does-not-exist"},
+			expectedLen: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, cancel, err := newCache(t, objects)
+			require.NoError(t, err)
+			defer cancel()
+
+			objs, err := c.Retrieve(context.Background(), tc.key)
+			require.NoError(t, err)
+			assert.Len(t, objs, tc.expectedLen)
+		})
+	}
+}
+
+func TestInformerCache_Events_cluster_scoped(t *testing.T) {
+	newEvent := func(namespace, name string, involvedObject map[string]interface{}) *unstructured.Unstructured {
+		event := newUnstructured("v1", "Event", namespace, name)
+		event.Object["involvedObject"] = involvedObject
+		return event
+	}
+
+	node := map[string]interface{}{"apiVersion": "v1", "kind": "Node", "name": "node1"}
+	pod := map[string]interface{}{"apiVersion": "v1", "kind": "Pod", "namespace": "default", "name": "node1"}
+
+	objects := []runtime.Object{
+		newUnstructured("v1", "Namespace", "", "default"),
+		newUnstructured("v1", "Namespace", "", "kube-system"),
+		newEvent("default", "node1.1", node),
+		newEvent("kube-system", "node1.2", node),
+		newEvent("default", "pod.1", pod),
+	}
+
+	c, cancel, err := newCache(t, objects)
+	require.NoError(t, err)
+	defer cancel()
+
+	events, err := c.Events(context.Background(), newUnstructured("v1", "Node", "", "node1"))
+	require.NoError(t, err)
+
+	var names []string
+	for _, event := range events {
+		names = append(names, event.GetName())
+	}
+	assert.ElementsMatch(t, []string{"node1.1", "node1.2"}, names)
+}
+
+func TestInformerCache_Retrieve_all_namespaces(t *testing.T) {
+	objects := []runtime.Object{
+		newUnstructured("v1", "Namespace", "", "default"),
+		newUnstructured("v1", "Namespace", "", "app-1"),
+		newUnstructured("v1", "Namespace", "", "restricted"),
+		newUnstructured("foo/v1", "Kind", "default", "foo1"),
+		newUnstructured("foo/v1", "Kind", "app-1", "foo1"),
+		newUnstructured("foo/v1", "Kind", "app-1", "foo2"),
+		newUnstructured("foo/v1", "Kind", "restricted", "foo3"),
+		newUnstructured("v1", "Node", "", "node1"),
+	}
+
+	cases := []struct {
+		name                string
+		key                 CacheKey
+		forbidden           []string
+		namespacesForbidden bool
+		expectedLen         int
+	}{
+		{
+			name:        "list",
+			key:         CacheKey{Namespace: AllNamespaces, APIVersion: "foo/v1", Kind: "Kind"},
+			expectedLen: 4,
+		},
+		{
+			name:        "get",
+			key:         CacheKey{Namespace: AllNamespaces, APIVersion: "foo/v1", Kind: "Kind", Name: "foo1"},
+			expectedLen: 2,
+		},
+		{
+			name:        "cluster scoped",
+			key:         CacheKey{Namespace: AllNamespaces, APIVersion: "v1", Kind: "Node"},
+			expectedLen: 1,
+		},
+		{
+			name:        "list by namespace when cluster list is forbidden",
+			key:         CacheKey{Namespace: AllNamespaces, APIVersion: "foo/v1", Kind: "Kind"},
+			forbidden:   []string{"", "restricted"},
+			expectedLen: 3,
+		},
+		{
+			name:                "list by fallback namespaces when namespaces are forbidden",
+			key:                 CacheKey{Namespace: AllNamespaces, APIVersion: "foo/v1", Kind: "Kind"},
+			forbidden:           []string{""},
+			namespacesForbidden: true,
+			expectedLen:         2,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			scheme := newScheme()
+
+			client, err := fake.NewClient(scheme, resources, objects)
+			require.NoError(t, err)
+
+			for _, namespace := range tc.forbidden {
+				forbiddenNamespace := namespace
+				client.FakeDynamic.PrependReactor("list", "kinds", func(action clienttesting.Action) (bool, runtime.Object, error) {
+					if action.GetNamespace() != forbiddenNamespace {
+						return false, nil, nil
+					}
+					return true, nil, apierrors.NewForbidden(schema.GroupResource{Group: "foo", Resource: "kinds"}, "", nil)
+				})
+			}
+
+			if tc.namespacesForbidden {
+				client.FakeDynamic.PrependReactor("list", "namespaces", func(action clienttesting.Action) (bool, runtime.Object, error) {
+					return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, "", nil)
+				})
+			}
+
+			restMapper, err := client.RESTMapper()
+			require.NoError(t, err)
+
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+
+			fallbackNamespaces := func() []string {
+				return []string{"app-1", "app-1", ""}
+			}
+			c := NewInformerCache(stopCh, client.FakeDynamic, restMapper, InformerCacheNamespacesOpt(fallbackNamespaces))
+
+			objs, err := c.Retrieve(context.Background(), tc.key)
+			require.NoError(t, err)
+			assert.Len(t, objs, tc.expectedLen)
+		})
+	}
+}
+
 func TestInformerCache_Watch(t *testing.T) {
 	scheme := newScheme()
 
@@ -239,7 +472,7 @@ func TestInformerCache_Watch(t *testing.T) {
 
 	// verify predefined objects are present
 	cacheKey := CacheKey{Namespace: "default", APIVersion: "apps/v1", Kind: "Deployment"}
-	found, err := cache.Retrieve(cacheKey)
+	found, err := cache.Retrieve(context.Background(), cacheKey)
 	require.NoError(t, err)
 
 	require.Len(t, found, 1)
@@ -279,7 +512,7 @@ func TestInformerCache_Watch(t *testing.T) {
 	case <-notifyCh:
 	}
 
-	found, err = cache.Retrieve(cacheKey)
+	found, err = cache.Retrieve(context.Background(), cacheKey)
 	require.NoError(t, err)
 
 	// 2 == initial + the new object
@@ -299,7 +532,7 @@ func TestInformerCache_Watch(t *testing.T) {
 	case <-notifyCh:
 	}
 
-	found, err = cache.Retrieve(cacheKey)
+	found, err = cache.Retrieve(context.Background(), cacheKey)
 	require.NoError(t, err)
 
 	require.Len(t, found, 2)
@@ -341,7 +574,7 @@ func TestInformerCache_Watch_Stop(t *testing.T) {
 
 	// verify predefined objects are present
 	cacheKey := CacheKey{Namespace: "default", APIVersion: "apps/v1", Kind: "Deployment"}
-	found, err := cache.Retrieve(cacheKey)
+	found, err := cache.Retrieve(context.Background(), cacheKey)
 	require.NoError(t, err)
 
 	require.Len(t, found, 0)
@@ -383,7 +616,7 @@ func TestInformerCache_Watch_Stop(t *testing.T) {
 	_, err = resClient.Create(obj)
 	require.NoError(t, err)
 
-	found, err = cache.Retrieve(cacheKey)
+	found, err = cache.Retrieve(context.Background(), cacheKey)
 	require.NoError(t, err)
 
 	// The second object is not seen because we shutdown the informer
@@ -424,3 +657,123 @@ func TestChannelContext(t *testing.T) {
 	<-done2
 	assert.Equal(t, int32(0), atomic.LoadInt32(&count))
 }
+
+func TestInformerCache_Retrieve_loading(t *testing.T) {
+	clusterClient, err := fake.NewClient(newScheme(), resources, nil)
+	require.NoError(t, err)
+
+	release := make(chan struct{})
+	clusterClient.FakeDynamic.PrependReactor("list", "deployments", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		<-release
+		return false, nil, nil
+	})
+
+	restMapper, err := clusterClient.RESTMapper()
+	require.NoError(t, err)
+
+	notifyCh := make(chan CacheNotification)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	c := NewInformerCache(stopCh, clusterClient.FakeDynamic, restMapper,
+		InformerCacheNotificationOpt(notifyCh, stopCh),
+		InformerCacheLoggerOpt(log.TestLogger(t)),
+		InformerCacheSyncTimeoutOpt(10*time.Millisecond),
+	)
+
+	cacheKey := CacheKey{Namespace: "default", APIVersion: "apps/v1", Kind: "Deployment"}
+
+	_, err = c.Retrieve(context.Background(), cacheKey)
+	require.Error(t, err)
+	assert.True(t, isLoading(err))
+
+	close(release)
+
+	select {
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for sync to notify")
+	case notification := <-notifyCh:
+		assert.Equal(t, CacheNotification{CacheKey: cacheKey, Action: CacheUpdate}, notification)
+	}
+
+	found, err := c.Retrieve(context.Background(), cacheKey)
+	require.NoError(t, err)
+	assert.Empty(t, found)
+}
+
+func TestInformerCache_Retrieve_canceled(t *testing.T) {
+	clusterClient, err := fake.NewClient(newScheme(), resources, nil)
+	require.NoError(t, err)
+
+	release := make(chan struct{})
+	defer close(release)
+	clusterClient.FakeDynamic.PrependReactor("list", "deployments", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		<-release
+		return false, nil, nil
+	})
+
+	restMapper, err := clusterClient.RESTMapper()
+	require.NoError(t, err)
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	c := NewInformerCache(stopCh, clusterClient.FakeDynamic, restMapper)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = c.Retrieve(ctx, CacheKey{Namespace: "default", APIVersion: "apps/v1", Kind: "Deployment"})
+	require.Error(t, err)
+	assert.True(t, isLoading(err))
+}
+
+func TestInformerCache_startInformers(t *testing.T) {
+	clusterClient, err := fake.NewClient(newScheme(), resources, nil)
+	require.NoError(t, err)
+
+	release := make(chan struct{})
+	defer close(release)
+	clusterClient.FakeDynamic.PrependReactor("list", "deployments", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		<-release
+		return false, nil, nil
+	})
+
+	restMapper, err := clusterClient.RESTMapper()
+	require.NoError(t, err)
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	c := NewInformerCache(stopCh, clusterClient.FakeDynamic, restMapper, InformerCacheSyncTimeoutOpt(time.Minute))
+
+	// Starting doesn't wait for the informer to sync.
+	c.startInformers(context.Background(), []CacheKey{
+		{Namespace: "default", APIVersion: "apps/v1", Kind: "Deployment"},
+	})
+
+	c.mu.RLock()
+	_, ok := c.syncing[informerKey{namespace: "default", gvk: schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}}]
+	c.mu.RUnlock()
+	assert.True(t, ok)
+}
+
+func TestInformerCache_syncContext(t *testing.T) {
+	c := &InformerCache{syncTimeout: time.Minute}
+
+	ctx := withSyncDeadline(context.Background())
+
+	ctx1, cancel1 := c.syncContext(ctx)
+	defer cancel1()
+
+	time.Sleep(time.Millisecond)
+
+	ctx2, cancel2 := c.syncContext(ctx)
+	defer cancel2()
+
+	deadline1, ok := ctx1.Deadline()
+	require.True(t, ok)
+	deadline2, ok := ctx2.Deadline()
+	require.True(t, ok)
+	assert.Equal(t, deadline1, deadline2)
+}
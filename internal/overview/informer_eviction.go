@@ -0,0 +1,218 @@
+package overview
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/client-go/informers"
+)
+
+// cachedInformer is an informer run by the InformerCache, along with how it
+// is being used.
+type cachedInformer struct {
+	informer informers.GenericInformer
+	ctx      context.Context
+	cancel   context.CancelFunc
+
+	// lastUsed is the time the informer was last used in nanoseconds. It
+	// is updated atomically, since lookups only hold a read lock.
+	lastUsed int64
+
+	// refs and loading are guarded by the cache's lock.
+	refs    int
+	loading bool
+}
+
+// newCachedInformer creates a cachedInformer which is stopped when stopCh
+// is closed, or when it is evicted.
+func newCachedInformer(gi informers.GenericInformer, stopCh <-chan struct{}) *cachedInformer {
+	ctx, cancel := channelContext(stopCh)
+
+	return &cachedInformer{
+		informer: gi,
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+// done returns a channel which is closed when the informer is stopped.
+func (ci *cachedInformer) done() <-chan struct{} {
+	return ci.ctx.Done()
+}
+
+// stopped reports whether the informer has been stopped.
+func (ci *cachedInformer) stopped() bool {
+	return ci.ctx.Err() != nil
+}
+
+func (ci *cachedInformer) lastUsedTime() time.Time {
+	return time.Unix(0, atomic.LoadInt64(&ci.lastUsed))
+}
+
+// informerRefs records the informers used to generate content for a
+// stream. Informers with references aren't evicted, so streams keep
+// receiving updates while they are open.
+type informerRefs struct {
+	mu       sync.Mutex
+	released bool
+	caches   map[*cachedInformer]*InformerCache
+}
+
+type informerRefsKey struct{}
+
+// withInformerRefs returns a context which records the informers used while
+// it is active. The returned informerRefs must be released when the content
+// is no longer needed.
+func withInformerRefs(ctx context.Context) (context.Context, *informerRefs) {
+	refs := &informerRefs{
+		caches: make(map[*cachedInformer]*InformerCache),
+	}
+	return context.WithValue(ctx, informerRefsKey{}, refs), refs
+}
+
+// informerRefsFrom returns the informerRefs in a context, if there are any.
+func informerRefsFrom(ctx context.Context) *informerRefs {
+	refs, _ := ctx.Value(informerRefsKey{}).(*informerRefs)
+	return refs
+}
+
+// add references an informer. Informers are only referenced once.
+func (r *informerRefs) add(c *InformerCache, ci *cachedInformer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.released {
+		return
+	}
+	if _, ok := r.caches[ci]; ok {
+		return
+	}
+
+	r.caches[ci] = c
+
+	c.mu.Lock()
+	ci.refs++
+	c.mu.Unlock()
+}
+
+// release removes references to every informer. Informers used after the
+// references are released aren't referenced.
+func (r *informerRefs) release() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.released = true
+
+	for ci, c := range r.caches {
+		c.mu.Lock()
+		ci.refs--
+		c.mu.Unlock()
+	}
+
+	r.caches = nil
+}
+
+// use records that an informer was used.
+func (c *InformerCache) use(ctx context.Context, ci *cachedInformer) {
+	atomic.StoreInt64(&ci.lastUsed, c.now().UnixNano())
+
+	if refs := informerRefsFrom(ctx); refs != nil {
+		refs.add(c, ci)
+	}
+}
+
+// runEvictor periodically evicts idle informers until the cache is stopped.
+// Run this as a goroutine.
+func (c *InformerCache) runEvictor() {
+	ticker := time.NewTicker(c.idleTTL / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stopCh:
+			return
+		case <-ticker.C:
+			c.evictIdle()
+		}
+	}
+}
+
+// evictIdle stops informers which haven't been used within the idle TTL
+// and aren't referenced.
+func (c *InformerCache) evictIdle() {
+	c.mu.Lock()
+
+	now := c.now()
+
+	var evicted []informerKey
+	for _, set := range []map[informerKey]*cachedInformer{c.informers, c.syncing} {
+		for key, ci := range set {
+			if ci.refs > 0 || now.Sub(ci.lastUsedTime()) < c.idleTTL {
+				continue
+			}
+
+			c.evict(set, key, ci)
+			evicted = append(evicted, key)
+		}
+	}
+
+	c.mu.Unlock()
+
+	for _, key := range evicted {
+		c.notifyEvicted(key)
+	}
+}
+
+// evictLeastRecentlyUsed stops the least recently used informer which isn't
+// referenced, and returns its key. The cache's lock must be held.
+func (c *InformerCache) evictLeastRecentlyUsed() (informerKey, bool) {
+	var oldest *cachedInformer
+	var oldestKey informerKey
+	var oldestSet map[informerKey]*cachedInformer
+
+	for _, set := range []map[informerKey]*cachedInformer{c.informers, c.syncing} {
+		for key, ci := range set {
+			if ci.refs > 0 {
+				continue
+			}
+
+			if oldest == nil || ci.lastUsedTime().Before(oldest.lastUsedTime()) {
+				oldest = ci
+				oldestKey = key
+				oldestSet = set
+			}
+		}
+	}
+
+	if oldest == nil {
+		c.logger.With("max", c.maxInformers).Warnf("informer limit reached, but every informer is in use")
+		return informerKey{}, false
+	}
+
+	c.evict(oldestSet, oldestKey, oldest)
+	return oldestKey, true
+}
+
+// evict stops an informer and removes it. The cache's lock must be held.
+func (c *InformerCache) evict(set map[informerKey]*cachedInformer, key informerKey, ci *cachedInformer) {
+	c.logger.With("gvk", key.gvk.String(), "namespace", key.namespace).Debugf("evicting informer")
+
+	ci.cancel()
+	delete(set, key)
+}
+
+// notifyEvicted sends a delete notification without a name for an evicted
+// informer, since the objects it held are no longer cached. The cache's
+// lock must not be held.
+func (c *InformerCache) notifyEvicted(key informerKey) {
+	c.notify(CacheNotification{
+		CacheKey: CacheKey{
+			Namespace:  key.namespace,
+			APIVersion: key.gvk.GroupVersion().String(),
+			Kind:       key.gvk.Kind,
+		},
+		Action: CacheDelete,
+	})
+}
@@ -0,0 +1,132 @@
+package overview
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twosson/kubeapt/internal/cluster/fake"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func newEvictionTestCache(t *testing.T, stopCh <-chan struct{}, opts ...InformerCacheOpt) *InformerCache {
+	objects := []runtime.Object{
+		newUnstructured("apps/v1", "Deployment", "default", "deploy"),
+	}
+
+	clusterClient, err := fake.NewClient(newScheme(), resources, objects)
+	require.NoError(t, err)
+
+	restMapper, err := clusterClient.RESTMapper()
+	require.NoError(t, err)
+
+	return NewInformerCache(stopCh, clusterClient.FakeDynamic, restMapper, opts...)
+}
+
+func informerCount(c *InformerCache) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.informers) + len(c.syncing)
+}
+
+func TestInformerCache_evictIdle(t *testing.T) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	c := newEvictionTestCache(t, stopCh, InformerCacheIdleTTLOpt(time.Minute))
+
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	key := CacheKey{Namespace: "default", APIVersion: "apps/v1", Kind: "Deployment"}
+
+	_, err := c.Retrieve(context.Background(), key)
+	require.NoError(t, err)
+
+	ctx, refs := withInformerRefs(context.Background())
+	_, err = c.Retrieve(ctx, CacheKey{Namespace: "other", APIVersion: "apps/v1", Kind: "Deployment"})
+	require.NoError(t, err)
+
+	require.Equal(t, 2, informerCount(c))
+
+	c.evictIdle()
+	assert.Equal(t, 2, informerCount(c))
+
+	now = now.Add(2 * time.Minute)
+
+	c.evictIdle()
+	assert.Equal(t, 1, informerCount(c), "referenced informer is kept")
+
+	refs.release()
+
+	c.evictIdle()
+	assert.Equal(t, 0, informerCount(c))
+
+	// Evicted informers are created again when they are used.
+	objects, err := c.Retrieve(context.Background(), key)
+	require.NoError(t, err)
+	assert.Len(t, objects, 1)
+}
+
+func TestInformerCache_maxInformers(t *testing.T) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	c := newEvictionTestCache(t, stopCh, InformerCacheMaxInformersOpt(2))
+
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	for _, namespace := range []string{"a", "b", "c"} {
+		now = now.Add(time.Second)
+
+		_, err := c.Retrieve(context.Background(), CacheKey{Namespace: namespace, APIVersion: "apps/v1", Kind: "Deployment"})
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, 2, informerCount(c))
+
+	c.mu.RLock()
+	var namespaces []string
+	for _, set := range []map[informerKey]*cachedInformer{c.informers, c.syncing} {
+		for key := range set {
+			namespaces = append(namespaces, key.namespace)
+		}
+	}
+	c.mu.RUnlock()
+
+	assert.ElementsMatch(t, []string{"b", "c"}, namespaces)
+}
+
+func Test_informerRefs(t *testing.T) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	c := newEvictionTestCache(t, stopCh)
+
+	ctx, refs := withInformerRefs(context.Background())
+	require.Equal(t, refs, informerRefsFrom(ctx))
+	assert.Nil(t, informerRefsFrom(context.Background()))
+
+	key := CacheKey{Namespace: "default", APIVersion: "apps/v1", Kind: "Deployment"}
+
+	for i := 0; i < 2; i++ {
+		_, err := c.Retrieve(ctx, key)
+		require.NoError(t, err)
+	}
+
+	stats := c.stats()
+	require.Len(t, stats.Informers, 1)
+	assert.Equal(t, 1, stats.Informers[0].References)
+
+	refs.release()
+
+	_, err := c.Retrieve(ctx, key)
+	require.NoError(t, err)
+
+	stats = c.stats()
+	require.Len(t, stats.Informers, 1)
+	assert.Equal(t, 0, stats.Informers[0].References)
+}
@@ -3,7 +3,7 @@ package overview
 import (
 	"context"
 	"github.com/pkg/errors"
-	"github.com/twosson/kubeapt/internal/content"
+	"github.com/twosson/kubeapt/pkg/content"
 	"k8s.io/api/extensions/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/clock"
@@ -35,12 +35,14 @@ func (js *IngressSummary) Content(ctx context.Context, object runtime.Object, c
 	}, nil
 }
 
-type IngressDetails struct{}
+type IngressDetails struct {
+	prefix string
+}
 
 var _ View = (*IngressDetails)(nil)
 
 func NewIngressDetails(prefix, namespace string, c clock.Clock) View {
-	return &IngressDetails{}
+	return &IngressDetails{prefix: prefix}
 }
 
 func (ing *IngressDetails) Content(ctx context.Context, object runtime.Object, c Cache) ([]content.Content, error) {
@@ -51,7 +53,7 @@ func (ing *IngressDetails) Content(ctx context.Context, object runtime.Object, c
 
 	return []content.Content{
 		ingressTLSTable(ingress),
-		ingressRulesTable(ingress),
+		ingressRulesTable(ing.prefix, ingress),
 	}, nil
 }
 
@@ -73,7 +75,7 @@ func ingressTLSTable(ingress *v1beta1.Ingress) *content.Table {
 	return &table
 }
 
-func ingressRulesTable(ingress *v1beta1.Ingress) *content.Table {
+func ingressRulesTable(prefix string, ingress *v1beta1.Ingress) *content.Table {
 	table := content.NewTable("Rules", "Rules are not configured for this Ingress")
 
 	table.Columns = tableCols("Host", "Path", "Backend")
@@ -85,7 +87,7 @@ func ingressRulesTable(ingress *v1beta1.Ingress) *content.Table {
 				table.AddRow(content.TableRow{
 					"Host":    content.NewStringText(rule.Host),
 					"Path":    content.NewStringText(path.Path),
-					"Backend": content.NewLinkText(backendText, gvkPath("v1", "Service", path.Backend.ServiceName)),
+					"Backend": content.NewLinkText(backendText, gvkPath(prefix, "v1", "Service", path.Backend.ServiceName)),
 				})
 			}
 		}
@@ -4,22 +4,22 @@ import (
 	"context"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"github.com/twosson/kubeapt/internal/content"
+	"github.com/twosson/kubeapt/pkg/content"
 	"k8s.io/apimachinery/pkg/util/clock"
 	"testing"
 	"time"
 )
 
 func TestIngressSummary_InvalidObject(t *testing.T) {
-	assertViewInvalidObject(t, NewIngressSummary("prefix", "ns", clock.NewFakeClock(time.Now())))
+	assertViewInvalidObject(t, NewIngressSummary(testPrefix, "ns", clock.NewFakeClock(time.Now())))
 }
 
 func TestIngressDetails_InvalidObject(t *testing.T) {
-	assertViewInvalidObject(t, NewIngressDetails("prefix", "ns", clock.NewFakeClock(time.Now())))
+	assertViewInvalidObject(t, NewIngressDetails(testPrefix, "ns", clock.NewFakeClock(time.Now())))
 }
 
 func TestIngressDetails(t *testing.T) {
-	v := NewIngressDetails("prefix", "ns", clock.NewFakeClock(time.Now()))
+	v := NewIngressDetails(testPrefix, "ns", clock.NewFakeClock(time.Now()))
 
 	cache := NewMemoryCache()
 
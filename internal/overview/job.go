@@ -3,18 +3,20 @@ package overview
 import (
 	"context"
 	"github.com/pkg/errors"
-	"github.com/twosson/kubeapt/internal/content"
+	"github.com/twosson/kubeapt/pkg/content"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/clock"
 	"k8s.io/kubernetes/pkg/apis/batch"
 )
 
-type JobSummary struct{}
+type JobSummary struct {
+	prefix string
+}
 
 var _ View = (*JobSummary)(nil)
 
 func NewJobSummary(prefix, namespace string, c clock.Clock) View {
-	return &JobSummary{}
+	return &JobSummary{prefix: prefix}
 }
 
 func (js *JobSummary) Content(ctx context.Context, object runtime.Object, c Cache) ([]content.Content, error) {
@@ -26,12 +28,12 @@ func (js *JobSummary) Content(ctx context.Context, object runtime.Object, c Cach
 	s := job.Spec.Selector
 	s.MatchLabels["job-name"] = job.Labels["job-name"]
 
-	pods, err := listPods(job.GetNamespace(), s, job.GetUID(), c)
+	pods, err := listPods(ctx, job.GetNamespace(), s, job.GetUID(), c)
 	if err != nil {
 		return nil, err
 	}
 
-	detail, err := printJobSummary(job, pods)
+	detail, err := printJobSummary(js.prefix, job, pods)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,84 @@
+package overview
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/twosson/kubeapt/pkg/content"
+)
+
+// errInformerStarted is returned instead of objects by retrievals which only
+// start informers.
+var errInformerStarted = errors.New("informer started")
+
+// informerStarter is implemented by caches which load objects in the
+// background.
+type informerStarter interface {
+	startInformers(ctx context.Context, keys []CacheKey)
+}
+
+type startInformersKey struct{}
+
+// startingInformers returns true if retrievals made with ctx should only
+// start informers.
+func startingInformers(ctx context.Context) bool {
+	starting, _ := ctx.Value(startInformersKey{}).(bool)
+	return starting
+}
+
+type syncDeadlineKey struct{}
+
+// syncDeadline is when retrievals for a request stop waiting for the cache
+// to sync. It is set when the first of them starts waiting.
+type syncDeadline struct {
+	once     sync.Once
+	deadline time.Time
+}
+
+// withSyncDeadline returns a context whose retrievals share a sync deadline,
+// so content which retrieves several kinds waits for the cache at most
+// once in total.
+func withSyncDeadline(ctx context.Context) context.Context {
+	return context.WithValue(ctx, syncDeadlineKey{}, &syncDeadline{})
+}
+
+// loadingError is returned when objects can't be retrieved before a request
+// stops waiting for the cache to sync. The namespace is empty for cluster
+// scoped objects and for objects in every namespace.
+type loadingError struct {
+	resource  string
+	namespace string
+}
+
+func (e *loadingError) Error() string {
+	if e.namespace == "" {
+		return fmt.Sprintf("%s in the cluster are still loading", e.resource)
+	}
+	return fmt.Sprintf("%s in namespace %s are still loading", e.resource, e.namespace)
+}
+
+// isLoading returns true if err was caused by a loadingError.
+func isLoading(err error) bool {
+	_, ok := errors.Cause(err).(*loadingError)
+	return ok
+}
+
+// loadingContent creates content which explains what is still loading.
+func loadingContent(err error) content.Content {
+	loading := content.NewLoading("Loading", errors.Cause(err).Error())
+	return &loading
+}
+
+// loadingContentResponse creates a response which is shown until objects
+// have been loaded. Streams are refreshed once the cache has synced.
+func loadingContentResponse(err error) ContentResponse {
+	return ContentResponse{
+		Title: "Loading",
+		Views: []Content{
+			{Contents: []content.Content{loadingContent(err)}},
+		},
+	}
+}
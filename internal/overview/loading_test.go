@@ -0,0 +1,70 @@
+package overview
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twosson/kubeapt/internal/cluster"
+	"github.com/twosson/kubeapt/pkg/content"
+)
+
+func Test_isLoading(t *testing.T) {
+	err := &loadingError{resource: "pods", namespace: "default"}
+
+	assert.True(t, isLoading(err))
+	assert.True(t, isLoading(errors.Wrap(err, "loading objects")))
+	assert.False(t, isLoading(errors.New("error")))
+
+	assert.Equal(t, "pods in namespace default are still loading", err.Error())
+}
+
+func Test_realGenerator_loading(t *testing.T) {
+	describer := &loadingDescriber{resource: "pods"}
+	pathFilters := []pathFilter{*newPathFilter("/pods", describer)}
+
+	g := newGenerator(NewMemoryCache(), pathFilters, nil)
+
+	got, err := g.Generate(context.Background(), "/pods", testPrefix, "default")
+	require.NoError(t, err)
+
+	loading := content.NewLoading("Loading", "pods in namespace default are still loading")
+	expected := ContentResponse{
+		Title: "Loading",
+		Views: []Content{
+			{Contents: []content.Content{&loading}},
+		},
+	}
+	assert.Equal(t, expected, got)
+}
+
+func TestSectionDescriber_loading(t *testing.T) {
+	loadingPods := &loadingDescriber{resource: "pods"}
+	forbidden := &forbiddenDescriber{resource: "secrets"}
+
+	d := NewSectionDescriber("/section", "Section", loadingPods, forbidden)
+	got, err := d.Describe(context.Background(), testPrefix, "default", nil, DescriberOptions{})
+	require.NoError(t, err)
+
+	loading := content.NewLoading("Loading", "pods in namespace default are still loading")
+	expected := ContentResponse{
+		Views: []Content{
+			{Contents: []content.Content{&loading}, Title: "Section"},
+		},
+	}
+	assert.Equal(t, expected, got)
+}
+
+type loadingDescriber struct {
+	resource string
+}
+
+func (d *loadingDescriber) Describe(ctx context.Context, prefix, namespace string, clusterClient cluster.ClientInterface, options DescriberOptions) (ContentResponse, error) {
+	return emptyContentResponse, &loadingError{resource: d.resource, namespace: namespace}
+}
+
+func (d *loadingDescriber) PathFilters(namespace string) []pathFilter {
+	return nil
+}
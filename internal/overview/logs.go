@@ -0,0 +1,275 @@
+package overview
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/twosson/kubeapt/internal/cluster"
+	"github.com/twosson/kubeapt/internal/log"
+	"github.com/twosson/kubeapt/pkg/content"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const podLogsPath = "/workloads/pods/{name}/logs"
+
+// podLogsHandler serves container logs for a pod. Logs are returned as
+// content.Logs, or streamed over server sent events when follow is set. Pods
+// are in the current namespace unless the namespace query parameter is set.
+type podLogsHandler struct {
+	clusterClient    cluster.ClientInterface
+	currentNamespace func() string
+	streamFn         streamFn
+	logger           log.Logger
+}
+
+var _ http.Handler = (*podLogsHandler)(nil)
+
+func newPodLogsHandler(clusterClient cluster.ClientInterface, currentNamespace func() string, sfn streamFn, logger log.Logger) *podLogsHandler {
+	return &podLogsHandler{
+		clusterClient:    clusterClient,
+		currentNamespace: currentNamespace,
+		streamFn:         sfn,
+		logger:           logger,
+	}
+}
+
+func (h *podLogsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	name := mux.Vars(r)["name"]
+
+	query := r.URL.Query()
+	namespace := query.Get("namespace")
+	if namespace == "" {
+		namespace = h.currentNamespace()
+	}
+
+	logger := h.logger.With("pod", name, "namespace", namespace)
+
+	options, err := podLogOptionsFromQuery(query)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error(), logger)
+		return
+	}
+
+	kubeClient, err := h.clusterClient.KubernetesClient()
+	if err != nil {
+		logger.Errorf("creating kubernetes client: %v", err)
+		respondWithError(w, http.StatusInternalServerError, err.Error(), logger)
+		return
+	}
+
+	pods := kubeClient.CoreV1().Pods(namespace)
+
+	pod, err := pods.Get(name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			respondWithError(w, http.StatusNotFound, contentNotFound.Error(), logger)
+			return
+		}
+		logger.Errorf("fetching pod: %v", err)
+		respondWithError(w, http.StatusInternalServerError, err.Error(), logger)
+		return
+	}
+
+	containers := podContainerNames(pod)
+	if options.Container == "" && len(containers) > 0 {
+		options.Container = containers[0]
+	}
+
+	if !containsString(containers, options.Container) {
+		message := fmt.Sprintf("container %q is not valid for pod %q", options.Container, name)
+		respondWithError(w, http.StatusBadRequest, message, logger)
+		return
+	}
+
+	rc, err := pods.GetLogs(name, options).Stream()
+	if err != nil {
+		logger.Errorf("streaming logs: %v", err)
+		respondWithError(w, http.StatusInternalServerError, err.Error(), logger)
+		return
+	}
+	defer rc.Close()
+
+	newLogs := func() content.Logs {
+		return content.NewLogs(name, options.Container, containers)
+	}
+
+	if options.Follow {
+		h.follow(ctx, w, rc, newLogs, options.Timestamps)
+		return
+	}
+
+	logs := newLogs()
+	if err := readLogLines(rc, options.Timestamps, logs.AddLine); err != nil {
+		logger.Errorf("reading logs: %v", err)
+		respondWithError(w, http.StatusInternalServerError, err.Error(), logger)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if err := json.NewEncoder(w).Encode(&logs); err != nil {
+		logger.Errorf("encoding response: %v", err)
+	}
+}
+
+// follow streams log lines as they are written. Each message contains a
+// single line.
+func (h *podLogsHandler) follow(ctx context.Context, w http.ResponseWriter, rc io.ReadCloser, newLogs func() content.Logs, timestamps bool) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Closing the stream unblocks the reader when the client goes away.
+	go func() {
+		<-ctx.Done()
+		rc.Close()
+	}()
+
+	// The channel is unbuffered so every line has been handed to streamFn
+	// before the stream is canceled at the end of the logs.
+	ch := make(chan []byte)
+
+	go func() {
+		defer cancel()
+
+		err := readLogLines(rc, timestamps, func(line content.LogLine) {
+			logs := newLogs()
+			logs.AddLine(line)
+
+			data, err := json.Marshal(&logs)
+			if err != nil {
+				h.logger.Errorf("marshal err: %v", err)
+				return
+			}
+
+			select {
+			case ch <- data:
+			case <-ctx.Done():
+			}
+		})
+
+		if err != nil && ctx.Err() == nil {
+			h.logger.Errorf("reading logs: %v", err)
+		}
+	}()
+
+	h.streamFn(ctx, w, ch)
+}
+
+// podLogOptionsFromQuery creates log options from query parameters.
+func podLogOptionsFromQuery(query url.Values) (*corev1.PodLogOptions, error) {
+	options := &corev1.PodLogOptions{
+		Container: query.Get("container"),
+	}
+
+	var err error
+
+	if options.Previous, err = parseBoolParam(query, "previous"); err != nil {
+		return nil, err
+	}
+	if options.Timestamps, err = parseBoolParam(query, "timestamps"); err != nil {
+		return nil, err
+	}
+	if options.Follow, err = parseBoolParam(query, "follow"); err != nil {
+		return nil, err
+	}
+
+	if options.SinceSeconds, err = parseInt64Param(query, "sinceSeconds"); err != nil {
+		return nil, err
+	}
+	if options.TailLines, err = parseInt64Param(query, "tail"); err != nil {
+		return nil, err
+	}
+
+	return options, nil
+}
+
+func parseBoolParam(query url.Values, name string) (bool, error) {
+	s := query.Get(name)
+	if s == "" {
+		return false, nil
+	}
+
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, errors.Errorf("%s must be a boolean", name)
+	}
+
+	return b, nil
+}
+
+func parseInt64Param(query url.Values, name string) (*int64, error) {
+	s := query.Get(name)
+	if s == "" {
+		return nil, nil
+	}
+
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || i < 0 {
+		return nil, errors.Errorf("%s must be a non-negative integer", name)
+	}
+
+	return &i, nil
+}
+
+// readLogLines reads lines from r until EOF.
+func readLogLines(r io.Reader, timestamps bool, fn func(content.LogLine)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		fn(parseLogLine(scanner.Text(), timestamps))
+	}
+
+	return scanner.Err()
+}
+
+// parseLogLine parses a log line. When timestamps are requested, the API
+// server prefixes each line with a RFC3339 timestamp and a space.
+func parseLogLine(s string, timestamps bool) content.LogLine {
+	if !timestamps {
+		return content.LogLine{Message: s}
+	}
+
+	parts := strings.SplitN(s, " ", 2)
+	if len(parts) != 2 {
+		return content.LogLine{Message: s}
+	}
+
+	return content.LogLine{
+		Timestamp: parts[0],
+		Message:   parts[1],
+	}
+}
+
+func podContainerNames(pod *corev1.Pod) []string {
+	var names []string
+	for _, container := range pod.Spec.Containers {
+		names = append(names, container.Name)
+	}
+	for _, container := range pod.Spec.InitContainers {
+		names = append(names, container.Name)
+	}
+
+	return names
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
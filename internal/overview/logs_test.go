@@ -0,0 +1,221 @@
+package overview
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twosson/kubeapt/internal/cluster/fake"
+	"github.com/twosson/kubeapt/internal/log"
+	"github.com/twosson/kubeapt/pkg/content"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// newFakeLogServer creates a fake API server which serves a pod with two
+// containers and its logs. Log query parameters are sent to queryCh.
+func newFakeLogServer(t *testing.T, queryCh chan<- url.Values) *httptest.Server {
+	pod := &corev1.Pod{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "default"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app"}, {Name: "sidecar"}},
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/namespaces/default/pods/pod", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(pod))
+	})
+	mux.HandleFunc("/api/v1/namespaces/default/pods/pod/log", func(w http.ResponseWriter, r *http.Request) {
+		if queryCh != nil {
+			queryCh <- r.URL.Query()
+		}
+		fmt.Fprint(w, "2018-11-08T17:55:45Z line 1\n2018-11-08T17:55:46Z line 2\n")
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`)
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func newPodLogsTestHandler(t *testing.T, ts *httptest.Server, namespace string, sfn streamFn) http.Handler {
+	clusterClient, err := fake.NewClient(runtime.NewScheme(), resources, nil)
+	require.NoError(t, err)
+
+	kubeClient, err := kubernetes.NewForConfig(&rest.Config{Host: ts.URL})
+	require.NoError(t, err)
+	clusterClient.FakeKubernetes = kubeClient
+
+	h := newHandler("/api", newStubbedGenerator(nil, nil), nil, stubStream, log.NopLogger())
+	h.handle("/api", podLogsPath, newPodLogsHandler(clusterClient, func() string { return namespace }, sfn, log.NopLogger()))
+
+	return h
+}
+
+func Test_podLogsHandler(t *testing.T) {
+	queryCh := make(chan url.Values, 1)
+	ts := newFakeLogServer(t, queryCh)
+	defer ts.Close()
+
+	h := newPodLogsTestHandler(t, ts, "default", stubStream)
+
+	values := url.Values{
+		"namespace":    []string{"default"},
+		"container":    []string{"sidecar"},
+		"previous":     []string{"true"},
+		"sinceSeconds": []string{"60"},
+		"tail":         []string{"10"},
+		"timestamps":   []string{"true"},
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/api/workloads/pods/pod/logs?"+values.Encode(), nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	query := <-queryCh
+	assert.Equal(t, "sidecar", query.Get("container"))
+	assert.Equal(t, "true", query.Get("previous"))
+	assert.Equal(t, "60", query.Get("sinceSeconds"))
+	assert.Equal(t, "10", query.Get("tailLines"))
+	assert.Equal(t, "true", query.Get("timestamps"))
+	assert.Equal(t, "", query.Get("follow"))
+
+	expected := content.NewLogs("pod", "sidecar", []string{"app", "sidecar"})
+	expected.AddLine(content.LogLine{Timestamp: "2018-11-08T17:55:45Z", Message: "line 1"})
+	expected.AddLine(content.LogLine{Timestamp: "2018-11-08T17:55:46Z", Message: "line 2"})
+
+	var got content.Logs
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+	assert.Equal(t, expected, got)
+}
+
+func Test_podLogsHandler_errors(t *testing.T) {
+	ts := newFakeLogServer(t, nil)
+	defer ts.Close()
+
+	h := newPodLogsTestHandler(t, ts, "default", stubStream)
+
+	cases := []struct {
+		name         string
+		path         string
+		expectedCode int
+	}{
+		{
+			name:         "invalid tail",
+			path:         "/api/workloads/pods/pod/logs?tail=many",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid container",
+			path:         "/api/workloads/pods/pod/logs?container=missing",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "pod not found",
+			path:         "/api/workloads/pods/missing/logs",
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedCode, w.Code)
+		})
+	}
+}
+
+func Test_podLogsHandler_current_namespace(t *testing.T) {
+	ts := newFakeLogServer(t, nil)
+	defer ts.Close()
+
+	h := newPodLogsTestHandler(t, ts, "other", stubStream)
+
+	cases := []struct {
+		name         string
+		path         string
+		expectedCode int
+	}{
+		{
+			name:         "current namespace",
+			path:         "/api/workloads/pods/pod/logs",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "namespace query",
+			path:         "/api/workloads/pods/pod/logs?namespace=default",
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedCode, w.Code)
+		})
+	}
+}
+
+func Test_podLogsHandler_follow(t *testing.T) {
+	queryCh := make(chan url.Values, 1)
+	ts := newFakeLogServer(t, queryCh)
+	defer ts.Close()
+
+	var messages []string
+	sfn := func(ctx context.Context, w http.ResponseWriter, ch chan []byte) {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg := <-ch:
+				messages = append(messages, string(msg))
+			}
+		}
+	}
+
+	h := newPodLogsTestHandler(t, ts, "default", sfn)
+
+	r := httptest.NewRequest(http.MethodGet, "/api/workloads/pods/pod/logs?follow=true", nil)
+	w := httptest.NewRecorder()
+
+	// The handler returns when the log stream ends.
+	h.ServeHTTP(w, r)
+
+	query := <-queryCh
+	assert.Equal(t, "true", query.Get("follow"))
+	assert.Equal(t, "app", query.Get("container"))
+
+	require.Len(t, messages, 2)
+	assert.Contains(t, messages[0], `"message":"2018-11-08T17:55:45Z line 1"`)
+}
+
+func Test_parseLogLine(t *testing.T) {
+	assert.Equal(t,
+		content.LogLine{Timestamp: "2018-11-08T17:55:45Z", Message: "hello world"},
+		parseLogLine("2018-11-08T17:55:45Z hello world", true))
+	assert.Equal(t,
+		content.LogLine{Message: "hello world"},
+		parseLogLine("hello world", false))
+}
@@ -0,0 +1,376 @@
+package overview
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/twosson/kubeapt/internal/cluster"
+	"github.com/twosson/kubeapt/internal/log"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// metricsSampleInterval is how often usage is sampled. metrics-server
+	// resolves usage every minute by default, so samples which haven't
+	// changed since the last sample are skipped.
+	metricsSampleInterval = 30 * time.Second
+
+	// metricsWindow is the number of samples kept for each pod and node.
+	metricsWindow = 20
+
+	// metricsViewTTL is how long the pods in a namespace are sampled after
+	// their usage was last viewed, when usage can't be read across the
+	// cluster.
+	metricsViewTTL = 5 * time.Minute
+
+	// metricsIdleTimeout is how long usage is sampled after it was last
+	// read. An idle sampler doesn't query the metrics API until usage is
+	// read again.
+	metricsIdleTimeout = 5 * time.Minute
+)
+
+// usageSample is the usage of a pod or node at a time.
+type usageSample struct {
+	timestamp time.Time
+	usage     corev1.ResourceList
+}
+
+// metricsKey identifies a pod, or a node if the namespace is empty.
+type metricsKey struct {
+	namespace string
+	name      string
+}
+
+// usageWindows are the recent samples for each pod or node, oldest first.
+type usageWindows map[metricsKey][]usageSample
+
+// record adds a sample to the window of an object. Samples which aren't
+// newer than the last sample are skipped, and the oldest samples are
+// dropped once the window is full.
+func (w usageWindows) record(key metricsKey, sample usageSample) {
+	samples := w[key]
+	if n := len(samples); n > 0 && !sample.timestamp.After(samples[n-1].timestamp) {
+		return
+	}
+
+	samples = append(samples, sample)
+	if len(samples) > metricsWindow {
+		samples = samples[len(samples)-metricsWindow:]
+	}
+	w[key] = samples
+}
+
+// prune forgets objects which weren't in the last sample, e.g. deleted pods.
+func (w usageWindows) prune(current map[metricsKey]bool) {
+	for key := range w {
+		if !current[key] {
+			delete(w, key)
+		}
+	}
+}
+
+// metricsSampler samples the usage of every pod and node in the background.
+// Users who can't read pod usage across the cluster sample the pods in the
+// current namespace, and in the namespaces whose pods' usage was viewed
+// recently. Usage is only sampled while it is being read. A short window of
+// samples is kept in memory for charts. Pod and node usage are unavailable
+// separately, as users may be allowed to read one and not the other. A nil
+// sampler has no samples.
+type metricsSampler struct {
+	client    cluster.MetricsInterface
+	namespace func() string
+	logger    log.Logger
+	wakeCh    chan struct{}
+	now       func() time.Time
+
+	mu             sync.RWMutex
+	pods           usageWindows
+	nodes          usageWindows
+	podsAvailable  bool
+	nodesAvailable bool
+	// allNamespaces is true if the last sample read the usage of every pod.
+	allNamespaces bool
+	// viewed is when the usage of pods in each namespace was last viewed.
+	viewed map[string]time.Time
+	// used is when usage was last read.
+	used time.Time
+}
+
+func newMetricsSampler(client cluster.MetricsInterface, namespace func() string, logger log.Logger) *metricsSampler {
+	s := &metricsSampler{
+		client:    client,
+		namespace: namespace,
+		logger:    logger,
+		wakeCh:    make(chan struct{}, 1),
+		now:       time.Now,
+		pods:      usageWindows{},
+		nodes:     usageWindows{},
+		viewed:    make(map[string]time.Time),
+	}
+	s.used = s.now()
+
+	return s
+}
+
+// run samples usage until stopCh is closed. Intervals in which the sampler
+// is idle are skipped.
+func (s *metricsSampler) run(stopCh <-chan struct{}) {
+	ticker := time.NewTicker(metricsSampleInterval)
+	defer ticker.Stop()
+
+	for {
+		if !s.idle() {
+			s.sample()
+		}
+
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+		case <-s.wakeCh:
+		}
+	}
+}
+
+// wake samples usage without waiting for the next interval, e.g. after the
+// current namespace changes or a namespace is viewed.
+func (s *metricsSampler) wake() {
+	select {
+	case s.wakeCh <- struct{}{}:
+	default:
+	}
+}
+
+// idle returns true if usage hasn't been read recently.
+func (s *metricsSampler) idle() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.now().Sub(s.used) > metricsIdleTimeout
+}
+
+// use records that usage was read. An idle sampler samples usage again
+// without waiting for the next interval.
+func (s *metricsSampler) use() {
+	s.mu.Lock()
+	idle := s.now().Sub(s.used) > metricsIdleTimeout
+	s.used = s.now()
+	s.mu.Unlock()
+
+	if idle {
+		s.wake()
+	}
+}
+
+// sample records the current usage of pods and nodes.
+func (s *metricsSampler) sample() {
+	pods, allNamespaces, podErr := s.podMetrics()
+	nodes, nodeErr := s.client.NodeMetrics()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.allNamespaces = allNamespaces
+	s.podsAvailable = s.checkAvailable("pod", s.podsAvailable, podErr)
+	current := make(map[metricsKey]bool)
+	if s.podsAvailable {
+		for _, pm := range pods {
+			key := metricsKey{namespace: pm.Namespace, name: pm.Name}
+			s.pods.record(key, usageSample{timestamp: pm.Timestamp, usage: pm.Usage()})
+			current[key] = true
+		}
+	}
+	s.pods.prune(current)
+
+	s.nodesAvailable = s.checkAvailable("node", s.nodesAvailable, nodeErr)
+	current = make(map[metricsKey]bool)
+	if s.nodesAvailable {
+		for _, nm := range nodes {
+			key := metricsKey{name: nm.Name}
+			s.nodes.record(key, usageSample{timestamp: nm.Timestamp, usage: nm.Usage})
+			current[key] = true
+		}
+	}
+	s.nodes.prune(current)
+}
+
+// podMetrics returns the usage of every pod, and true. If the user can't
+// read usage across the cluster, the usage of the pods in the viewed
+// namespaces the user can read is returned instead.
+func (s *metricsSampler) podMetrics() ([]cluster.PodMetrics, bool, error) {
+	pods, err := s.client.PodMetrics("")
+	if !cluster.IsMetricsForbidden(err) {
+		return pods, err == nil, err
+	}
+
+	pods = nil
+	sampled := false
+	for _, namespace := range s.viewedNamespaces() {
+		namespacePods, namespaceErr := s.client.PodMetrics(namespace)
+		if namespaceErr != nil {
+			if cluster.IsMetricsForbidden(namespaceErr) {
+				continue
+			}
+			return nil, false, namespaceErr
+		}
+
+		sampled = true
+		pods = append(pods, namespacePods...)
+	}
+
+	if !sampled {
+		return nil, false, err
+	}
+
+	return pods, false, nil
+}
+
+// viewedNamespaces returns the current namespace and the namespaces whose
+// pods' usage was viewed recently. Namespaces which weren't viewed recently
+// are forgotten.
+func (s *metricsSampler) viewedNamespaces() []string {
+	namespaces := []string{s.namespace()}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for namespace, viewed := range s.viewed {
+		if s.now().Sub(viewed) > metricsViewTTL {
+			delete(s.viewed, namespace)
+			continue
+		}
+		namespaces = append(namespaces, namespace)
+	}
+
+	namespaces = uniqueNamespaces(namespaces)
+	sort.Strings(namespaces)
+
+	return namespaces
+}
+
+// view records that the usage of pods in a namespace was viewed. Namespaces
+// which aren't sampled yet are sampled without waiting for the next
+// interval.
+func (s *metricsSampler) view(namespace string) {
+	s.mu.Lock()
+	_, viewed := s.viewed[namespace]
+	s.viewed[namespace] = s.now()
+	allNamespaces := s.allNamespaces
+	s.mu.Unlock()
+
+	if !viewed && !allNamespaces {
+		s.wake()
+	}
+}
+
+// checkAvailable returns whether a sample succeeded. Clusters without
+// metrics-server are common, so the metrics API becoming unavailable is
+// only logged when it changes.
+func (s *metricsSampler) checkAvailable(kind string, wasAvailable bool, err error) bool {
+	switch {
+	case err == nil:
+		if !wasAvailable {
+			s.logger.Debugf("%s metrics are available", kind)
+		}
+		return true
+	case cluster.IsMetricsUnavailable(err):
+		if wasAvailable {
+			s.logger.Debugf("%s metrics are unavailable: %v", kind, err)
+		}
+	default:
+		s.logger.Errorf("sampling %s metrics: %v", kind, err)
+	}
+
+	return false
+}
+
+// podMetricsAvailable returns true if pod usage is being sampled.
+func (s *metricsSampler) podMetricsAvailable() bool {
+	if s == nil {
+		return false
+	}
+
+	s.use()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.podsAvailable
+}
+
+// nodeMetricsAvailable returns true if node usage is being sampled.
+func (s *metricsSampler) nodeMetricsAvailable() bool {
+	if s == nil {
+		return false
+	}
+
+	s.use()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.nodesAvailable
+}
+
+// podSamples returns the recent usage of a pod, oldest first.
+func (s *metricsSampler) podSamples(namespace, name string) []usageSample {
+	if s == nil {
+		return nil
+	}
+
+	s.use()
+	s.view(namespace)
+	return s.samples(s.pods, metricsKey{namespace: namespace, name: name})
+}
+
+// nodeSamples returns the recent usage of a node, oldest first.
+func (s *metricsSampler) nodeSamples(name string) []usageSample {
+	if s == nil {
+		return nil
+	}
+
+	s.use()
+	return s.samples(s.nodes, metricsKey{name: name})
+}
+
+// podUsage returns the latest usage of a pod.
+func (s *metricsSampler) podUsage(namespace, name string) (corev1.ResourceList, bool) {
+	return latestUsage(s.podSamples(namespace, name))
+}
+
+// nodeUsage returns the latest usage of a node.
+func (s *metricsSampler) nodeUsage(name string) (corev1.ResourceList, bool) {
+	return latestUsage(s.nodeSamples(name))
+}
+
+func (s *metricsSampler) samples(w usageWindows, key metricsKey) []usageSample {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]usageSample(nil), w[key]...)
+}
+
+func latestUsage(samples []usageSample) (corev1.ResourceList, bool) {
+	if len(samples) == 0 {
+		return nil, false
+	}
+
+	return samples[len(samples)-1].usage, true
+}
+
+type metricsSamplerKey struct{}
+
+// withMetrics returns a context whose views and columns show usage sampled
+// by a sampler.
+func withMetrics(ctx context.Context, sampler *metricsSampler) context.Context {
+	return context.WithValue(ctx, metricsSamplerKey{}, sampler)
+}
+
+// metricsFromContext returns the sampler for a request, or nil if usage
+// isn't sampled.
+func metricsFromContext(ctx context.Context) *metricsSampler {
+	sampler, _ := ctx.Value(metricsSamplerKey{}).(*metricsSampler)
+	return sampler
+}
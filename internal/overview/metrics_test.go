@@ -0,0 +1,177 @@
+package overview
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twosson/kubeapt/internal/cluster"
+	"github.com/twosson/kubeapt/internal/cluster/fake"
+	"github.com/twosson/kubeapt/internal/log"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var testMetricsTime = time.Date(2018, 11, 20, 10, 0, 0, 0, time.UTC)
+
+func newTestPodMetrics(namespace, name string, timestamp time.Time, cpu, memory string) cluster.PodMetrics {
+	return cluster.PodMetrics{
+		Namespace:  namespace,
+		Name:       name,
+		Timestamp:  timestamp,
+		Containers: []cluster.ContainerMetrics{{Name: "app", Usage: resourceList(cpu, memory)}},
+	}
+}
+
+// newTestMetricsSampler creates a sampler which has sampled the usage of
+// node1, and of the web and db pods in the default namespace, which is the
+// only namespace whose usage can be read.
+func newTestMetricsSampler(t *testing.T) *metricsSampler {
+	client := &fake.MetricsClient{
+		Namespaces: []string{"default"},
+		Pods: []cluster.PodMetrics{
+			newTestPodMetrics("default", "web", testMetricsTime, "250m", "512Mi"),
+			newTestPodMetrics("default", "db", testMetricsTime, "100m", "256Mi"),
+			newTestPodMetrics("other", "web", testMetricsTime, "1", "1Gi"),
+		},
+		Nodes: []cluster.NodeMetrics{
+			{Name: "node1", Timestamp: testMetricsTime, Usage: resourceList("500m", "1Gi")},
+		},
+	}
+
+	s := newMetricsSampler(client, func() string { return "default" }, log.NopLogger())
+	s.sample()
+
+	require.True(t, s.podMetricsAvailable())
+	require.True(t, s.nodeMetricsAvailable())
+
+	return s
+}
+
+func Test_metricsSampler_sample(t *testing.T) {
+	client := &fake.MetricsClient{}
+	s := newMetricsSampler(client, func() string { return AllNamespaces }, log.NopLogger())
+
+	for i := 0; i < metricsWindow+5; i++ {
+		timestamp := testMetricsTime.Add(time.Duration(i) * time.Minute)
+		client.Pods = []cluster.PodMetrics{
+			newTestPodMetrics("default", "web", timestamp, "100m", ""),
+			newTestPodMetrics("other", "db", timestamp, "200m", ""),
+		}
+		client.Nodes = []cluster.NodeMetrics{{Name: "node1", Timestamp: timestamp, Usage: resourceList("1", "")}}
+
+		s.sample()
+		// Usage which hasn't been resolved again isn't sampled twice.
+		s.sample()
+	}
+
+	samples := s.podSamples("default", "web")
+	require.Len(t, samples, metricsWindow)
+	assert.Equal(t, testMetricsTime.Add(5*time.Minute), samples[0].timestamp)
+	assert.Equal(t, testMetricsTime.Add((metricsWindow+4)*time.Minute), samples[metricsWindow-1].timestamp)
+
+	assert.Len(t, s.podSamples("other", "db"), metricsWindow)
+	assert.Len(t, s.nodeSamples("node1"), metricsWindow)
+
+	// Pods which are deleted are forgotten.
+	client.Pods = client.Pods[:1]
+	s.sample()
+	assert.Empty(t, s.podSamples("other", "db"))
+
+	usage, ok := s.podUsage("default", "web")
+	require.True(t, ok)
+	cpu := usage[corev1.ResourceCPU]
+	assert.Equal(t, "100m", cpu.String())
+}
+
+func Test_metricsSampler_sample_viewed_namespaces(t *testing.T) {
+	client := &fake.MetricsClient{
+		Pods: []cluster.PodMetrics{
+			newTestPodMetrics("default", "web", testMetricsTime, "100m", ""),
+			newTestPodMetrics("other", "db", testMetricsTime, "200m", ""),
+			newTestPodMetrics("restricted", "api", testMetricsTime, "300m", ""),
+		},
+		Namespaces: []string{"default", "other"},
+	}
+	s := newMetricsSampler(client, func() string { return "default" }, log.NopLogger())
+
+	now := testMetricsTime
+	s.now = func() time.Time { return now }
+
+	// Only the current namespace is sampled until others are viewed.
+	s.sample()
+	require.True(t, s.podMetricsAvailable())
+	assert.Len(t, s.podSamples("default", "web"), 1)
+	assert.Empty(t, s.podSamples("other", "db"))
+	assert.Empty(t, s.podSamples("restricted", "api"))
+
+	// Viewed namespaces the user can't read are skipped.
+	s.sample()
+	assert.Len(t, s.podSamples("other", "db"), 1)
+	assert.Empty(t, s.podSamples("restricted", "api"))
+	assert.True(t, s.podMetricsAvailable())
+
+	// Namespaces which haven't been viewed recently are forgotten.
+	now = now.Add(metricsViewTTL + time.Minute)
+	s.sample()
+	assert.Empty(t, s.podSamples("other", "db"))
+	assert.Len(t, s.podSamples("default", "web"), 1)
+
+	// Every namespace is sampled when usage can be read across the cluster.
+	client.Namespaces = nil
+	s.sample()
+	assert.Len(t, s.podSamples("restricted", "api"), 1)
+}
+
+func Test_metricsSampler_idle(t *testing.T) {
+	s := newMetricsSampler(&fake.MetricsClient{}, func() string { return "default" }, log.NopLogger())
+
+	now := testMetricsTime
+	s.now = func() time.Time { return now }
+	s.used = now
+
+	assert.False(t, s.idle())
+
+	now = now.Add(metricsIdleTimeout + time.Minute)
+	assert.True(t, s.idle())
+	assert.Empty(t, s.wakeCh)
+
+	// Reading usage wakes an idle sampler.
+	s.nodeSamples("node1")
+	assert.False(t, s.idle())
+	assert.Len(t, s.wakeCh, 1)
+}
+
+func Test_metricsSampler_unavailable(t *testing.T) {
+	client := &fake.MetricsClient{
+		Pods:  []cluster.PodMetrics{newTestPodMetrics("default", "web", testMetricsTime, "100m", "")},
+		Nodes: []cluster.NodeMetrics{{Name: "node1", Timestamp: testMetricsTime, Usage: resourceList("1", "")}},
+	}
+	s := newMetricsSampler(client, func() string { return "default" }, log.NopLogger())
+
+	s.sample()
+	require.True(t, s.podMetricsAvailable())
+
+	client.Err = &cluster.MetricsUnavailableError{Err: errors.New("the server could not find the requested resource")}
+	s.sample()
+
+	assert.False(t, s.podMetricsAvailable())
+	assert.False(t, s.nodeMetricsAvailable())
+	assert.Empty(t, s.podSamples("default", "web"))
+	assert.Empty(t, s.nodeSamples("node1"))
+}
+
+func Test_metricsFromContext(t *testing.T) {
+	assert.Nil(t, metricsFromContext(context.Background()))
+
+	// A missing sampler has no usage.
+	s := metricsFromContext(context.Background())
+	assert.False(t, s.podMetricsAvailable())
+	_, ok := s.podUsage("default", "web")
+	assert.False(t, ok)
+
+	sampler := newTestMetricsSampler(t)
+	assert.Equal(t, sampler, metricsFromContext(withMetrics(context.Background(), sampler)))
+}
@@ -0,0 +1,45 @@
+package overview
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/twosson/kubeapt/pkg/content"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/kubernetes/pkg/apis/core"
+)
+
+type NamespaceSummary struct{}
+
+var _ View = (*NamespaceSummary)(nil)
+
+func NewNamespaceSummary(prefix, namespace string, c clock.Clock) View {
+	return &NamespaceSummary{}
+}
+
+func (ns *NamespaceSummary) Content(ctx context.Context, object runtime.Object, c Cache) ([]content.Content, error) {
+	namespace, err := retrieveNamespace(object)
+	if err != nil {
+		return nil, err
+	}
+
+	detail, err := printNamespaceSummary(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := content.NewSummary("Details", []content.Section{detail})
+	return []content.Content{
+		&summary,
+	}, nil
+}
+
+func retrieveNamespace(object runtime.Object) (*core.Namespace, error) {
+	namespace, ok := object.(*core.Namespace)
+	if !ok {
+		return nil, errors.Errorf("expected object to be a Namespace, it was %T", object)
+	}
+
+	return namespace, nil
+}
@@ -2,11 +2,12 @@ package overview
 
 import (
 	"github.com/twosson/kubeapt/internal/apt"
+	apiextv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	"path"
 	"strings"
 )
 
-func navigationEntries(root string) (*apt.Navigation, error) {
+func navigationEntries(root string, crds []*apiextv1beta1.CustomResourceDefinition) (*apt.Navigation, error) {
 	rootPath := root
 	if !strings.HasSuffix(rootPath, "/") {
 		rootPath = rootPath + "/"
@@ -32,6 +33,10 @@ func navigationEntries(root string) (*apt.Navigation, error) {
 						Title: "Deployments",
 						Path:  path.Join(root, "workloads/deployments"),
 					},
+					{
+						Title: "Horizontal Pod Autoscalers",
+						Path:  path.Join(root, "workloads/horizontal-pod-autoscalers"),
+					},
 					{
 						Title: "Jobs",
 						Path:  path.Join(root, "workloads/jobs"),
@@ -62,6 +67,10 @@ func navigationEntries(root string) (*apt.Navigation, error) {
 						Title: "Ingresses",
 						Path:  path.Join(root, "discovery-and-load-balancing/ingresses"),
 					},
+					{
+						Title: "Network Policies",
+						Path:  path.Join(root, "discovery-and-load-balancing/network-policies"),
+					},
 					{
 						Title: "Services",
 						Path:  path.Join(root, "discovery-and-load-balancing/services"),
@@ -90,11 +99,11 @@ func navigationEntries(root string) (*apt.Navigation, error) {
 					},
 				},
 			},
-			// TODO: re-enable when there is code to support CRD
-			// {
-			// 	Title: "Custom Resources",
-			// 	Path:  path.Join(root, "custom-resources"),
-			// },
+			{
+				Title:    "Custom Resources",
+				Path:     path.Join(root, "custom-resources"),
+				Children: customResourceNavigation(root, crds),
+			},
 			{
 				Title: "RBAC",
 				Path:  path.Join(root, "rbac"),
@@ -109,6 +118,40 @@ func navigationEntries(root string) (*apt.Navigation, error) {
 					},
 				},
 			},
+			{
+				Title: "Cluster",
+				Path:  path.Join(root, "cluster"),
+				Children: []*apt.Navigation{
+					{
+						Title: "Nodes",
+						Path:  path.Join(root, "cluster/nodes"),
+					},
+					{
+						Title: "Namespaces",
+						Path:  path.Join(root, "cluster/namespaces"),
+					},
+					{
+						Title: "Persistent Volumes",
+						Path:  path.Join(root, "cluster/persistent-volumes"),
+					},
+					{
+						Title: "Storage Classes",
+						Path:  path.Join(root, "cluster/storage-classes"),
+					},
+					{
+						Title: "Cluster Roles",
+						Path:  path.Join(root, "cluster/cluster-roles"),
+					},
+					{
+						Title: "Cluster Role Bindings",
+						Path:  path.Join(root, "cluster/cluster-role-bindings"),
+					},
+					{
+						Title: "Custom Resource Definitions",
+						Path:  path.Join(root, "cluster/custom-resource-definitions"),
+					},
+				},
+			},
 			{
 				Title: "Events",
 				Path:  path.Join(root, "events"),
@@ -7,7 +7,7 @@ import (
 )
 
 func TestNavigationEntries(t *testing.T) {
-	got, err := navigationEntries("/content/overview")
+	got, err := navigationEntries("/content/overview", nil)
 	require.NoError(t, err)
 
 	assert.Equal(t, got.Title, "Overview")
@@ -0,0 +1,349 @@
+package overview
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/twosson/kubeapt/pkg/content"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/kubernetes/pkg/apis/core"
+	"k8s.io/kubernetes/pkg/apis/networking"
+	"sort"
+	"strings"
+)
+
+type NetworkPolicySummary struct{}
+
+var _ View = (*NetworkPolicySummary)(nil)
+
+func NewNetworkPolicySummary(prefix, namespace string, c clock.Clock) View {
+	return &NetworkPolicySummary{}
+}
+
+func (ns *NetworkPolicySummary) Content(ctx context.Context, object runtime.Object, c Cache) ([]content.Content, error) {
+	np, err := retrieveNetworkPolicy(object)
+	if err != nil {
+		return nil, err
+	}
+
+	detail, err := printNetworkPolicySummary(np)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := content.NewSummary("Details", []content.Section{detail})
+	return []content.Content{
+		&summary,
+	}, nil
+}
+
+type NetworkPolicyRules struct{}
+
+var _ View = (*NetworkPolicyRules)(nil)
+
+func NewNetworkPolicyRules(prefix, namespace string, c clock.Clock) View {
+	return &NetworkPolicyRules{}
+}
+
+func (nr *NetworkPolicyRules) Content(ctx context.Context, object runtime.Object, c Cache) ([]content.Content, error) {
+	np, err := retrieveNetworkPolicy(object)
+	if err != nil {
+		return nil, err
+	}
+
+	ingress := content.NewTable("Ingress Rules", ingressEmptyMessage(hasPolicyType(np, networking.PolicyTypeIngress)))
+	ingress.Columns = tableCols("From", "Ports")
+	for _, rule := range np.Spec.Ingress {
+		ingress.AddRow(content.TableRow{
+			"From":  content.NewStringText(describeNetworkPolicyPeers(rule.From)),
+			"Ports": content.NewStringText(describeNetworkPolicyPorts(rule.Ports)),
+		})
+	}
+
+	egress := content.NewTable("Egress Rules", egressEmptyMessage(hasPolicyType(np, networking.PolicyTypeEgress)))
+	egress.Columns = tableCols("To", "Ports")
+	for _, rule := range np.Spec.Egress {
+		egress.AddRow(content.TableRow{
+			"To":    content.NewStringText(describeNetworkPolicyPeers(rule.To)),
+			"Ports": content.NewStringText(describeNetworkPolicyPorts(rule.Ports)),
+		})
+	}
+
+	return []content.Content{
+		&ingress,
+		&egress,
+	}, nil
+}
+
+// PodNetworkPolicies lists the network policies which select a pod, and
+// the traffic they allow to and from it once combined. Policies are
+// additive: a pod is isolated for a direction if any policy which selects
+// it applies to that direction, and then only traffic allowed by one of
+// their rules is allowed.
+type PodNetworkPolicies struct {
+	prefix string
+}
+
+var _ View = (*PodNetworkPolicies)(nil)
+
+func NewPodNetworkPolicies(prefix, namespace string, c clock.Clock) View {
+	return &PodNetworkPolicies{prefix: prefix}
+}
+
+func (pn *PodNetworkPolicies) Content(ctx context.Context, object runtime.Object, c Cache) ([]content.Content, error) {
+	pod, err := retrievePod(object)
+	if err != nil {
+		return nil, err
+	}
+
+	policies, err := findNetworkPoliciesForPod(ctx, pod, c)
+	if err != nil {
+		return nil, err
+	}
+
+	policyTable := content.NewTable("Network Policies", "No network policies select this pod")
+	policyTable.Columns = tableCols("Name", "Pod Selector", "Policy Types")
+
+	var ingressIsolated, egressIsolated bool
+	for _, np := range policies {
+		ingressIsolated = ingressIsolated || hasPolicyType(np, networking.PolicyTypeIngress)
+		egressIsolated = egressIsolated || hasPolicyType(np, networking.PolicyTypeEgress)
+
+		policyTable.AddRow(content.TableRow{
+			"Name":         content.NewLinkText(np.Name, gvkPath(pn.prefix, "networking.k8s.io/v1", "NetworkPolicy", np.Name)),
+			"Pod Selector": content.NewStringText(describeSelector(&np.Spec.PodSelector, "<all pods>")),
+			"Policy Types": content.NewStringText(describePolicyTypes(np)),
+		})
+	}
+
+	section := content.NewSection()
+	section.AddText("Ingress", describeIsolation(ingressIsolated))
+	section.AddText("Egress", describeIsolation(egressIsolated))
+	effective := content.NewSummary("Effective Policy", []content.Section{section})
+
+	ingress := content.NewTable("Allowed Ingress", ingressEmptyMessage(ingressIsolated))
+	ingress.Columns = tableCols("Policy", "From", "Ports")
+
+	egress := content.NewTable("Allowed Egress", egressEmptyMessage(egressIsolated))
+	egress.Columns = tableCols("Policy", "To", "Ports")
+
+	for _, np := range policies {
+		policyLink := content.NewLinkText(np.Name, gvkPath(pn.prefix, "networking.k8s.io/v1", "NetworkPolicy", np.Name))
+
+		if hasPolicyType(np, networking.PolicyTypeIngress) {
+			for _, rule := range np.Spec.Ingress {
+				ingress.AddRow(content.TableRow{
+					"Policy": policyLink,
+					"From":   content.NewStringText(describeNetworkPolicyPeers(rule.From)),
+					"Ports":  content.NewStringText(describeNetworkPolicyPorts(rule.Ports)),
+				})
+			}
+		}
+
+		if hasPolicyType(np, networking.PolicyTypeEgress) {
+			for _, rule := range np.Spec.Egress {
+				egress.AddRow(content.TableRow{
+					"Policy": policyLink,
+					"To":     content.NewStringText(describeNetworkPolicyPeers(rule.To)),
+					"Ports":  content.NewStringText(describeNetworkPolicyPorts(rule.Ports)),
+				})
+			}
+		}
+	}
+
+	return []content.Content{
+		&policyTable,
+		&effective,
+		&ingress,
+		&egress,
+	}, nil
+}
+
+func describeIsolation(isolated bool) string {
+	if isolated {
+		return "Isolated"
+	}
+	return "Not isolated"
+}
+
+func ingressEmptyMessage(isolated bool) string {
+	if isolated {
+		return "All ingress traffic is denied"
+	}
+	return "All ingress traffic is allowed"
+}
+
+func egressEmptyMessage(isolated bool) string {
+	if isolated {
+		return "All egress traffic is denied"
+	}
+	return "All egress traffic is allowed"
+}
+
+// effectivePolicyTypes returns the directions a network policy applies to.
+// Policies created before policy types existed apply to ingress, and to
+// egress if they have egress rules.
+func effectivePolicyTypes(np *networking.NetworkPolicy) []networking.PolicyType {
+	if len(np.Spec.PolicyTypes) > 0 {
+		return np.Spec.PolicyTypes
+	}
+
+	policyTypes := []networking.PolicyType{networking.PolicyTypeIngress}
+	if len(np.Spec.Egress) > 0 {
+		policyTypes = append(policyTypes, networking.PolicyTypeEgress)
+	}
+	return policyTypes
+}
+
+func describePolicyTypes(np *networking.NetworkPolicy) string {
+	var policyTypes []string
+	for _, policyType := range effectivePolicyTypes(np) {
+		policyTypes = append(policyTypes, string(policyType))
+	}
+	return strings.Join(policyTypes, ", ")
+}
+
+func hasPolicyType(np *networking.NetworkPolicy, policyType networking.PolicyType) bool {
+	for _, t := range effectivePolicyTypes(np) {
+		if t == policyType {
+			return true
+		}
+	}
+	return false
+}
+
+// describeSelector describes a label selector, or returns emptyMessage if
+// it selects everything.
+func describeSelector(labelSelector *metav1.LabelSelector, emptyMessage string) string {
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return "<invalid>"
+	}
+	if selector.Empty() {
+		return emptyMessage
+	}
+	return selector.String()
+}
+
+// describeNetworkPolicyPeers describes the peers of a rule. A rule without
+// peers allows traffic from or to anywhere.
+func describeNetworkPolicyPeers(peers []networking.NetworkPolicyPeer) string {
+	if len(peers) == 0 {
+		return "<any>"
+	}
+
+	var descriptions []string
+	for _, peer := range peers {
+		descriptions = append(descriptions, describeNetworkPolicyPeer(peer))
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+func describeNetworkPolicyPeer(peer networking.NetworkPolicyPeer) string {
+	if peer.IPBlock != nil {
+		if len(peer.IPBlock.Except) > 0 {
+			return fmt.Sprintf("%s except %s", peer.IPBlock.CIDR, strings.Join(peer.IPBlock.Except, ", "))
+		}
+		return peer.IPBlock.CIDR
+	}
+
+	pods := "all pods"
+	if peer.PodSelector != nil {
+		if selector := describeSelector(peer.PodSelector, ""); selector != "" {
+			pods = fmt.Sprintf("pods %s", selector)
+		}
+	}
+
+	if peer.NamespaceSelector == nil {
+		return fmt.Sprintf("%s in this namespace", pods)
+	}
+
+	namespaces := describeSelector(peer.NamespaceSelector, "")
+	if namespaces == "" {
+		return fmt.Sprintf("%s in all namespaces", pods)
+	}
+	return fmt.Sprintf("%s in namespaces %s", pods, namespaces)
+}
+
+// describeNetworkPolicyPorts describes the ports of a rule, e.g. "80/TCP".
+// A rule without ports allows traffic on any port.
+func describeNetworkPolicyPorts(ports []networking.NetworkPolicyPort) string {
+	if len(ports) == 0 {
+		return "<any>"
+	}
+
+	var descriptions []string
+	for _, port := range ports {
+		protocol := core.ProtocolTCP
+		if port.Protocol != nil {
+			protocol = *port.Protocol
+		}
+
+		number := "<any>"
+		if port.Port != nil {
+			number = port.Port.String()
+		}
+
+		descriptions = append(descriptions, fmt.Sprintf("%s/%s", number, protocol))
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+func retrieveNetworkPolicy(object runtime.Object) (*networking.NetworkPolicy, error) {
+	np, ok := object.(*networking.NetworkPolicy)
+	if !ok {
+		return nil, errors.Errorf("expected object to be a NetworkPolicy, it was %T", object)
+	}
+
+	return np, nil
+}
+
+// findNetworkPoliciesForPod returns the network policies which select a pod,
+// sorted by name.
+func findNetworkPoliciesForPod(ctx context.Context, pod *core.Pod, c Cache) ([]*networking.NetworkPolicy, error) {
+	var results []*networking.NetworkPolicy
+	if pod == nil {
+		return nil, errors.New("nil pod")
+	}
+	if c == nil {
+		return nil, errors.New("nil cache")
+	}
+
+	key := CacheKey{
+		Namespace:  pod.Namespace,
+		APIVersion: "networking.k8s.io/v1",
+		Kind:       "NetworkPolicy",
+	}
+	ul, err := c.Retrieve(ctx, key)
+	if err != nil {
+		return nil, errors.Wrap(err, "retrieving network policies")
+	}
+	for _, u := range ul {
+		np := &networking.NetworkPolicy{}
+		err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, np)
+		if err != nil {
+			return nil, errors.Wrap(err, "converting unstructured network policy")
+		}
+		if err := copyObjectMeta(np, u); err != nil {
+			return nil, errors.Wrap(err, "copying object metadata")
+		}
+
+		// An empty pod selector selects every pod in the namespace
+		matches, err := selectorMatchesPod(&np.Spec.PodSelector, pod, true)
+		if err != nil {
+			return nil, errors.Wrapf(err, "matching pod selector for network policy: %v", np.Name)
+		}
+		if !matches {
+			continue
+		}
+		results = append(results, np)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Name < results[j].Name
+	})
+
+	return results, nil
+}
@@ -0,0 +1,231 @@
+package overview
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twosson/kubeapt/pkg/content"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/kubernetes/pkg/apis/core"
+	"k8s.io/kubernetes/pkg/apis/networking"
+	"testing"
+	"time"
+)
+
+func newTestNetworkPolicy(name string, podSelector map[string]string, spec networkingv1.NetworkPolicySpec) *networkingv1.NetworkPolicy {
+	spec.PodSelector = metav1.LabelSelector{MatchLabels: podSelector}
+
+	return &networkingv1.NetworkPolicy{
+		TypeMeta: metav1.TypeMeta{APIVersion: "networking.k8s.io/v1", Kind: "NetworkPolicy"},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+		},
+		Spec: spec,
+	}
+}
+
+func TestNetworkPolicySummary_InvalidObject(t *testing.T) {
+	assertViewInvalidObject(t, NewNetworkPolicySummary(testPrefix, "ns", clock.NewFakeClock(time.Now())))
+}
+
+func TestNetworkPolicyRules_InvalidObject(t *testing.T) {
+	assertViewInvalidObject(t, NewNetworkPolicyRules(testPrefix, "ns", clock.NewFakeClock(time.Now())))
+}
+
+func TestPodNetworkPolicies_InvalidObject(t *testing.T) {
+	assertViewInvalidObject(t, NewPodNetworkPolicies(testPrefix, "ns", clock.NewFakeClock(time.Now())))
+}
+
+func TestNetworkPolicyRules(t *testing.T) {
+	v := NewNetworkPolicyRules(testPrefix, "ns", clock.NewFakeClock(time.Now()))
+
+	port := intstr.FromInt(5432)
+	np := &networking.NetworkPolicy{
+		Spec: networking.NetworkPolicySpec{
+			Ingress: []networking.NetworkPolicyIngressRule{
+				{
+					From: []networking.NetworkPolicyPeer{
+						{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}},
+					},
+					Ports: []networking.NetworkPolicyPort{{Port: &port}},
+				},
+			},
+			PolicyTypes: []networking.PolicyType{networking.PolicyTypeIngress},
+		},
+	}
+
+	got, err := v.Content(context.Background(), np, NewMemoryCache())
+	require.NoError(t, err)
+
+	ingress := content.NewTable("Ingress Rules", "All ingress traffic is denied")
+	ingress.Columns = tableCols("From", "Ports")
+	ingress.AddRow(content.TableRow{
+		"From":  content.NewStringText("pods app=web in this namespace"),
+		"Ports": content.NewStringText("5432/TCP"),
+	})
+
+	egress := content.NewTable("Egress Rules", "All egress traffic is allowed")
+	egress.Columns = tableCols("To", "Ports")
+
+	assert.Equal(t, []content.Content{&ingress, &egress}, got)
+}
+
+func TestPodNetworkPolicies(t *testing.T) {
+	v := NewPodNetworkPolicies(testPrefix, "ns", clock.NewFakeClock(time.Now()))
+
+	pod := &core.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "db",
+			Labels:    map[string]string{"app": "db"},
+		},
+	}
+
+	cache := NewMemoryCache()
+
+	got, err := v.Content(context.Background(), pod, cache)
+	require.NoError(t, err)
+	require.Len(t, got, 4)
+
+	// Pods which aren't selected by any policies aren't isolated.
+	assert.True(t, got[0].IsEmpty())
+	effective, ok := got[1].(*content.Summary)
+	require.True(t, ok)
+	assert.Equal(t, []content.Item{
+		content.TextItem("Ingress", "Not isolated"),
+		content.TextItem("Egress", "Not isolated"),
+	}, effective.Sections[0].Items)
+
+	tcp := corev1.ProtocolTCP
+	port := intstr.FromInt(5432)
+	storeObject(t, cache, newTestNetworkPolicy("allow-web", map[string]string{"app": "db"}, networkingv1.NetworkPolicySpec{
+		Ingress: []networkingv1.NetworkPolicyIngressRule{
+			{
+				From: []networkingv1.NetworkPolicyPeer{
+					{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}},
+				},
+				Ports: []networkingv1.NetworkPolicyPort{{Protocol: &tcp, Port: &port}},
+			},
+		},
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+	}))
+	storeObject(t, cache, newTestNetworkPolicy("deny-all", nil, networkingv1.NetworkPolicySpec{
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+	}))
+	storeObject(t, cache, newTestNetworkPolicy("api", map[string]string{"app": "api"}, networkingv1.NetworkPolicySpec{
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+	}))
+
+	got, err = v.Content(context.Background(), pod, cache)
+	require.NoError(t, err)
+
+	policies := content.NewTable("Network Policies", "No network policies select this pod")
+	policies.Columns = tableCols("Name", "Pod Selector", "Policy Types")
+	policies.AddRow(content.TableRow{
+		"Name":         content.NewLinkText("allow-web", "/content/overview/discovery-and-load-balancing/network-policies/allow-web"),
+		"Pod Selector": content.NewStringText("app=db"),
+		"Policy Types": content.NewStringText("Ingress"),
+	})
+	policies.AddRow(content.TableRow{
+		"Name":         content.NewLinkText("deny-all", "/content/overview/discovery-and-load-balancing/network-policies/deny-all"),
+		"Pod Selector": content.NewStringText("<all pods>"),
+		"Policy Types": content.NewStringText("Ingress, Egress"),
+	})
+
+	section := content.NewSection()
+	section.AddText("Ingress", "Isolated")
+	section.AddText("Egress", "Isolated")
+	effectiveSummary := content.NewSummary("Effective Policy", []content.Section{section})
+
+	ingress := content.NewTable("Allowed Ingress", "All ingress traffic is denied")
+	ingress.Columns = tableCols("Policy", "From", "Ports")
+	ingress.AddRow(content.TableRow{
+		"Policy": content.NewLinkText("allow-web", "/content/overview/discovery-and-load-balancing/network-policies/allow-web"),
+		"From":   content.NewStringText("pods app=web in this namespace"),
+		"Ports":  content.NewStringText("5432/TCP"),
+	})
+
+	egress := content.NewTable("Allowed Egress", "All egress traffic is denied")
+	egress.Columns = tableCols("Policy", "To", "Ports")
+
+	expected := []content.Content{
+		&policies,
+		&effectiveSummary,
+		&ingress,
+		&egress,
+	}
+
+	assert.Equal(t, expected, got)
+}
+
+func Test_effectivePolicyTypes(t *testing.T) {
+	np := &networking.NetworkPolicy{}
+	assert.Equal(t, []networking.PolicyType{networking.PolicyTypeIngress}, effectivePolicyTypes(np))
+
+	np.Spec.Egress = []networking.NetworkPolicyEgressRule{{}}
+	assert.Equal(t,
+		[]networking.PolicyType{networking.PolicyTypeIngress, networking.PolicyTypeEgress},
+		effectivePolicyTypes(np))
+
+	np.Spec.PolicyTypes = []networking.PolicyType{networking.PolicyTypeEgress}
+	assert.Equal(t, []networking.PolicyType{networking.PolicyTypeEgress}, effectivePolicyTypes(np))
+}
+
+func Test_describeNetworkPolicyPeer(t *testing.T) {
+	cases := []struct {
+		name     string
+		peer     networking.NetworkPolicyPeer
+		expected string
+	}{
+		{
+			name:     "pods in this namespace",
+			peer:     networking.NetworkPolicyPeer{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}},
+			expected: "pods app=web in this namespace",
+		},
+		{
+			name:     "all pods in this namespace",
+			peer:     networking.NetworkPolicyPeer{PodSelector: &metav1.LabelSelector{}},
+			expected: "all pods in this namespace",
+		},
+		{
+			name:     "namespaces",
+			peer:     networking.NetworkPolicyPeer{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}},
+			expected: "all pods in namespaces team=a",
+		},
+		{
+			name: "pods in all namespaces",
+			peer: networking.NetworkPolicyPeer{
+				PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+				NamespaceSelector: &metav1.LabelSelector{},
+			},
+			expected: "pods app=web in all namespaces",
+		},
+		{
+			name:     "ip block",
+			peer:     networking.NetworkPolicyPeer{IPBlock: &networking.IPBlock{CIDR: "10.0.0.0/8", Except: []string{"10.1.0.0/16"}}},
+			expected: "10.0.0.0/8 except 10.1.0.0/16",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, describeNetworkPolicyPeer(tc.peer))
+		})
+	}
+}
+
+func Test_describeNetworkPolicyPorts(t *testing.T) {
+	udp := core.ProtocolUDP
+	port := intstr.FromString("dns")
+
+	assert.Equal(t, "<any>", describeNetworkPolicyPorts(nil))
+	assert.Equal(t, "dns/UDP, <any>/TCP", describeNetworkPolicyPorts([]networking.NetworkPolicyPort{
+		{Protocol: &udp, Port: &port},
+		{},
+	}))
+}
@@ -0,0 +1,334 @@
+package overview
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/twosson/kubeapt/pkg/content"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/kubernetes/pkg/apis/core"
+)
+
+// nodeColumns add the resources available to pods, and their usage, to the
+// node list.
+var nodeColumns = []ResourceColumn{
+	{Name: "CPU Allocatable", Value: nodeAllocatable(core.ResourceCPU)},
+	{Name: "Memory Allocatable", Value: nodeAllocatable(core.ResourceMemory)},
+	{Name: "CPU Usage", Value: nodeUsage(core.ResourceCPU), Available: nodeMetricsAvailable},
+	{Name: "Memory Usage", Value: nodeUsage(core.ResourceMemory), Available: nodeMetricsAvailable},
+}
+
+// nodeAllocatable returns the amount of a resource on a node which is
+// available to pods.
+func nodeAllocatable(name core.ResourceName) func(context.Context, runtime.Object, Cache) content.Text {
+	return func(ctx context.Context, object runtime.Object, c Cache) content.Text {
+		node, ok := object.(*core.Node)
+		if !ok {
+			return content.NewStringText("<unknown>")
+		}
+
+		quantity, ok := node.Status.Allocatable[name]
+		if !ok {
+			return content.NewStringText("<none>")
+		}
+
+		return content.NewStringText(quantity.String())
+	}
+}
+
+type NodeSummary struct{}
+
+var _ View = (*NodeSummary)(nil)
+
+func NewNodeSummary(prefix, namespace string, c clock.Clock) View {
+	return &NodeSummary{}
+}
+
+func (ns *NodeSummary) Content(ctx context.Context, object runtime.Object, c Cache) ([]content.Content, error) {
+	node, err := retrieveNode(object)
+	if err != nil {
+		return nil, err
+	}
+
+	detail, err := printNodeSummary(node)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := content.NewSummary("Details", []content.Section{detail})
+	return []content.Content{
+		&summary,
+	}, nil
+}
+
+type NodeCondition struct{}
+
+var _ View = (*NodeCondition)(nil)
+
+func NewNodeCondition(prefix, namespace string, c clock.Clock) View {
+	return &NodeCondition{}
+}
+
+func (nc *NodeCondition) Content(ctx context.Context, object runtime.Object, c Cache) ([]content.Content, error) {
+	node, err := retrieveNode(object)
+	if err != nil {
+		return nil, err
+	}
+
+	table := content.NewTable("Conditions", "No conditions")
+	table.Columns = []content.TableColumn{
+		tableCol("Type"),
+		tableCol("Status"),
+		tableCol("Last heartbeat time"),
+		tableCol("Last transition time"),
+		tableCol("Reason"),
+		tableCol("Message"),
+	}
+
+	for _, condition := range node.Status.Conditions {
+		lastHeartbeatTime := condition.LastHeartbeatTime.UTC().Format(time.RFC3339)
+		lastTransitionTime := condition.LastTransitionTime.UTC().Format(time.RFC3339)
+
+		row := content.TableRow{
+			"Type":                 content.NewStringText(string(condition.Type)),
+			"Status":               content.NewStringText(string(condition.Status)),
+			"Last heartbeat time":  content.NewTimeText(lastHeartbeatTime),
+			"Last transition time": content.NewTimeText(lastTransitionTime),
+			"Reason":               content.NewStringText(condition.Reason),
+			"Message":              content.NewStringText(condition.Message),
+		}
+
+		table.AddRow(row)
+	}
+
+	return []content.Content{&table}, nil
+}
+
+type NodeSystemInfo struct{}
+
+var _ View = (*NodeSystemInfo)(nil)
+
+func NewNodeSystemInfo(prefix, namespace string, c clock.Clock) View {
+	return &NodeSystemInfo{}
+}
+
+func (ns *NodeSystemInfo) Content(ctx context.Context, object runtime.Object, c Cache) ([]content.Content, error) {
+	node, err := retrieveNode(object)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := printNodeSystemInfo(node)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := content.NewSummary("System Info", []content.Section{info})
+	return []content.Content{
+		&summary,
+	}, nil
+}
+
+// nodeResources are the resources shown in a node's allocated resources.
+var nodeResources = []core.ResourceName{
+	core.ResourceCPU,
+	core.ResourceMemory,
+	core.ResourceEphemeralStorage,
+}
+
+// NodeResources shows the resources requested by pods scheduled on a node,
+// compared with what the node can allocate.
+type NodeResources struct{}
+
+var _ View = (*NodeResources)(nil)
+
+func NewNodeResources(prefix, namespace string, c clock.Clock) View {
+	return &NodeResources{}
+}
+
+func (nr *NodeResources) Content(ctx context.Context, object runtime.Object, c Cache) ([]content.Content, error) {
+	node, err := retrieveNode(object)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := listNodePods(ctx, node, c)
+	if err != nil {
+		return nil, err
+	}
+
+	requests, limits := core.ResourceList{}, core.ResourceList{}
+	for _, pod := range pods {
+		// Pods which have finished don't use the node's resources.
+		if pod.Status.Phase == core.PodSucceeded || pod.Status.Phase == core.PodFailed {
+			continue
+		}
+
+		podRequests, podLimits := podRequestsAndLimits(pod)
+		addResourceList(requests, podRequests)
+		addResourceList(limits, podLimits)
+	}
+
+	table := content.NewTable("Allocated Resources", "No resources are allocatable")
+	table.Columns = []content.TableColumn{
+		tableCol("Resource"),
+		tableCol("Requests"),
+		tableCol("Limits"),
+		tableCol("Allocatable"),
+		tableCol("Capacity"),
+	}
+
+	for _, name := range nodeResources {
+		allocatable, ok := node.Status.Allocatable[name]
+		if !ok {
+			continue
+		}
+
+		capacity := node.Status.Capacity[name]
+
+		table.AddRow(content.TableRow{
+			"Resource":    content.NewStringText(string(name)),
+			"Requests":    content.NewStringText(formatAllocation(requests[name], allocatable)),
+			"Limits":      content.NewStringText(formatAllocation(limits[name], allocatable)),
+			"Allocatable": content.NewStringText(allocatable.String()),
+			"Capacity":    content.NewStringText(capacity.String()),
+		})
+	}
+
+	return []content.Content{&table}, nil
+}
+
+// formatAllocation formats an amount of a resource with the percentage of
+// the allocatable amount it uses.
+func formatAllocation(amount, allocatable resource.Quantity) string {
+	percent := 0.0
+	if allocatable.MilliValue() > 0 {
+		percent = float64(amount.MilliValue()) / float64(allocatable.MilliValue()) * 100
+	}
+
+	return fmt.Sprintf("%s (%d%%)", amount.String(), int64(percent))
+}
+
+// podRequestsAndLimits sums the requests and limits of a pod's containers.
+// Init containers run one at a time before the other containers start, so
+// a pod needs the larger of any init container and the sum of the other
+// containers.
+func podRequestsAndLimits(pod *core.Pod) (core.ResourceList, core.ResourceList) {
+	requests, limits := core.ResourceList{}, core.ResourceList{}
+
+	for _, container := range pod.Spec.Containers {
+		addResourceList(requests, container.Resources.Requests)
+		addResourceList(limits, container.Resources.Limits)
+	}
+
+	for _, container := range pod.Spec.InitContainers {
+		maxResourceList(requests, container.Resources.Requests)
+		maxResourceList(limits, container.Resources.Limits)
+	}
+
+	return requests, limits
+}
+
+// addResourceList adds the quantities in another list to a list.
+func addResourceList(list, other core.ResourceList) {
+	for name, quantity := range other {
+		value, ok := list[name]
+		if !ok {
+			list[name] = quantity.DeepCopy()
+			continue
+		}
+
+		value.Add(quantity)
+		list[name] = value
+	}
+}
+
+// maxResourceList sets quantities in a list to the quantities in another
+// list which are larger.
+func maxResourceList(list, other core.ResourceList) {
+	for name, quantity := range other {
+		value, ok := list[name]
+		if !ok || quantity.Cmp(value) > 0 {
+			list[name] = quantity.DeepCopy()
+		}
+	}
+}
+
+// NodePods lists the pods scheduled on a node.
+type NodePods struct {
+	prefix string
+}
+
+var _ View = (*NodePods)(nil)
+
+func NewNodePods(prefix, namespace string, c clock.Clock) View {
+	return &NodePods{prefix: prefix}
+}
+
+func (np *NodePods) Content(ctx context.Context, object runtime.Object, c Cache) ([]content.Content, error) {
+	node, err := retrieveNode(object)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := listNodePods(ctx, node, c)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &core.PodList{}
+	for _, pod := range pods {
+		list.Items = append(list.Items, *pod)
+	}
+
+	var contents []content.Content
+
+	// Pods on a node are in every namespace, so they are linked in their
+	// own namespace.
+	otf := summaryFunc("Pods", "No pods are scheduled on this node", podTransforms)
+	if err := printObject(list, true, otf(AllNamespaces, np.prefix, &contents)); err != nil {
+		return nil, errors.Wrap(err, "unable to print pods")
+	}
+
+	return contents, nil
+}
+
+// listNodePods lists the pods in every namespace which are scheduled on a
+// node, ordered by namespace and name.
+func listNodePods(ctx context.Context, node *core.Node, c Cache) ([]*core.Pod, error) {
+	key := CacheKey{
+		Namespace:     AllNamespaces,
+		APIVersion:    "v1",
+		Kind:          "Pod",
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", node.GetName()).String(),
+	}
+
+	pods, err := loadPods(ctx, key, c, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(pods, func(i, j int) bool {
+		if pods[i].Namespace != pods[j].Namespace {
+			return pods[i].Namespace < pods[j].Namespace
+		}
+		return pods[i].Name < pods[j].Name
+	})
+
+	return pods, nil
+}
+
+func retrieveNode(object runtime.Object) (*core.Node, error) {
+	node, ok := object.(*core.Node)
+	if !ok {
+		return nil, errors.Errorf("expected object to be a Node, it was %T", object)
+	}
+
+	return node, nil
+}
@@ -0,0 +1,238 @@
+package overview
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twosson/kubeapt/pkg/content"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/kubernetes/pkg/apis/core"
+)
+
+func newTestNode() *core.Node {
+	return &core.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+		Status: core.NodeStatus{
+			Capacity: core.ResourceList{
+				core.ResourceCPU:    resource.MustParse("4"),
+				core.ResourceMemory: resource.MustParse("8Gi"),
+			},
+			Allocatable: core.ResourceList{
+				core.ResourceCPU:    resource.MustParse("2"),
+				core.ResourceMemory: resource.MustParse("4Gi"),
+			},
+		},
+	}
+}
+
+func resourceList(cpu, memory string) corev1.ResourceList {
+	list := corev1.ResourceList{}
+	if cpu != "" {
+		list[corev1.ResourceCPU] = resource.MustParse(cpu)
+	}
+	if memory != "" {
+		list[corev1.ResourceMemory] = resource.MustParse(memory)
+	}
+	return list
+}
+
+func storeNodePod(t *testing.T, c Cache, namespace, name, nodeName string, phase corev1.PodPhase, containers ...corev1.Container) {
+	pod := &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         namespace,
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(time.Now().AddDate(0, 0, -1)),
+		},
+		Spec: corev1.PodSpec{
+			NodeName:   nodeName,
+			Containers: containers,
+		},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+
+	m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(pod)
+	require.NoError(t, err)
+
+	require.NoError(t, c.Store(&unstructured.Unstructured{Object: m}))
+}
+
+func TestNodeResources_InvalidObject(t *testing.T) {
+	assertViewInvalidObject(t, NewNodeResources(testPrefix, "ns", clock.NewFakeClock(time.Now())))
+}
+
+func TestNodeResources(t *testing.T) {
+	cache := NewMemoryCache()
+
+	storeNodePod(t, cache, "default", "web", "node1", corev1.PodRunning,
+		corev1.Container{Resources: corev1.ResourceRequirements{Requests: resourceList("500m", "1Gi"), Limits: resourceList("1", "2Gi")}},
+		corev1.Container{Resources: corev1.ResourceRequirements{Requests: resourceList("250m", "")}},
+	)
+	storeNodePod(t, cache, "other", "db", "node1", corev1.PodPending,
+		corev1.Container{Resources: corev1.ResourceRequirements{Requests: resourceList("250m", "1Gi")}},
+	)
+	storeNodePod(t, cache, "default", "done", "node1", corev1.PodSucceeded,
+		corev1.Container{Resources: corev1.ResourceRequirements{Requests: resourceList("1", "1Gi")}},
+	)
+	storeNodePod(t, cache, "default", "elsewhere", "node2", corev1.PodRunning,
+		corev1.Container{Resources: corev1.ResourceRequirements{Requests: resourceList("1", "1Gi")}},
+	)
+
+	v := NewNodeResources(testPrefix, "ns", clock.NewFakeClock(time.Now()))
+
+	contents, err := v.Content(context.Background(), newTestNode(), cache)
+	require.NoError(t, err)
+
+	expected := content.NewTable("Allocated Resources", "No resources are allocatable")
+	expected.Columns = []content.TableColumn{
+		tableCol("Resource"),
+		tableCol("Requests"),
+		tableCol("Limits"),
+		tableCol("Allocatable"),
+		tableCol("Capacity"),
+	}
+	expected.AddRow(content.TableRow{
+		"Resource":    content.NewStringText("cpu"),
+		"Requests":    content.NewStringText("1 (50%)"),
+		"Limits":      content.NewStringText("1 (50%)"),
+		"Allocatable": content.NewStringText("2"),
+		"Capacity":    content.NewStringText("4"),
+	})
+	expected.AddRow(content.TableRow{
+		"Resource":    content.NewStringText("memory"),
+		"Requests":    content.NewStringText("2Gi (50%)"),
+		"Limits":      content.NewStringText("2Gi (50%)"),
+		"Allocatable": content.NewStringText("4Gi"),
+		"Capacity":    content.NewStringText("8Gi"),
+	})
+
+	assert.Equal(t, []content.Content{&expected}, contents)
+}
+
+func Test_podRequestsAndLimits(t *testing.T) {
+	pod := &core.Pod{
+		Spec: core.PodSpec{
+			InitContainers: []core.Container{
+				{Resources: core.ResourceRequirements{Requests: core.ResourceList{core.ResourceCPU: resource.MustParse("2")}}},
+				{Resources: core.ResourceRequirements{Requests: core.ResourceList{core.ResourceMemory: resource.MustParse("64Mi")}}},
+			},
+			Containers: []core.Container{
+				{Resources: core.ResourceRequirements{Requests: core.ResourceList{
+					core.ResourceCPU:    resource.MustParse("500m"),
+					core.ResourceMemory: resource.MustParse("128Mi"),
+				}}},
+				{Resources: core.ResourceRequirements{
+					Requests: core.ResourceList{core.ResourceCPU: resource.MustParse("500m")},
+					Limits:   core.ResourceList{core.ResourceCPU: resource.MustParse("1")},
+				}},
+			},
+		},
+	}
+
+	requests, limits := podRequestsAndLimits(pod)
+
+	cpu := requests[core.ResourceCPU]
+	memory := requests[core.ResourceMemory]
+	cpuLimit := limits[core.ResourceCPU]
+
+	assert.Equal(t, "2", cpu.String())
+	assert.Equal(t, "128Mi", memory.String())
+	assert.Equal(t, "1", cpuLimit.String())
+}
+
+func TestNodePods(t *testing.T) {
+	cache := NewMemoryCache()
+
+	storeNodePod(t, cache, "other", "db", "node1", corev1.PodRunning)
+	storeNodePod(t, cache, "default", "web", "node1", corev1.PodRunning)
+	storeNodePod(t, cache, "default", "elsewhere", "node2", corev1.PodRunning)
+
+	v := NewNodePods(testPrefix, "ns", clock.NewFakeClock(time.Now()))
+
+	contents, err := v.Content(context.Background(), newTestNode(), cache)
+	require.NoError(t, err)
+	require.Len(t, contents, 1)
+
+	tbl, ok := contents[0].(*content.Table)
+	require.True(t, ok)
+
+	var links []content.Text
+	for _, row := range tbl.Rows {
+		links = append(links, row["Name"])
+	}
+
+	assert.Equal(t, []content.Text{
+		content.NewLinkText("web", "/content/overview/workloads/pods/web?namespace=default"),
+		content.NewLinkText("db", "/content/overview/workloads/pods/db?namespace=other"),
+	}, links)
+}
+
+func TestNodeSystemInfo(t *testing.T) {
+	node := newTestNode()
+	node.Status.NodeInfo = core.NodeSystemInfo{
+		KernelVersion:  "4.15.0",
+		OSImage:        "Ubuntu 18.04",
+		KubeletVersion: "v1.11.3",
+	}
+
+	v := NewNodeSystemInfo(testPrefix, "ns", clock.NewFakeClock(time.Now()))
+
+	contents, err := v.Content(context.Background(), node, NewMemoryCache())
+	require.NoError(t, err)
+	require.Len(t, contents, 1)
+
+	summary, ok := contents[0].(*content.Summary)
+	require.True(t, ok)
+	assert.Equal(t, "System Info", summary.Title)
+}
+
+func Test_withResourceColumns_nodes(t *testing.T) {
+	node := newTestNode()
+	node.CreationTimestamp = metav1.NewTime(time.Now().AddDate(0, 0, -1))
+	list := &core.NodeList{Items: []core.Node{*node}}
+
+	cases := []struct {
+		name     string
+		ctx      context.Context
+		expected []string
+	}{
+		{
+			name:     "without metrics",
+			ctx:      context.Background(),
+			expected: []string{"CPU Allocatable", "Memory Allocatable", "Labels"},
+		},
+		{
+			name:     "with metrics",
+			ctx:      withMetrics(context.Background(), newTestMetricsSampler(t)),
+			expected: []string{"CPU Allocatable", "Memory Allocatable", "CPU Usage", "Memory Usage", "Labels"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var contents []content.Content
+			otf := withResourceColumns(tc.ctx, NewMemoryCache(), summaryFunc("Nodes", "No nodes", nodeTransforms), nodeColumns)
+			require.NoError(t, printObject(list, false, otf("", testPrefix, &contents)))
+			require.Len(t, contents, 1)
+
+			tbl, ok := contents[0].(*content.Table)
+			require.True(t, ok)
+
+			names := tbl.ColumnNames()
+			require.True(t, len(names) >= len(tc.expected))
+			assert.Equal(t, tc.expected, names[len(names)-len(tc.expected):])
+
+			require.Len(t, tbl.Rows, 1)
+			assert.Equal(t, content.NewStringText("2"), tbl.Rows[0]["CPU Allocatable"])
+			assert.Equal(t, content.NewStringText("4Gi"), tbl.Rows[0]["Memory Allocatable"])
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package overview
+
+import (
+	"context"
+	"sync"
+)
+
+// notificationFilter reports whether a subscriber is interested in a
+// cache notification.
+type notificationFilter func(CacheNotification) bool
+
+type subscription struct {
+	filter notificationFilter
+	ch     chan struct{}
+}
+
+// cacheNotifier fans out cache notifications to subscribers. Subscribers
+// receive a signal rather than the notification itself. Signals are
+// coalesced, so a slow subscriber sees at most one pending signal no matter
+// how many notifications arrived in the meantime.
+type cacheNotifier struct {
+	mu            sync.Mutex
+	subscriptions map[*subscription]bool
+}
+
+func newCacheNotifier() *cacheNotifier {
+	return &cacheNotifier{
+		subscriptions: make(map[*subscription]bool),
+	}
+}
+
+// Subscribe returns a channel which is signaled when a notification matching
+// filter is received. A nil filter matches every notification. The
+// subscription is removed when ctx is done.
+func (n *cacheNotifier) Subscribe(ctx context.Context, filter notificationFilter) <-chan struct{} {
+	s := &subscription{
+		filter: filter,
+		ch:     make(chan struct{}, 1),
+	}
+
+	n.mu.Lock()
+	n.subscriptions[s] = true
+	n.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		n.mu.Lock()
+		delete(n.subscriptions, s)
+		n.mu.Unlock()
+	}()
+
+	return s.ch
+}
+
+// Notify signals subscribers interested in a notification.
+func (n *cacheNotifier) Notify(notification CacheNotification) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for s := range n.subscriptions {
+		if s.filter != nil && !s.filter(notification) {
+			continue
+		}
+
+		select {
+		case s.ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// namespaceFilter matches notifications for objects in a namespace. Cluster
+// scoped objects always match. An empty namespace is treated as the default
+// namespace, which is how the cache treats it. AllNamespaces matches every
+// notification.
+func namespaceFilter(namespace string) notificationFilter {
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	return func(notification CacheNotification) bool {
+		ns := notification.CacheKey.Namespace
+		return namespace == AllNamespaces || ns == "" || ns == namespace
+	}
+}
+
+// namespaceNotifier fans out namespace changes to subscribers. A slow
+// subscriber only sees the latest namespace.
+type namespaceNotifier struct {
+	mu            sync.Mutex
+	subscriptions map[chan string]bool
+}
+
+func newNamespaceNotifier() *namespaceNotifier {
+	return &namespaceNotifier{
+		subscriptions: make(map[chan string]bool),
+	}
+}
+
+// Subscribe returns a channel which receives the new namespace when the
+// namespace changes. The subscription is removed when ctx is done.
+func (n *namespaceNotifier) Subscribe(ctx context.Context) <-chan string {
+	ch := make(chan string, 1)
+
+	n.mu.Lock()
+	n.subscriptions[ch] = true
+	n.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		n.mu.Lock()
+		delete(n.subscriptions, ch)
+		n.mu.Unlock()
+	}()
+
+	return ch
+}
+
+// Notify sends a namespace to subscribers. A namespace which hasn't been
+// received yet is replaced.
+func (n *namespaceNotifier) Notify(namespace string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for ch := range n.subscriptions {
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- namespace
+	}
+}
@@ -0,0 +1,135 @@
+package overview
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_cacheNotifier(t *testing.T) {
+	n := newCacheNotifier()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch := n.Subscribe(ctx, namespaceFilter("default"))
+
+	n.Notify(CacheNotification{CacheKey: CacheKey{Namespace: "other", APIVersion: "v1", Kind: "Pod"}})
+	select {
+	case <-ch:
+		t.Fatal("unexpected signal for notification in other namespace")
+	default:
+	}
+
+	// Notifications are coalesced into a single signal.
+	n.Notify(CacheNotification{CacheKey: CacheKey{Namespace: "default", APIVersion: "v1", Kind: "Pod"}})
+	n.Notify(CacheNotification{CacheKey: CacheKey{APIVersion: "v1", Kind: "Node"}})
+
+	select {
+	case <-ch:
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for signal")
+	}
+
+	select {
+	case <-ch:
+		t.Fatal("expected signals to be coalesced")
+	default:
+	}
+}
+
+func Test_cacheNotifier_unsubscribe(t *testing.T) {
+	n := newCacheNotifier()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	n.Subscribe(ctx, nil)
+	cancel()
+
+	subscriptionCount := func() int {
+		n.mu.Lock()
+		defer n.mu.Unlock()
+		return len(n.subscriptions)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for subscriptionCount() > 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	assert.Equal(t, 0, subscriptionCount())
+}
+
+func Test_namespaceFilter(t *testing.T) {
+	cases := []struct {
+		name      string
+		namespace string
+		key       CacheKey
+		expected  bool
+	}{
+		{
+			name:      "same namespace",
+			namespace: "default",
+			key:       CacheKey{Namespace: "default"},
+			expected:  true,
+		},
+		{
+			name:      "other namespace",
+			namespace: "default",
+			key:       CacheKey{Namespace: "other"},
+			expected:  false,
+		},
+		{
+			name:      "cluster scoped",
+			namespace: "default",
+			key:       CacheKey{},
+			expected:  true,
+		},
+		{
+			name:      "empty namespace is default",
+			namespace: "",
+			key:       CacheKey{Namespace: "default"},
+			expected:  true,
+		},
+		{
+			name:      "all namespaces",
+			namespace: AllNamespaces,
+			key:       CacheKey{Namespace: "other"},
+			expected:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			filter := namespaceFilter(tc.namespace)
+			assert.Equal(t, tc.expected, filter(CacheNotification{CacheKey: tc.key}))
+		})
+	}
+}
+
+func Test_namespaceNotifier(t *testing.T) {
+	n := newNamespaceNotifier()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch := n.Subscribe(ctx)
+
+	// Only the latest namespace is kept for a slow subscriber.
+	n.Notify("first")
+	n.Notify("second")
+
+	select {
+	case namespace := <-ch:
+		assert.Equal(t, "second", namespace)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for namespace")
+	}
+
+	select {
+	case namespace := <-ch:
+		t.Fatalf("unexpected namespace %q", namespace)
+	default:
+	}
+}
@@ -1,16 +1,25 @@
 package overview
 
 import (
+	"context"
 	"fmt"
-	"github.com/davecgh/go-spew/spew"
 	"github.com/pkg/errors"
 	"github.com/twosson/kubeapt/internal/apt"
 	"github.com/twosson/kubeapt/internal/cluster"
 	"github.com/twosson/kubeapt/internal/log"
-	"k8s.io/client-go/restmapper"
 	"net/http"
 	"os"
+	"reflect"
 	"sync"
+	"time"
+)
+
+const (
+	// restMapperResetDelay is how long the REST mapper waits after a custom
+	// resource definition changes before it is reset. Definitions are often
+	// created together, and changes received during this window are
+	// coalesced.
+	restMapperResetDelay = time.Second
 )
 
 // ClusterOverview is an API for generating a cluster overview.
@@ -23,30 +32,20 @@ type ClusterOverview struct {
 
 	logger log.Logger
 
-	cache  Cache
-	stopCh chan struct{}
+	cache      Cache
+	access     cluster.AccessInterface
+	restMapper *discoveryRESTMapper
+	notifier   *cacheNotifier
+	namespaces *namespaceNotifier
+	searcher   *searcher
+	metrics    *metricsSampler
+	stopCh     chan struct{}
 
 	generator *realGenerator
 }
 
 // NewClusterOverview creates an instance of ClusterOverview.
 func NewClusterOverview(client cluster.ClientInterface, namespace string, logger log.Logger) (*ClusterOverview, error) {
-	stopCh := make(chan struct{})
-
-	var opts []InformerCacheOpt
-
-	if os.Getenv("DASH_VERBOSE_CACHE") != "" {
-		ch := make(chan CacheNotification)
-
-		go func() {
-			for notif := range ch {
-				spew.Dump(notif)
-			}
-		}()
-
-		opts = append(opts, InformerCacheNotificationOpt(ch, stopCh))
-	}
-
 	if client == nil {
 		return nil, errors.New("nil cluster client")
 	}
@@ -60,14 +59,47 @@ func NewClusterOverview(client cluster.ClientInterface, namespace string, logger
 		return nil, errors.Wrapf(err, "creating DiscoveryClient")
 	}
 
-	groupResources, err := restmapper.GetAPIGroupResources(di)
+	rm, err := newDiscoveryRESTMapper(di)
 	if err != nil {
 		logger.Errorf("discovering APIGroupResources: %v", err)
-		return nil, errors.Wrapf(err, "mapping APIGroupResources")
+		return nil, err
+	}
+
+	access, err := client.AccessClient()
+	if err != nil {
+		return nil, errors.Wrapf(err, "creating AccessClient")
+	}
+
+	metricsClient, err := client.MetricsClient()
+	if err != nil {
+		return nil, errors.Wrapf(err, "creating MetricsClient")
+	}
+
+	stopCh := make(chan struct{})
+	notifyCh := make(chan CacheNotification)
+
+	notifier := newCacheNotifier()
+	index := newSearchIndex()
+
+	resetCh := make(chan struct{}, 1)
+
+	go handleCacheNotifications(notifyCh, resetCh, notifier, index, logger)
+	go resetRESTMapper(resetCh, stopCh, rm.Reset, restMapperResetDelay, logger)
+
+	// co is set once it has been created. Users who can't list namespaces
+	// see objects in every namespace from the current and configured
+	// namespaces.
+	var co *ClusterOverview
+	fallbackNamespaces := func() []string {
+		return []string{co.currentNamespace(), namespace}
 	}
-	rm := restmapper.NewDiscoveryRESTMapper(groupResources)
 
-	opts = append(opts, InformerCacheLoggerOpt(logger))
+	opts := []InformerCacheOpt{
+		InformerCacheNotificationOpt(notifyCh, stopCh),
+		InformerCacheLoggerOpt(logger),
+		InformerCacheAccessOpt(access),
+		InformerCacheNamespacesOpt(fallbackNamespaces),
+	}
 	cache := NewInformerCache(stopCh, dynamicClient, rm, opts...)
 
 	var pathFilters []pathFilter
@@ -76,14 +108,24 @@ func NewClusterOverview(client cluster.ClientInterface, namespace string, logger
 
 	g := newGenerator(cache, pathFilters, client)
 
-	co := &ClusterOverview{
-		namespace: namespace,
-		client:    client,
-		logger:    logger,
-		cache:     cache,
-		generator: g,
-		stopCh:    stopCh,
+	co = &ClusterOverview{
+		namespace:  namespace,
+		client:     client,
+		logger:     logger,
+		cache:      cache,
+		access:     access,
+		restMapper: rm,
+		notifier:   notifier,
+		namespaces: newNamespaceNotifier(),
+		searcher:   newSearcher(cache, index, logger),
+		generator:  g,
+		stopCh:     stopCh,
 	}
+
+	co.metrics = newMetricsSampler(metricsClient, co.currentNamespace, logger)
+	g.metrics = co.metrics
+	go co.metrics.run(stopCh)
+
 	return co, nil
 }
 
@@ -99,21 +141,176 @@ func (co *ClusterOverview) ContentPath() string {
 
 // Handler returns a handler for serving overview HTTP content.
 func (co *ClusterOverview) Handler(prefix string) http.Handler {
-	return newHandler(prefix, co.generator, stream, co.logger)
+	co.mu.Lock()
+	done := co.stopCh
+	co.mu.Unlock()
+
+	h := newHandler(prefix, co.generator, co.notifier, stream, co.logger,
+		handlerNamespaceOpt(co.namespaces, co.currentNamespace),
+		handlerDoneOpt(done))
+	h.handle(prefix, podLogsPath, newPodLogsHandler(co.client, co.currentNamespace, stream, co.logger))
+	h.handle(prefix, applyPath, newApplyHandler(co.client, co.cache, co.logger))
+	for _, r := range actionResources {
+		h.handle(prefix, actionPath(r.Path, "{name}", "{action}"), newActionHandler(r, co.client, co.cache, co.currentNamespace, co.logger))
+	}
+	if ic, ok := co.cache.(*InformerCache); ok {
+		h.handle(prefix, cacheStatsPath, newCacheStatsHandler(ic, co.logger))
+	}
+	return h
 }
 
-// Navigation returns navigation entries for overview.
+// Navigation returns navigation entries for overview. Entries for objects
+// the user can't list in the current namespace are left out.
 func (co *ClusterOverview) Navigation(root string) (*apt.Navigation, error) {
-	return navigationEntries(root)
+	// Custom resources are left out until their definitions have loaded.
+	crds, err := listCustomResourceDefinitions(context.Background(), co.cache)
+	if err != nil {
+		if !isForbidden(err) && !isLoading(err) {
+			co.logger.Errorf("listing custom resource definitions: %v", err)
+		}
+		crds = nil
+	}
+
+	nav, err := navigationEntries(root, crds)
+	if err != nil {
+		return nil, err
+	}
+
+	na := &navigationAccess{
+		access:     co.access,
+		restMapper: co.restMapper,
+		namespace:  co.currentNamespace(),
+		logger:     co.logger,
+	}
+	filterNavigation(nav, root, navigationResources(crds), na.allowed)
+
+	return nav, nil
 }
 
-// SetNamespace sets the current namespace.
+// handleCacheNotifications consumes cache notifications, updates the search
+// index and forwards them to the notifier. A REST mapper reset is requested on
+// resetCh when custom resource definitions change so the cache can create
+// informers for the new custom resources.
+func handleCacheNotifications(ch <-chan CacheNotification, resetCh chan<- struct{}, notifier *cacheNotifier, index *searchIndex, logger log.Logger) {
+	verbose := os.Getenv("DASH_VERBOSE_CACHE") != ""
+	crds := crdSpecs{}
+
+	for notif := range ch {
+		if verbose {
+			logger.With("key", notif.CacheKey, "action", notif.Action).Debugf("cache notification")
+		}
+
+		if crds.changed(notif) {
+			// A pending reset covers this change.
+			select {
+			case resetCh <- struct{}{}:
+			default:
+			}
+		}
+
+		index.update(notif)
+		notifier.Notify(notif)
+	}
+}
+
+// resetRESTMapper calls reset after a reset is requested on resetCh, until
+// stopCh is closed. Discovery runs here rather than in the notification
+// handler so it doesn't hold up cache notifications. Requests received during
+// the delay are covered by the reset, and requests received while resetting
+// are coalesced into one more reset.
+func resetRESTMapper(resetCh <-chan struct{}, stopCh <-chan struct{}, reset func() error, delay time.Duration, logger log.Logger) {
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-resetCh:
+		}
+
+		select {
+		case <-stopCh:
+			return
+		case <-time.After(delay):
+		}
+
+		// This reset covers requests received while waiting.
+		select {
+		case <-resetCh:
+		default:
+		}
+
+		if err := reset(); err != nil {
+			logger.Errorf("resetting REST mapper: %v", err)
+		}
+	}
+}
+
+// crdSpecs are the specs of the custom resource definitions seen in cache
+// notifications, by name. Discovery only changes when a definition is added
+// or deleted, or its spec changes, so other updates such as status changes
+// and informer resyncs don't need a REST mapper reset.
+type crdSpecs map[string]interface{}
+
+// changed records a notification and returns true if it added, deleted or
+// changed the spec of a custom resource definition.
+func (s crdSpecs) changed(notif CacheNotification) bool {
+	if notif.CacheKey.APIVersion != crdCacheKey.APIVersion || notif.CacheKey.Kind != crdCacheKey.Kind {
+		return false
+	}
+
+	name := notif.CacheKey.Name
+	if notif.Action == CacheDelete {
+		delete(s, name)
+		return true
+	}
+
+	// Notifications without an object can't be compared.
+	if notif.Object == nil {
+		return true
+	}
+
+	spec := notif.Object.Object["spec"]
+	previous, ok := s[name]
+	s[name] = spec
+
+	return !ok || !reflect.DeepEqual(previous, spec)
+}
+
+// Search finds objects held by the cache by name prefix, label, annotation,
+// container image or owner. Searches default to the current namespace.
+func (co *ClusterOverview) Search(ctx context.Context, query apt.SearchQuery) ([]apt.SearchResult, error) {
+	if query.Namespace == "" {
+		query.Namespace = co.currentNamespace()
+	}
+
+	return co.searcher.search(ctx, query)
+}
+
+// SetNamespace sets the current namespace. Content streams for other
+// namespaces are closed.
 func (co *ClusterOverview) SetNamespace(namespace string) error {
 	co.logger.With("namespace", namespace, "module", "overview").Debugf("setting namespace")
+
+	co.mu.Lock()
+	changed := co.namespace != namespace
 	co.namespace = namespace
+	co.mu.Unlock()
+
+	if changed {
+		co.namespaces.Notify(namespace)
+		co.metrics.wake()
+	}
+
 	return nil
 }
 
+// currentNamespace returns the current namespace.
+func (co *ClusterOverview) currentNamespace() string {
+	co.mu.Lock()
+	defer co.mu.Unlock()
+
+	return co.namespace
+}
+
 // Start starts overview.
 func (co *ClusterOverview) Start() error {
 	return nil
@@ -7,6 +7,7 @@ import (
 	"github.com/twosson/kubeapt/internal/log"
 	"k8s.io/apimachinery/pkg/runtime"
 	"testing"
+	"time"
 )
 
 func TestClusterOverview(t *testing.T) {
@@ -47,3 +48,69 @@ func TestClusterOverview_SetNamespace(t *testing.T) {
 	err = o.SetNamespace("ns2")
 	require.NoError(t, err)
 }
+
+func Test_crdSpecs_changed(t *testing.T) {
+	crds := crdSpecs{}
+
+	crd := newUnstructured(crdCacheKey.APIVersion, crdCacheKey.Kind, "", "crontabs.stable.example.com")
+	crd.Object["spec"] = map[string]interface{}{"group": "stable.example.com"}
+
+	notif := CacheNotification{
+		CacheKey: CacheKey{APIVersion: crdCacheKey.APIVersion, Kind: crdCacheKey.Kind, Name: crd.GetName()},
+		Action:   CacheStore,
+		Object:   crd,
+	}
+	assert.True(t, crds.changed(notif))
+
+	// Resyncs and status updates don't change discovery.
+	notif.Action = CacheUpdate
+	assert.False(t, crds.changed(notif))
+
+	updated := crd.DeepCopy()
+	updated.Object["spec"] = map[string]interface{}{"group": "stable.example.com", "version": "v2"}
+	notif.Object = updated
+	assert.True(t, crds.changed(notif))
+
+	notif.Action = CacheDelete
+	assert.True(t, crds.changed(notif))
+
+	other := CacheNotification{
+		CacheKey: CacheKey{APIVersion: "v1", Kind: "Pod", Name: "pod"},
+		Action:   CacheStore,
+	}
+	assert.False(t, crds.changed(other))
+}
+
+func Test_resetRESTMapper(t *testing.T) {
+	resetCh := make(chan struct{}, 1)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	resets := make(chan struct{}, 10)
+	reset := func() error {
+		resets <- struct{}{}
+		return nil
+	}
+
+	go resetRESTMapper(resetCh, stopCh, reset, 10*time.Millisecond, log.NopLogger())
+
+	// Requests made before the delay ends are coalesced into one reset.
+	for i := 0; i < 3; i++ {
+		select {
+		case resetCh <- struct{}{}:
+		default:
+		}
+	}
+
+	select {
+	case <-resets:
+	case <-time.After(time.Second):
+		t.Fatal("REST mapper was not reset")
+	}
+
+	select {
+	case <-resets:
+		t.Fatal("REST mapper was reset more than once")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
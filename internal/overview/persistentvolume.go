@@ -0,0 +1,47 @@
+package overview
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/twosson/kubeapt/pkg/content"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/kubernetes/pkg/apis/core"
+)
+
+type PersistentVolumeSummary struct {
+	prefix string
+}
+
+var _ View = (*PersistentVolumeSummary)(nil)
+
+func NewPersistentVolumeSummary(prefix, namespace string, c clock.Clock) View {
+	return &PersistentVolumeSummary{prefix: prefix}
+}
+
+func (pvs *PersistentVolumeSummary) Content(ctx context.Context, object runtime.Object, c Cache) ([]content.Content, error) {
+	pv, err := retrievePersistentVolume(object)
+	if err != nil {
+		return nil, err
+	}
+
+	detail, err := printPersistentVolumeSummary(pvs.prefix, pv)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := content.NewSummary("Details", []content.Section{detail})
+	return []content.Content{
+		&summary,
+	}, nil
+}
+
+func retrievePersistentVolume(object runtime.Object) (*core.PersistentVolume, error) {
+	pv, ok := object.(*core.PersistentVolume)
+	if !ok {
+		return nil, errors.Errorf("expected object to be a Persistent Volume, it was %T", object)
+	}
+
+	return pv, nil
+}
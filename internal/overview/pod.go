@@ -3,7 +3,8 @@ package overview
 import (
 	"context"
 	"github.com/pkg/errors"
-	"github.com/twosson/kubeapt/internal/content"
+	"github.com/twosson/kubeapt/internal/cluster"
+	"github.com/twosson/kubeapt/pkg/content"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -16,10 +17,12 @@ import (
 	"time"
 )
 
-type PodList struct{}
+type PodList struct {
+	prefix string
+}
 
 func NewPodList(prefix, namespace string, c clock.Clock) View {
-	return &PodList{}
+	return &PodList{prefix: prefix}
 }
 
 func (pc *PodList) Content(ctx context.Context, object runtime.Object, c Cache) ([]content.Content, error) {
@@ -33,7 +36,7 @@ func (pc *PodList) Content(ctx context.Context, object runtime.Object, c Cache)
 		return nil, err
 	}
 
-	pods, err := listPods(mobject.GetNamespace(), selector, mobject.GetUID(), c)
+	pods, err := listPods(ctx, mobject.GetNamespace(), selector, mobject.GetUID(), c)
 	if err != nil {
 		return nil, err
 	}
@@ -48,7 +51,7 @@ func (pc *PodList) Content(ctx context.Context, object runtime.Object, c Cache)
 	err = printContentObject(
 		"Pods",
 		"ns",
-		"prefix",
+		pc.prefix,
 		"No pods were found",
 		podTransforms,
 		list,
@@ -61,12 +64,14 @@ func (pc *PodList) Content(ctx context.Context, object runtime.Object, c Cache)
 	return contents, nil
 }
 
-type PodSummary struct{}
+type PodSummary struct {
+	prefix string
+}
 
 var _ View = (*PodSummary)(nil)
 
 func NewPodSummary(prefix, namespace string, c clock.Clock) View {
-	return &PodSummary{}
+	return &PodSummary{prefix: prefix}
 }
 
 func (ps *PodSummary) Content(ctx context.Context, object runtime.Object, c Cache) ([]content.Content, error) {
@@ -78,7 +83,7 @@ func (ps *PodSummary) Content(ctx context.Context, object runtime.Object, c Cach
 		return nil, err
 	}
 
-	detail, err := printPodSummary(pod, clk)
+	detail, err := printPodSummary(ps.prefix, pod, clk)
 	if err != nil {
 		return nil, err
 	}
@@ -151,10 +156,12 @@ func (pc *PodContainer) Content(ctx context.Context, object runtime.Object, c Ca
 	return describePodContainers(containers, statuses)
 }
 
-type PodVolume struct{}
+type PodVolume struct {
+	prefix string
+}
 
 func NewPodVolume(prefix, namespace string, c clock.Clock) View {
-	return &PodVolume{}
+	return &PodVolume{prefix: prefix}
 }
 
 func (pc *PodVolume) Content(ctx context.Context, object runtime.Object, c Cache) ([]content.Content, error) {
@@ -166,7 +173,7 @@ func (pc *PodVolume) Content(ctx context.Context, object runtime.Object, c Cache
 	sections := []content.Section{}
 
 	for _, volume := range pod.Spec.Volumes {
-		sections = append(sections, summarizeVolume(volume))
+		sections = append(sections, summarizeVolume(pc.prefix, volume))
 	}
 
 	volumes := content.NewSummary("Volumes", sections)
@@ -209,14 +216,14 @@ func createPodStatus(pods []*core.Pod) podStatus {
 	return ps
 }
 
-func listPods(namespace string, selector *metav1.LabelSelector, uid types.UID, c Cache) ([]*core.Pod, error) {
+func listPods(ctx context.Context, namespace string, selector *metav1.LabelSelector, uid types.UID, c Cache) ([]*core.Pod, error) {
 	key := CacheKey{
 		Namespace:  namespace,
 		APIVersion: "v1",
 		Kind:       "Pod",
 	}
 
-	pods, err := loadPods(key, c, selector)
+	pods, err := loadPods(ctx, key, c, selector)
 	if err != nil {
 		return nil, err
 	}
@@ -234,8 +241,8 @@ func listPods(namespace string, selector *metav1.LabelSelector, uid types.UID, c
 	return owned, nil
 }
 
-func loadPods(key CacheKey, c Cache, selector *metav1.LabelSelector) ([]*core.Pod, error) {
-	objects, err := c.Retrieve(key)
+func loadPods(ctx context.Context, key CacheKey, c Cache, selector *metav1.LabelSelector) ([]*core.Pod, error) {
+	objects, err := c.Retrieve(ctx, key)
 	if err != nil {
 		return nil, err
 	}
@@ -282,10 +289,7 @@ func getSelector(object runtime.Object) (*metav1.LabelSelector, error) {
 	case *extensions.Deployment:
 		return t.Spec.Selector, nil
 	case *core.Service:
-		selector := &metav1.LabelSelector{
-			MatchLabels: t.Spec.Selector,
-		}
-		return selector, nil
+		return cluster.ServiceSelector(t.Spec.Selector), nil
 	default:
 		return nil, errors.Errorf("unable to retrieve selector for type %T", object)
 	}
@@ -4,7 +4,7 @@ import (
 	"context"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"github.com/twosson/kubeapt/internal/content"
+	"github.com/twosson/kubeapt/pkg/content"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/clock"
@@ -17,11 +17,11 @@ import (
 )
 
 func TestPodList_InvalidObject(t *testing.T) {
-	assertViewInvalidObject(t, NewPodList("prefix", "ns", clock.NewFakeClock(time.Now())))
+	assertViewInvalidObject(t, NewPodList(testPrefix, "ns", clock.NewFakeClock(time.Now())))
 }
 
 func TestPodList(t *testing.T) {
-	pl := NewPodList("prefix", "ns", clock.NewFakeClock(time.Now()))
+	pl := NewPodList(testPrefix, "ns", clock.NewFakeClock(time.Now()))
 
 	cache := NewMemoryCache()
 
@@ -71,11 +71,11 @@ func TestPodList(t *testing.T) {
 }
 
 func TestPodCondition_InvalidObject(t *testing.T) {
-	assertViewInvalidObject(t, NewPodCondition("prefix", "ns", clock.NewFakeClock(time.Now())))
+	assertViewInvalidObject(t, NewPodCondition(testPrefix, "ns", clock.NewFakeClock(time.Now())))
 }
 
 func TestPodCondition(t *testing.T) {
-	pc := NewPodCondition("prefix", "ns", clock.NewFakeClock(time.Now()))
+	pc := NewPodCondition(testPrefix, "ns", clock.NewFakeClock(time.Now()))
 
 	lastProbeTime := metav1.Time{
 		Time: time.Unix(1539603521, 0),
@@ -3,7 +3,8 @@ package overview
 import (
 	"bytes"
 	"fmt"
-	"github.com/twosson/kubeapt/internal/content"
+	"github.com/twosson/kubeapt/internal/apt"
+	"github.com/twosson/kubeapt/pkg/content"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/api/extensions/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -13,12 +14,15 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	resourcehelper "k8s.io/kubernetes/pkg/api/resource"
 	"k8s.io/kubernetes/pkg/apis/apps"
+	"k8s.io/kubernetes/pkg/apis/autoscaling"
 	"k8s.io/kubernetes/pkg/apis/batch"
 	"k8s.io/kubernetes/pkg/apis/core"
 	"k8s.io/kubernetes/pkg/apis/core/helper"
 	"k8s.io/kubernetes/pkg/apis/core/helper/qos"
 	"k8s.io/kubernetes/pkg/apis/extensions"
+	"k8s.io/kubernetes/pkg/apis/networking"
 	"k8s.io/kubernetes/pkg/apis/rbac"
+	"k8s.io/kubernetes/pkg/apis/storage"
 	"k8s.io/kubernetes/pkg/printers/internalversion"
 	"path"
 	"sort"
@@ -82,7 +86,7 @@ func printCronJobSummary(cronJob *batch.CronJob, jobs []*batch.Job) (content.Sec
 	return section, nil
 }
 
-func printDeploymentSummary(deployment *extensions.Deployment) (content.Section, error) {
+func printDeploymentSummary(prefix string, deployment *extensions.Deployment, hpas []*autoscaling.HorizontalPodAutoscaler) (content.Section, error) {
 	section := content.NewSection()
 
 	section.AddText("Name", deployment.GetName())
@@ -123,10 +127,12 @@ func printDeploymentSummary(deployment *extensions.Deployment) (content.Section,
 	)
 	section.AddText("Status", status)
 
+	addManagedByLinks(prefix, &section, hpas)
+
 	return section, nil
 }
 
-func printJobSummary(job *batch.Job, pods []*core.Pod) (content.Section, error) {
+func printJobSummary(prefix string, job *batch.Job, pods []*core.Pod) (content.Section, error) {
 	section := content.NewSection()
 	section.AddText("Name", job.GetName())
 	section.AddText("Namespace", job.GetNamespace())
@@ -142,7 +148,7 @@ func printJobSummary(job *batch.Job, pods []*core.Pod) (content.Section, error)
 	section.AddList("Annotations", job.GetAnnotations())
 
 	if controllerRef := metav1.GetControllerOf(job); controllerRef != nil {
-		section.AddLink("Controlled By", controllerRef.Name, controlledByPath(controllerRef))
+		section.AddLink("Controlled By", controllerRef.Name, controlledByPath(prefix, controllerRef))
 	}
 
 	if p := job.Spec.Parallelism; p != nil {
@@ -170,7 +176,7 @@ func printJobSummary(job *batch.Job, pods []*core.Pod) (content.Section, error)
 	return section, nil
 }
 
-func printPodSummary(pod *core.Pod, c clock.Clock) (content.Section, error) {
+func printPodSummary(prefix string, pod *core.Pod, c clock.Clock) (content.Section, error) {
 	section := content.NewSection()
 	section.AddText("Name", pod.GetName())
 	section.AddText("Namespace", pod.GetNamespace())
@@ -180,7 +186,11 @@ func printPodSummary(pod *core.Pod, c clock.Clock) (content.Section, error) {
 		section.AddText("PriorityClassName", fmt.Sprintf("%s", stringOrNone(pod.Spec.PriorityClassName)))
 	}
 
-	section.AddText("Node", stringOrNone(pod.Spec.NodeName))
+	if pod.Spec.NodeName != "" {
+		section.AddLink("Node", pod.Spec.NodeName, gvkPath(prefix, "v1", "Node", pod.Spec.NodeName))
+	} else {
+		section.AddText("Node", "<none>")
+	}
 	section.AddTimestamp("Start Time", formatTime(pod.Status.StartTime))
 	section.AddLabels("Labels", pod.GetLabels())
 	section.AddList("Annotations", pod.GetAnnotations())
@@ -203,7 +213,7 @@ func printPodSummary(pod *core.Pod, c clock.Clock) (content.Section, error) {
 	section.AddText("IP", pod.Status.PodIP)
 
 	if controllerRef := metav1.GetControllerOf(pod); controllerRef != nil {
-		item := content.LinkItem("Controlled By", controllerRef.Name, controlledByPath(controllerRef))
+		item := content.LinkItem("Controlled By", controllerRef.Name, controlledByPath(prefix, controllerRef))
 		section.Items = append(section.Items, item)
 	}
 
@@ -219,7 +229,7 @@ func printPodSummary(pod *core.Pod, c clock.Clock) (content.Section, error) {
 
 	section.AddLabels("Node-Selectors", pod.Spec.NodeSelector)
 	section.AddLink("Service Account", pod.Spec.ServiceAccountName,
-		gvkPath("v1", "ServiceAccount", pod.Spec.ServiceAccountName))
+		gvkPath(prefix, "v1", "ServiceAccount", pod.Spec.ServiceAccountName))
 
 	// TODO add tolerations printer
 
@@ -246,7 +256,7 @@ func printDaemonSetSummary(replicaSet *extensions.DaemonSet, pods []*core.Pod) (
 	return section, nil
 }
 
-func printReplicaSetSummary(replicaSet *extensions.ReplicaSet, pods []*core.Pod) (content.Section, error) {
+func printReplicaSetSummary(prefix string, replicaSet *extensions.ReplicaSet, pods []*core.Pod) (content.Section, error) {
 	section := content.NewSection()
 
 	section.AddText("Name", replicaSet.GetName())
@@ -264,7 +274,7 @@ func printReplicaSetSummary(replicaSet *extensions.ReplicaSet, pods []*core.Pod)
 	ps := createPodStatus(pods)
 
 	if controllerRef := metav1.GetControllerOf(replicaSet); controllerRef != nil {
-		section.AddLink("Controlled By", controllerRef.Name, controlledByPath(controllerRef))
+		section.AddLink("Controlled By", controllerRef.Name, controlledByPath(prefix, controllerRef))
 	}
 
 	replicas := fmt.Sprintf("%d current / %d desired",
@@ -302,7 +312,7 @@ func printReplicationControllerSummary(rc *core.ReplicationController, pods []*c
 	return section, nil
 }
 
-func printStatefulSetSummary(ss *apps.StatefulSet, pods []*core.Pod) (content.Section, error) {
+func printStatefulSetSummary(prefix string, ss *apps.StatefulSet, pods []*core.Pod, hpas []*autoscaling.HorizontalPodAutoscaler) (content.Section, error) {
 	section := content.NewSection()
 	section.AddText("Name", ss.GetName())
 	section.AddText("Namespace", ss.GetNamespace())
@@ -330,11 +340,51 @@ func printStatefulSetSummary(ss *apps.StatefulSet, pods []*core.Pod) (content.Se
 		ps.Running, ps.Waiting, ps.Succeeded, ps.Failed)
 	section.AddText("Pod Status", podStatus)
 
+	addManagedByLinks(prefix, &section, hpas)
+
 	// TODO: add pod template
 
 	return section, nil
 }
 
+// addManagedByLinks links a workload to the horizontal pod autoscalers which
+// scale it.
+func addManagedByLinks(prefix string, section *content.Section, hpas []*autoscaling.HorizontalPodAutoscaler) {
+	for _, hpa := range hpas {
+		section.AddLink("Managed By", fmt.Sprintf("HPA %s", hpa.Name),
+			gvkPath(prefix, "autoscaling/v2beta1", "HorizontalPodAutoscaler", hpa.Name))
+	}
+}
+
+func printHorizontalPodAutoscalerSummary(prefix string, hpa *autoscaling.HorizontalPodAutoscaler) (content.Section, error) {
+	section := content.NewSection()
+	section.AddText("Name", hpa.GetName())
+	section.AddText("Namespace", hpa.GetNamespace())
+	section.AddLabels("Labels", hpa.GetLabels())
+	section.AddList("Annotations", hpa.GetAnnotations())
+	section.AddTimestamp("Creation Time", formatTime(&hpa.CreationTimestamp))
+
+	ref := hpa.Spec.ScaleTargetRef
+	section.AddLink("Scale Target", fmt.Sprintf("%s/%s", ref.Kind, ref.Name),
+		gvkPath(prefix, ref.APIVersion, ref.Kind, ref.Name))
+
+	var minReplicas string
+	if hpa.Spec.MinReplicas != nil {
+		minReplicas = fmt.Sprintf("%d", *hpa.Spec.MinReplicas)
+	} else {
+		minReplicas = "<unset>"
+	}
+	section.AddText("Min Replicas", minReplicas)
+	section.AddText("Max Replicas", fmt.Sprintf("%d", hpa.Spec.MaxReplicas))
+
+	section.AddText("Replicas", fmt.Sprintf("%d current / %d desired",
+		hpa.Status.CurrentReplicas, hpa.Status.DesiredReplicas))
+
+	section.AddTimestamp("Last Scale Time", formatTime(hpa.Status.LastScaleTime))
+
+	return section, nil
+}
+
 func printServiceSummary(s *core.Service) (content.Section, error) {
 	section := content.NewSection()
 	section.AddText("Name", s.GetName())
@@ -379,7 +429,7 @@ func printServiceSummary(s *core.Service) (content.Section, error) {
 	return section, nil
 }
 
-func printServiceAccountSummary(serviceAccount *core.ServiceAccount, tokens []*core.Secret, missingSecrets sets.String) (content.Section, error) {
+func printServiceAccountSummary(prefix string, serviceAccount *core.ServiceAccount, tokens []*core.Secret, missingSecrets sets.String) (content.Section, error) {
 	section := content.NewSection()
 	section.AddText("Name", serviceAccount.GetName())
 	section.AddText("Namespace", serviceAccount.GetNamespace())
@@ -418,14 +468,14 @@ func printServiceAccountSummary(serviceAccount *core.ServiceAccount, tokens []*c
 		if len(names) == 0 {
 			section.AddText(header, "<none>")
 		} else {
-			prefix := header
+			title := header
 			for _, name := range names {
 				if missingSecrets.Has(name) {
-					section.AddText(prefix, fmt.Sprintf("%s (not found)", name))
+					section.AddText(title, fmt.Sprintf("%s (not found)", name))
 				} else {
-					section.AddLink(prefix, name, gvkPath("v1", "Secret", name))
+					section.AddLink(title, name, gvkPath(prefix, "v1", "Secret", name))
 				}
-				prefix = emptyHeader
+				title = emptyHeader
 			}
 		}
 	}
@@ -459,6 +509,22 @@ func printIngressSummary(ingress *v1beta1.Ingress) (content.Section, error) {
 	return section, nil
 }
 
+func printNetworkPolicySummary(np *networking.NetworkPolicy) (content.Section, error) {
+	section := content.NewSection()
+	section.AddText("Name", np.GetName())
+	section.AddText("Namespace", np.GetNamespace())
+
+	section.AddLabels("Labels", np.GetLabels())
+	section.AddList("Annotations", np.GetAnnotations())
+	section.AddTimestamp("Creation Time", formatTime(&np.CreationTimestamp))
+
+	section.AddText("Pod Selector", describeSelector(&np.Spec.PodSelector, "<all pods>"))
+
+	section.AddText("Policy Types", describePolicyTypes(np))
+
+	return section, nil
+}
+
 func printConfigMapSummary(configMap *core.ConfigMap) (content.Section, error) {
 	section := content.NewSection()
 	section.AddText("Name", configMap.GetName())
@@ -534,7 +600,11 @@ func printRoleSummary(role *rbac.Role) (content.Section, error) {
 }
 
 func printRoleRule(role *rbac.Role) (content.Table, error) {
-	table := content.NewTable("Rules", "No rules are configured for this Role")
+	return printPolicyRules(role.Rules, "No rules are configured for this Role")
+}
+
+func printPolicyRules(rules []rbac.PolicyRule, emptyMessage string) (content.Table, error) {
+	table := content.NewTable("Rules", emptyMessage)
 
 	columnNames := []string{
 		"Resources",
@@ -547,7 +617,7 @@ func printRoleRule(role *rbac.Role) (content.Table, error) {
 		table.Columns = append(table.Columns, tableCol(name))
 	}
 
-	for _, rule := range role.Rules {
+	for _, rule := range rules {
 		resources := strings.Join(rule.Resources, ", ")
 		nonResourceURLs := "[]"
 		if len(rule.NonResourceURLs) > 0 {
@@ -555,7 +625,7 @@ func printRoleRule(role *rbac.Role) (content.Table, error) {
 		}
 		resourceNames := "[]"
 		if len(rule.ResourceNames) > 0 {
-			nonResourceURLs = strings.Join(rule.ResourceNames, ", ")
+			resourceNames = strings.Join(rule.ResourceNames, ", ")
 		}
 		verbs := "[]"
 		if len(rule.Verbs) > 0 {
@@ -573,7 +643,7 @@ func printRoleRule(role *rbac.Role) (content.Table, error) {
 	return table, nil
 }
 
-func printRoleBindingSummary(roleBinding *rbac.RoleBinding, role *rbac.Role) (content.Section, error) {
+func printRoleBindingSummary(prefix string, roleBinding *rbac.RoleBinding, role *rbac.Role) (content.Section, error) {
 	section := content.NewSection()
 	section.AddText("Name", roleBinding.GetName())
 	section.AddText("Namespace", roleBinding.GetNamespace())
@@ -581,13 +651,17 @@ func printRoleBindingSummary(roleBinding *rbac.RoleBinding, role *rbac.Role) (co
 	section.AddLabels("Labels", roleBinding.GetLabels())
 	section.AddList("Annotations", roleBinding.GetAnnotations())
 
-	section.AddLink("Role", role.GetName(), gvkPath(role.APIVersion, role.Kind, role.Name))
+	section.AddLink("Role", role.GetName(), gvkPath(prefix, role.APIVersion, role.Kind, role.Name))
 
 	return section, nil
 }
 
 func printRoleBindingSubjects(roleBinding *rbac.RoleBinding) (content.Table, error) {
-	table := content.NewTable("Subjects", "No subjects are configured for this RoleBinding")
+	return printSubjects(roleBinding.Subjects, "No subjects are configured for this RoleBinding")
+}
+
+func printSubjects(subjects []rbac.Subject, emptyMessage string) (content.Table, error) {
+	table := content.NewTable("Subjects", emptyMessage)
 
 	columnNames := []string{
 		"Kind",
@@ -599,7 +673,7 @@ func printRoleBindingSubjects(roleBinding *rbac.RoleBinding) (content.Table, err
 		table.Columns = append(table.Columns, tableCol(name))
 	}
 
-	for _, subject := range roleBinding.Subjects {
+	for _, subject := range subjects {
 		kind := subject.Kind
 		name := subject.Name
 		namespace := subject.Namespace
@@ -614,7 +688,173 @@ func printRoleBindingSubjects(roleBinding *rbac.RoleBinding) (content.Table, err
 	return table, nil
 }
 
-func printPodTemplate(template *core.PodTemplateSpec, containerStatuses []core.ContainerStatus) ([]content.Content, error) {
+func printClusterRoleSummary(clusterRole *rbac.ClusterRole) (content.Section, error) {
+	section := content.NewSection()
+	section.AddText("Name", clusterRole.GetName())
+
+	section.AddLabels("Labels", clusterRole.GetLabels())
+	section.AddList("Annotations", clusterRole.GetAnnotations())
+
+	if clusterRole.AggregationRule != nil {
+		var selectors []string
+		for _, selector := range clusterRole.AggregationRule.ClusterRoleSelectors {
+			selectors = append(selectors, metav1.FormatLabelSelector(&selector))
+		}
+		section.AddText("Aggregation Selectors", strings.Join(selectors, ", "))
+	}
+
+	return section, nil
+}
+
+func printClusterRoleBindingSummary(prefix string, clusterRoleBinding *rbac.ClusterRoleBinding) (content.Section, error) {
+	section := content.NewSection()
+	section.AddText("Name", clusterRoleBinding.GetName())
+
+	section.AddLabels("Labels", clusterRoleBinding.GetLabels())
+	section.AddList("Annotations", clusterRoleBinding.GetAnnotations())
+
+	roleRef := clusterRoleBinding.RoleRef
+	section.AddLink("Cluster Role", roleRef.Name,
+		gvkPath(prefix, "rbac.authorization.k8s.io/v1", roleRef.Kind, roleRef.Name))
+
+	return section, nil
+}
+
+func printNodeSummary(node *core.Node) (content.Section, error) {
+	section := content.NewSection()
+	section.AddText("Name", node.GetName())
+
+	section.AddLabels("Labels", node.GetLabels())
+	section.AddList("Annotations", node.GetAnnotations())
+	section.AddTimestamp("Creation Time", formatTime(&node.CreationTimestamp))
+
+	var taints []string
+	for _, taint := range node.Spec.Taints {
+		taints = append(taints, taint.ToString())
+	}
+	section.AddText("Taints", stringOrNone(strings.Join(taints, ", ")))
+
+	section.AddText("Unschedulable", fmt.Sprintf("%t", node.Spec.Unschedulable))
+
+	var addresses []string
+	for _, address := range node.Status.Addresses {
+		addresses = append(addresses, fmt.Sprintf("%s: %s", address.Type, address.Address))
+	}
+	section.AddText("Addresses", stringOrNone(strings.Join(addresses, ", ")))
+
+	if node.Spec.PodCIDR != "" {
+		section.AddText("Pod CIDR", node.Spec.PodCIDR)
+	}
+
+	section.AddText("Kubelet Version", node.Status.NodeInfo.KubeletVersion)
+
+	return section, nil
+}
+
+func printNodeSystemInfo(node *core.Node) (content.Section, error) {
+	info := node.Status.NodeInfo
+
+	section := content.NewSection()
+	section.AddText("Machine ID", stringOrNone(info.MachineID))
+	section.AddText("System UUID", stringOrNone(info.SystemUUID))
+	section.AddText("Boot ID", stringOrNone(info.BootID))
+	section.AddText("Kernel Version", stringOrNone(info.KernelVersion))
+	section.AddText("OS Image", stringOrNone(info.OSImage))
+	section.AddText("Operating System", stringOrNone(info.OperatingSystem))
+	section.AddText("Architecture", stringOrNone(info.Architecture))
+	section.AddText("Container Runtime Version", stringOrNone(info.ContainerRuntimeVersion))
+	section.AddText("Kubelet Version", stringOrNone(info.KubeletVersion))
+	section.AddText("Kube-Proxy Version", stringOrNone(info.KubeProxyVersion))
+
+	return section, nil
+}
+
+func printNamespaceSummary(namespace *core.Namespace) (content.Section, error) {
+	section := content.NewSection()
+	section.AddText("Name", namespace.GetName())
+
+	section.AddLabels("Labels", namespace.GetLabels())
+	section.AddList("Annotations", namespace.GetAnnotations())
+	section.AddTimestamp("Creation Time", formatTime(&namespace.CreationTimestamp))
+
+	section.AddText("Status", string(namespace.Status.Phase))
+
+	return section, nil
+}
+
+func printPersistentVolumeSummary(prefix string, pv *core.PersistentVolume) (content.Section, error) {
+	section := content.NewSection()
+	section.AddText("Name", pv.GetName())
+
+	section.AddLabels("Labels", pv.GetLabels())
+	section.AddList("Annotations", pv.GetAnnotations())
+	section.AddTimestamp("Creation Time", formatTime(&pv.CreationTimestamp))
+
+	section.AddText("Finalizers", strings.Join(pv.ObjectMeta.Finalizers, ", "))
+
+	if pv.Spec.StorageClassName != "" {
+		section.AddLink("Storage Class", pv.Spec.StorageClassName,
+			gvkPath(prefix, "storage.k8s.io/v1", "StorageClass", pv.Spec.StorageClassName))
+	} else {
+		section.AddText("Storage Class", "<none>")
+	}
+
+	section.AddText("Status", string(pv.Status.Phase))
+
+	if claimRef := pv.Spec.ClaimRef; claimRef != nil {
+		section.AddText("Claim", fmt.Sprintf("%s/%s", claimRef.Namespace, claimRef.Name))
+	} else {
+		section.AddText("Claim", "<none>")
+	}
+
+	section.AddText("Reclaim Policy", string(pv.Spec.PersistentVolumeReclaimPolicy))
+	section.AddText("Access Modes", helper.GetAccessModesAsString(pv.Spec.AccessModes))
+
+	if pv.Spec.VolumeMode != nil {
+		section.AddText("VolumeMode", string(*pv.Spec.VolumeMode))
+	}
+
+	capacity := pv.Spec.Capacity[core.ResourceStorage]
+	section.AddText("Capacity", capacity.String())
+
+	if pv.Status.Message != "" {
+		section.AddText("Message", pv.Status.Message)
+	}
+
+	return section, nil
+}
+
+func printStorageClassSummary(storageClass *storage.StorageClass) (content.Section, error) {
+	section := content.NewSection()
+	section.AddText("Name", storageClass.GetName())
+
+	section.AddLabels("Labels", storageClass.GetLabels())
+	section.AddList("Annotations", storageClass.GetAnnotations())
+	section.AddTimestamp("Creation Time", formatTime(&storageClass.CreationTimestamp))
+
+	section.AddText("Provisioner", storageClass.Provisioner)
+	section.AddList("Parameters", storageClass.Parameters)
+
+	if storageClass.AllowVolumeExpansion != nil {
+		section.AddText("Allow Volume Expansion", fmt.Sprintf("%t", *storageClass.AllowVolumeExpansion))
+	}
+
+	if len(storageClass.MountOptions) > 0 {
+		section.AddText("Mount Options", strings.Join(storageClass.MountOptions, ", "))
+	}
+
+	if storageClass.ReclaimPolicy != nil {
+		section.AddText("Reclaim Policy", string(*storageClass.ReclaimPolicy))
+	}
+
+	if storageClass.VolumeBindingMode != nil {
+		section.AddText("Volume Binding Mode", string(*storageClass.VolumeBindingMode))
+	}
+
+	return section, nil
+}
+
+func printPodTemplate(prefix string, template *core.PodTemplateSpec, containerStatuses []core.ContainerStatus) ([]content.Content, error) {
 
 	templateSection := content.NewSection()
 	templateSection.AddLabels("Labels", template.Labels)
@@ -623,7 +863,7 @@ func printPodTemplate(template *core.PodTemplateSpec, containerStatuses []core.C
 	}
 	if template.Spec.ServiceAccountName != "" {
 		templateSection.AddLink("Service Account", template.Spec.ServiceAccountName,
-			gvkPath("v1", "ServiceAccount", template.Spec.ServiceAccountName))
+			gvkPath(prefix, "v1", "ServiceAccount", template.Spec.ServiceAccountName))
 	}
 
 	podTemplateSections := []content.Section{templateSection}
@@ -913,44 +1153,63 @@ func stringOrDefaultValue(s, defaultValue string) string {
 	return defaultValue
 }
 
-func gvkPath(apiVersion, kind, name string) string {
+// gvkPath returns the link to an object shown by the overview handler served
+// from prefix, or to the overview if the object's kind isn't shown.
+func gvkPath(prefix, apiVersion, kind, name string) string {
 	var p string
 
 	switch {
 	case apiVersion == "apps/v1" && kind == "DaemonSet":
-		p = "/content/overview/workloads/daemon-sets"
+		p = "workloads/daemon-sets"
 	case apiVersion == "extensions/v1beta1" && kind == "ReplicaSet":
-		p = "/content/overview/workloads/replica-sets"
+		p = "workloads/replica-sets"
 	case apiVersion == "apps/v1" && kind == "StatefulSet":
-		p = "/content/overview/workloads/stateful-sets"
-	case apiVersion == "extensions/v1beta1" && kind == "Deployment":
-		p = "/content/overview/workloads/deployments"
+		p = "workloads/stateful-sets"
+	case (apiVersion == "apps/v1" || apiVersion == "extensions/v1beta1") && kind == "Deployment":
+		p = "workloads/deployments"
 	case apiVersion == "batch/v1beta1" && kind == "CronJob":
-		p = "/content/overview/workloads/cron-jobs"
+		p = "workloads/cron-jobs"
 	case (apiVersion == "batch/v1beta1" || apiVersion == "batch/v1") && kind == "Job":
-		p = "/content/overview/workloads/jobs"
+		p = "workloads/jobs"
 	case apiVersion == "v1" && kind == "ReplicationController":
-		p = "/content/overview/workloads/replication-controllers"
+		p = "workloads/replication-controllers"
+	case (apiVersion == "autoscaling/v1" || apiVersion == "autoscaling/v2beta1") && kind == "HorizontalPodAutoscaler":
+		p = "workloads/horizontal-pod-autoscalers"
 	case apiVersion == "v1" && kind == "Secret":
-		p = "/content/overview/config-and-storage/secrets"
+		p = "config-and-storage/secrets"
 	case apiVersion == "v1" && kind == "PersistentVolumeClaim":
-		p = "/content/overview/config-and-storage/persistent-volume-claims"
+		p = "config-and-storage/persistent-volume-claims"
 	case apiVersion == "v1" && kind == "ServiceAccount":
-		p = "/content/overview/config-and-storage/service-accounts"
+		p = "config-and-storage/service-accounts"
 	case apiVersion == "v1" && kind == "Service":
-		p = "/content/overview/discovery-and-load-balancing/services"
+		p = "discovery-and-load-balancing/services"
+	case apiVersion == "networking.k8s.io/v1" && kind == "NetworkPolicy":
+		p = "discovery-and-load-balancing/network-policies"
 	case apiVersion == "rbac.authorization.k8s.io/v1" && kind == "Role":
-		p = "/content/overview/rbac/roles"
+		p = "rbac/roles"
+	case apiVersion == "v1" && kind == "Node":
+		p = "cluster/nodes"
+	case apiVersion == "v1" && kind == "Namespace":
+		p = "cluster/namespaces"
+	case apiVersion == "v1" && kind == "PersistentVolume":
+		p = "cluster/persistent-volumes"
+	case apiVersion == "storage.k8s.io/v1" && kind == "StorageClass":
+		p = "cluster/storage-classes"
+	case apiVersion == "rbac.authorization.k8s.io/v1" && kind == "ClusterRole":
+		p = "cluster/cluster-roles"
+	case apiVersion == "rbac.authorization.k8s.io/v1" && kind == "ClusterRoleBinding":
+		p = "cluster/cluster-role-bindings"
+	case apiVersion == "apiextensions.k8s.io/v1beta1" && kind == "CustomResourceDefinition":
+		p = "cluster/custom-resource-definitions"
 	default:
-		return "/content/overview"
+		return apt.LinkRoot(prefix)
 	}
 
-	return path.Join(p, name)
-
+	return path.Join(apt.LinkRoot(prefix), p, name)
 }
 
-func controlledByPath(controllerRef *metav1.OwnerReference) string {
-	return gvkPath(controllerRef.APIVersion, controllerRef.Kind, controllerRef.Name)
+func controlledByPath(prefix string, controllerRef *metav1.OwnerReference) string {
+	return gvkPath(prefix, controllerRef.APIVersion, controllerRef.Kind, controllerRef.Name)
 }
 
 func buildIngressString(ingress []core.LoadBalancerIngress) string {
@@ -4,10 +4,15 @@ import (
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"github.com/twosson/kubeapt/internal/content"
+	"github.com/twosson/kubeapt/pkg/content"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
 	"k8s.io/kubernetes/pkg/apis/batch"
 	"k8s.io/kubernetes/pkg/apis/core"
+	"k8s.io/kubernetes/pkg/apis/rbac"
+	"k8s.io/kubernetes/pkg/apis/storage"
 	"testing"
+	"time"
 )
 
 func Test_printCronJobSummary(t *testing.T) {
@@ -55,6 +60,90 @@ func Test_printConfigMapSummary(t *testing.T) {
 
 }
 
+func Test_printPodSummary_node(t *testing.T) {
+	pod := &core.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "default"},
+		Spec:       core.PodSpec{NodeName: "node1"},
+	}
+
+	got, err := printPodSummary(testPrefix, pod, clock.NewFakeClock(time.Now()))
+	require.NoError(t, err)
+
+	expected := content.NewSection()
+	expected.AddLink("Node", "node1", "/content/overview/cluster/nodes/node1")
+	assert.Contains(t, got.Items, expected.Items[0])
+
+	pod.Spec.NodeName = ""
+
+	got, err = printPodSummary(testPrefix, pod, clock.NewFakeClock(time.Now()))
+	require.NoError(t, err)
+
+	expected = content.NewSection()
+	expected.AddText("Node", "<none>")
+	assert.Contains(t, got.Items, expected.Items[0])
+}
+
+func Test_printPolicyRules(t *testing.T) {
+	rules := []rbac.PolicyRule{
+		{
+			Verbs:         []string{"get", "list"},
+			Resources:     []string{"pods"},
+			ResourceNames: []string{"pod-1"},
+		},
+		{
+			Verbs:           []string{"get"},
+			NonResourceURLs: []string{"/healthz"},
+		},
+	}
+
+	got, err := printPolicyRules(rules, "empty")
+	require.NoError(t, err)
+
+	expected := content.NewTable("Rules", "empty")
+	expected.Columns = tableCols("Resources", "Non-Resource URLs", "Resource Names", "Verbs")
+	expected.AddRow(content.TableRow{
+		"Resources":         content.NewStringText("pods"),
+		"Non-Resource URLs": content.NewStringText("[]"),
+		"Resource Names":    content.NewStringText("pod-1"),
+		"Verbs":             content.NewStringText("get, list"),
+	})
+	expected.AddRow(content.TableRow{
+		"Resources":         content.NewStringText(""),
+		"Non-Resource URLs": content.NewStringText("/healthz"),
+		"Resource Names":    content.NewStringText("[]"),
+		"Verbs":             content.NewStringText("get"),
+	})
+
+	assert.Equal(t, expected, got)
+}
+
+func Test_printStorageClassSummary(t *testing.T) {
+	reclaimPolicy := core.PersistentVolumeReclaimDelete
+	storageClass := &storage.StorageClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "standard",
+			CreationTimestamp: metav1.NewTime(time.Date(2018, 11, 2, 9, 45, 0, 0, time.UTC)),
+		},
+		Provisioner:   "kubernetes.io/gce-pd",
+		Parameters:    map[string]string{"type": "pd-standard"},
+		ReclaimPolicy: &reclaimPolicy,
+	}
+
+	got, err := printStorageClassSummary(storageClass)
+	require.NoError(t, err)
+
+	expected := content.NewSection()
+	expected.AddText("Name", "standard")
+	expected.AddLabels("Labels", nil)
+	expected.AddList("Annotations", nil)
+	expected.AddTimestamp("Creation Time", "2018-11-02T09:45:00Z")
+	expected.AddText("Provisioner", "kubernetes.io/gce-pd")
+	expected.AddList("Parameters", map[string]string{"type": "pd-standard"})
+	expected.AddText("Reclaim Policy", "Delete")
+
+	assert.Equal(t, expected, got)
+}
+
 func Test_gvkPath(t *testing.T) {
 	cases := []struct {
 		apiVersion string
@@ -80,6 +169,18 @@ func Test_gvkPath(t *testing.T) {
 			name:       "name",
 			expected:   "/content/overview/workloads/deployments/name",
 		},
+		{
+			apiVersion: "apps/v1",
+			kind:       "Deployment",
+			name:       "name",
+			expected:   "/content/overview/workloads/deployments/name",
+		},
+		{
+			apiVersion: "autoscaling/v1",
+			kind:       "HorizontalPodAutoscaler",
+			name:       "name",
+			expected:   "/content/overview/workloads/horizontal-pod-autoscalers/name",
+		},
 		{
 			apiVersion: "apps/v1",
 			kind:       "StatefulSet",
@@ -104,6 +205,12 @@ func Test_gvkPath(t *testing.T) {
 			name:       "name",
 			expected:   "/content/overview/workloads/replication-controllers/name",
 		},
+		{
+			apiVersion: "networking.k8s.io/v1",
+			kind:       "NetworkPolicy",
+			name:       "name",
+			expected:   "/content/overview/discovery-and-load-balancing/network-policies/name",
+		},
 		{
 			apiVersion: "v1",
 			kind:       "Service",
@@ -128,6 +235,18 @@ func Test_gvkPath(t *testing.T) {
 			name:       "name",
 			expected:   "/content/overview/rbac/roles/name",
 		},
+		{
+			apiVersion: "v1",
+			kind:       "Node",
+			name:       "name",
+			expected:   "/content/overview/cluster/nodes/name",
+		},
+		{
+			apiVersion: "rbac.authorization.k8s.io/v1",
+			kind:       "ClusterRole",
+			name:       "name",
+			expected:   "/content/overview/cluster/cluster-roles/name",
+		},
 		{
 			apiVersion: "unknown",
 			kind:       "unknown",
@@ -139,8 +258,13 @@ func Test_gvkPath(t *testing.T) {
 	for _, tc := range cases {
 		name := fmt.Sprintf("apiVersion:%q kind:%q", tc.apiVersion, tc.kind)
 		t.Run(name, func(t *testing.T) {
-			got := gvkPath(tc.apiVersion, tc.kind, tc.name)
+			got := gvkPath(testPrefix, tc.apiVersion, tc.kind, tc.name)
 			assert.Equal(t, tc.expected, got)
 		})
 	}
 }
+
+func Test_gvkPath_cluster(t *testing.T) {
+	got := gvkPath("/api/v1/clusters/staging/content/overview", "apps/v1", "Deployment", "web")
+	assert.Equal(t, "/clusters/staging/content/overview/workloads/deployments/web", got)
+}
@@ -3,18 +3,20 @@ package overview
 import (
 	"context"
 	"github.com/pkg/errors"
-	"github.com/twosson/kubeapt/internal/content"
+	"github.com/twosson/kubeapt/pkg/content"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/clock"
 	"k8s.io/kubernetes/pkg/apis/extensions"
 )
 
-type ReplicaSetSummary struct{}
+type ReplicaSetSummary struct {
+	prefix string
+}
 
 var _ View = (*ReplicaSetSummary)(nil)
 
 func NewReplicaSetSummary(prefix, namespace string, c clock.Clock) View {
-	return &ReplicaSetSummary{}
+	return &ReplicaSetSummary{prefix: prefix}
 }
 
 func (rss *ReplicaSetSummary) Content(ctx context.Context, object runtime.Object, c Cache) ([]content.Content, error) {
@@ -23,16 +25,16 @@ func (rss *ReplicaSetSummary) Content(ctx context.Context, object runtime.Object
 		return nil, err
 	}
 
-	return rss.summary(replicaSet, c)
+	return rss.summary(ctx, replicaSet, c)
 }
 
-func (rss *ReplicaSetSummary) summary(replicaSet *extensions.ReplicaSet, c Cache) ([]content.Content, error) {
-	pods, err := listPods(replicaSet.GetNamespace(), replicaSet.Spec.Selector, replicaSet.GetUID(), c)
+func (rss *ReplicaSetSummary) summary(ctx context.Context, replicaSet *extensions.ReplicaSet, c Cache) ([]content.Content, error) {
+	pods, err := listPods(ctx, replicaSet.GetNamespace(), replicaSet.Spec.Selector, replicaSet.GetUID(), c)
 	if err != nil {
 		return nil, err
 	}
 
-	section, err := printReplicaSetSummary(replicaSet, pods)
+	section, err := printReplicaSetSummary(rss.prefix, replicaSet, pods)
 	if err != nil {
 		return nil, err
 	}
@@ -3,7 +3,7 @@ package overview
 import (
 	"context"
 	"github.com/pkg/errors"
-	"github.com/twosson/kubeapt/internal/content"
+	"github.com/twosson/kubeapt/pkg/content"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/clock"
@@ -28,7 +28,7 @@ func (js *ReplicationControllerSummary) Content(ctx context.Context, object runt
 		MatchLabels: rc.Spec.Selector,
 	}
 
-	pods, err := listPods(rc.GetNamespace(), s, rc.GetUID(), c)
+	pods, err := listPods(ctx, rc.GetNamespace(), s, rc.GetUID(), c)
 	if err != nil {
 		return nil, err
 	}
@@ -3,9 +3,11 @@ package overview
 import (
 	"context"
 	"fmt"
+	"github.com/twosson/kubeapt/internal/apt"
 	"github.com/twosson/kubeapt/internal/cluster"
-	"github.com/twosson/kubeapt/internal/content"
+	"github.com/twosson/kubeapt/pkg/content"
 	metav1beta1 "k8s.io/apimachinery/pkg/apis/meta/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"path"
 	"reflect"
 )
@@ -13,7 +15,7 @@ import (
 func resourceLink(sectionType, resourceType string) lookupFunc {
 	return func(namespace, prefix string, cell interface{}) content.Text {
 		name := fmt.Sprintf("%v", cell)
-		resourcePath := path.Join("/content", "overview", sectionType, resourceType, name)
+		resourcePath := path.Join(apt.LinkRoot(prefix), sectionType, resourceType, name)
 		return content.NewLinkText(name, resourcePath)
 	}
 }
@@ -28,6 +30,17 @@ type ContentSection struct {
 	Title string
 }
 
+// ResourceColumn is a list table column computed from each listed object,
+// for values the object printer doesn't show.
+type ResourceColumn struct {
+	Name  string
+	Value func(ctx context.Context, object runtime.Object, c Cache) content.Text
+	// Available reports whether the column is shown, e.g. usage columns
+	// are left out if usage isn't sampled. Columns are always shown if it
+	// is nil.
+	Available func(ctx context.Context) bool
+}
+
 type ResourceOptions struct {
 	Path       string
 	CacheKey   CacheKey
@@ -36,6 +49,12 @@ type ResourceOptions struct {
 	Titles     ResourceTitle
 	Transforms map[string]lookupFunc
 	Sections   []ContentSection
+	// ClusterScoped is true if the resource is not namespaced.
+	ClusterScoped bool
+	// Actions are the actions which can be performed on objects.
+	Actions []string
+	// Columns are added to the list table before the labels column.
+	Columns []ResourceColumn
 }
 
 type Resource struct {
@@ -55,7 +74,10 @@ func (r *Resource) Describe(ctx context.Context, prefix, namespace string, clust
 func (r *Resource) List(namespace string) *ListDescriber {
 	emptyMessage := fmt.Sprintf("Namespace %s does not have any %s",
 		namespace, r.Titles.List)
-	return NewListDescriber(
+	if r.ClusterScoped || namespace == AllNamespaces {
+		emptyMessage = fmt.Sprintf("Cluster does not have any %s", r.Titles.List)
+	}
+	d := NewListDescriber(
 		r.Path,
 		r.Titles.List,
 		r.CacheKey,
@@ -67,18 +89,31 @@ func (r *Resource) List(namespace string) *ListDescriber {
 		},
 		summaryFunc(r.Titles.List, emptyMessage, r.Transforms),
 	)
+	d.columns = r.Columns
+
+	return d
 }
 
+// Object creates a describer for a single object. A YAML section is added
+// after the resource's sections.
 func (r *Resource) Object() *ObjectDescriber {
-	return NewObjectDescriber(
+	sections := make([]ContentSection, 0, len(r.Sections)+1)
+	sections = append(sections, r.Sections...)
+	sections = append(sections, yamlSection)
+
+	d := NewObjectDescriber(
 		path.Join(r.Path, "(?P<name>.*?)"),
 		r.Titles.Object,
 		DefaultLoader(r.CacheKey),
 		func() interface{} {
 			return reflect.New(reflect.ValueOf(r.ObjectType).Elem().Type()).Interface()
 		},
-		r.Sections,
+		sections,
 	)
+	d.resourcePath = r.Path
+	d.actions = r.Actions
+
+	return d
 }
 
 func (r *Resource) PathFilters(namespace string) []pathFilter {
@@ -123,3 +158,74 @@ func summaryFunc(title, emptyMessage string, m map[string]lookupFunc) ObjectTran
 		}
 	}
 }
+
+// withResourceColumns wraps an ObjectTransformFunc so columns computed from
+// the printed objects are added to the tables it creates. Columns which
+// aren't available are left out.
+func withResourceColumns(ctx context.Context, c Cache, otf ObjectTransformFunc, columns []ResourceColumn) ObjectTransformFunc {
+	var available []ResourceColumn
+	for _, column := range columns {
+		if column.Available == nil || column.Available(ctx) {
+			available = append(available, column)
+		}
+	}
+
+	if len(available) == 0 {
+		return otf
+	}
+
+	return func(namespace, prefix string, contents *[]content.Content) func(*metav1beta1.Table) error {
+		transform := otf(namespace, prefix, contents)
+
+		return func(tbl *metav1beta1.Table) error {
+			printed := len(*contents)
+			if err := transform(tbl); err != nil {
+				return err
+			}
+
+			for _, item := range (*contents)[printed:] {
+				if contentTable, ok := item.(*content.Table); ok {
+					addResourceColumns(ctx, c, contentTable, tbl, available)
+				}
+			}
+
+			return nil
+		}
+	}
+}
+
+// addResourceColumns adds columns computed from the objects in a printed
+// table. Rows in the content table match the printed rows.
+func addResourceColumns(ctx context.Context, c Cache, contentTable *content.Table, tbl *metav1beta1.Table, columns []ResourceColumn) {
+	at := len(contentTable.Columns)
+	for i, column := range contentTable.Columns {
+		if column.Name == "Labels" {
+			at = i
+			break
+		}
+	}
+
+	var added []content.TableColumn
+	for _, column := range columns {
+		added = append(added, content.TableColumn{Name: column.Name, Accessor: column.Name})
+	}
+
+	tableColumns := append([]content.TableColumn{}, contentTable.Columns[:at]...)
+	tableColumns = append(tableColumns, added...)
+	contentTable.Columns = append(tableColumns, contentTable.Columns[at:]...)
+
+	for i, row := range contentTable.Rows {
+		if i >= len(tbl.Rows) {
+			break
+		}
+
+		object := tbl.Rows[i].Object.Object
+		for _, column := range columns {
+			if object == nil {
+				row[column.Name] = content.NewStringText("<unknown>")
+				continue
+			}
+			row[column.Name] = column.Value(ctx, object, c)
+		}
+	}
+}
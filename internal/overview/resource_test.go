@@ -1 +1,33 @@
 package overview
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/twosson/kubeapt/pkg/content"
+	"testing"
+)
+
+func Test_resourceLink(t *testing.T) {
+	cases := []struct {
+		name     string
+		prefix   string
+		expected string
+	}{
+		{
+			name:     "current cluster",
+			prefix:   testPrefix,
+			expected: "/content/overview/workloads/pods/web",
+		},
+		{
+			name:     "cluster",
+			prefix:   "/api/v1/clusters/staging/content/overview",
+			expected: "/clusters/staging/content/overview/workloads/pods/web",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := resourceLink("workloads", "pods")("default", tc.prefix, "web")
+			assert.Equal(t, content.NewLinkText("web", tc.expected), got)
+		})
+	}
+}
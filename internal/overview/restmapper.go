@@ -0,0 +1,85 @@
+package overview
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/restmapper"
+)
+
+// discoveryRESTMapper is a RESTMapper backed by API discovery. It can be
+// rebuilt when the resources served by the cluster change, e.g. when
+// a CustomResourceDefinition is installed or removed.
+type discoveryRESTMapper struct {
+	discoveryClient discovery.DiscoveryInterface
+
+	mu       sync.RWMutex
+	delegate meta.RESTMapper
+}
+
+var _ meta.RESTMapper = (*discoveryRESTMapper)(nil)
+
+func newDiscoveryRESTMapper(discoveryClient discovery.DiscoveryInterface) (*discoveryRESTMapper, error) {
+	rm := &discoveryRESTMapper{
+		discoveryClient: discoveryClient,
+	}
+
+	if err := rm.Reset(); err != nil {
+		return nil, err
+	}
+
+	return rm, nil
+}
+
+// Reset rebuilds the mappings using discovery.
+func (rm *discoveryRESTMapper) Reset() error {
+	groupResources, err := restmapper.GetAPIGroupResources(rm.discoveryClient)
+	if err != nil {
+		return errors.Wrap(err, "mapping APIGroupResources")
+	}
+
+	delegate := restmapper.NewDiscoveryRESTMapper(groupResources)
+
+	rm.mu.Lock()
+	rm.delegate = delegate
+	rm.mu.Unlock()
+
+	return nil
+}
+
+func (rm *discoveryRESTMapper) mapper() meta.RESTMapper {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+	return rm.delegate
+}
+
+func (rm *discoveryRESTMapper) KindFor(resource schema.GroupVersionResource) (schema.GroupVersionKind, error) {
+	return rm.mapper().KindFor(resource)
+}
+
+func (rm *discoveryRESTMapper) KindsFor(resource schema.GroupVersionResource) ([]schema.GroupVersionKind, error) {
+	return rm.mapper().KindsFor(resource)
+}
+
+func (rm *discoveryRESTMapper) ResourceFor(input schema.GroupVersionResource) (schema.GroupVersionResource, error) {
+	return rm.mapper().ResourceFor(input)
+}
+
+func (rm *discoveryRESTMapper) ResourcesFor(input schema.GroupVersionResource) ([]schema.GroupVersionResource, error) {
+	return rm.mapper().ResourcesFor(input)
+}
+
+func (rm *discoveryRESTMapper) RESTMapping(gk schema.GroupKind, versions ...string) (*meta.RESTMapping, error) {
+	return rm.mapper().RESTMapping(gk, versions...)
+}
+
+func (rm *discoveryRESTMapper) RESTMappings(gk schema.GroupKind, versions ...string) ([]*meta.RESTMapping, error) {
+	return rm.mapper().RESTMappings(gk, versions...)
+}
+
+func (rm *discoveryRESTMapper) ResourceSingularizer(resource string) (string, error) {
+	return rm.mapper().ResourceSingularizer(resource)
+}
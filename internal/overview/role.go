@@ -3,7 +3,7 @@ package overview
 import (
 	"context"
 	"github.com/pkg/errors"
-	"github.com/twosson/kubeapt/internal/content"
+	"github.com/twosson/kubeapt/pkg/content"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/clock"
 	"k8s.io/kubernetes/pkg/apis/rbac"
@@ -68,7 +68,7 @@ func retrieveRole(object runtime.Object) (*rbac.Role, error) {
 	return rc, nil
 }
 
-func getRole(namespace, name string, c Cache) (*rbac.Role, error) {
+func getRole(ctx context.Context, namespace, name string, c Cache) (*rbac.Role, error) {
 	key := CacheKey{
 		Namespace:  namespace,
 		APIVersion: "rbac.authorization.k8s.io/v1",
@@ -76,7 +76,7 @@ func getRole(namespace, name string, c Cache) (*rbac.Role, error) {
 		Name:       name,
 	}
 
-	roles, err := loadRoles(key, c)
+	roles, err := loadRoles(ctx, key, c)
 	if err != nil {
 		return nil, err
 	}
@@ -88,8 +88,8 @@ func getRole(namespace, name string, c Cache) (*rbac.Role, error) {
 	return roles[0], nil
 }
 
-func loadRoles(key CacheKey, c Cache) ([]*rbac.Role, error) {
-	objects, err := c.Retrieve(key)
+func loadRoles(ctx context.Context, key CacheKey, c Cache) ([]*rbac.Role, error) {
+	objects, err := c.Retrieve(ctx, key)
 	if err != nil {
 		return nil, err
 	}
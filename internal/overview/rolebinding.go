@@ -3,18 +3,20 @@ package overview
 import (
 	"context"
 	"github.com/pkg/errors"
-	"github.com/twosson/kubeapt/internal/content"
+	"github.com/twosson/kubeapt/pkg/content"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/clock"
 	"k8s.io/kubernetes/pkg/apis/rbac"
 )
 
-type RoleBindingSummary struct{}
+type RoleBindingSummary struct {
+	prefix string
+}
 
 var _ View = (*RoleBindingSummary)(nil)
 
 func NewRoleBindingSummary(prefix, namespace string, c clock.Clock) View {
-	return &RoleBindingSummary{}
+	return &RoleBindingSummary{prefix: prefix}
 }
 
 func (js *RoleBindingSummary) Content(ctx context.Context, object runtime.Object, c Cache) ([]content.Content, error) {
@@ -23,12 +25,12 @@ func (js *RoleBindingSummary) Content(ctx context.Context, object runtime.Object
 		return nil, err
 	}
 
-	role, err := getRole(roleBinding.GetNamespace(), roleBinding.RoleRef.Name, c)
+	role, err := getRole(ctx, roleBinding.GetNamespace(), roleBinding.RoleRef.Name, c)
 	if err != nil {
 		return nil, err
 	}
 
-	detail, err := printRoleBindingSummary(roleBinding, role)
+	detail, err := printRoleBindingSummary(js.prefix, roleBinding, role)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,126 @@
+package overview
+
+import (
+	"context"
+	"path"
+
+	"github.com/twosson/kubeapt/internal/apt"
+	"github.com/twosson/kubeapt/internal/log"
+	"github.com/twosson/kubeapt/pkg/content"
+)
+
+// searchWarmResources are loaded before searching when a search asks for
+// the cache to be warmed, so commonly used kinds are found before they have
+// been viewed.
+var searchWarmResources = []*Resource{
+	workloadsCronJobs,
+	workloadsDaemonSets,
+	workloadsDeployments,
+	workloadsJobs,
+	workloadsPods,
+	workloadsStatefulSets,
+	dlbIngresses,
+	dlbServices,
+	csConfigMaps,
+	csSecrets,
+}
+
+// searcher finds objects held by the cache using a search index.
+type searcher struct {
+	cache  *InformerCache
+	index  *searchIndex
+	logger log.Logger
+}
+
+func newSearcher(c *InformerCache, index *searchIndex, logger log.Logger) *searcher {
+	return &searcher{
+		cache:  c,
+		index:  index,
+		logger: logger,
+	}
+}
+
+// search finds objects which match a query. Only objects which are still
+// cached and have an overview page are returned.
+func (s *searcher) search(ctx context.Context, query apt.SearchQuery) ([]apt.SearchResult, error) {
+	if query.Warm {
+		s.warm(ctx, query.Namespace)
+	}
+
+	paths := s.resourcePaths(ctx)
+
+	results := []apt.SearchResult{}
+	for _, hit := range s.index.search(query.Query, query.Namespace) {
+		if query.Limit > 0 && len(results) >= query.Limit {
+			break
+		}
+
+		resourcePath, ok := paths[CacheKey{APIVersion: hit.key.APIVersion, Kind: hit.key.Kind}]
+		if !ok {
+			continue
+		}
+
+		// The index can be behind the cache, e.g. when an informer has
+		// been evicted but the notification hasn't been handled yet.
+		if _, ok := s.cache.cachedObject(hit.key); !ok {
+			continue
+		}
+
+		var link content.Text = content.NewLinkText(hit.key.Name, path.Join(apt.LinkRoot(query.Root), resourcePath, hit.key.Name))
+		if hit.key.Namespace != "" {
+			link = namespacedText(link, query.Namespace, hit.key.Namespace)
+		}
+
+		results = append(results, apt.SearchResult{
+			Link:       link.(*content.LinkText),
+			APIVersion: hit.key.APIVersion,
+			Kind:       hit.key.Kind,
+			Namespace:  hit.key.Namespace,
+			Matches:    hit.matches,
+			Score:      hit.score,
+		})
+	}
+
+	return results, nil
+}
+
+// warm loads commonly used kinds in a namespace and indexes them. Kinds
+// which can't be listed or are still loading are skipped; loading kinds are
+// indexed from cache notifications once they have synced.
+func (s *searcher) warm(ctx context.Context, namespace string) {
+	for _, r := range searchWarmResources {
+		key := r.CacheKey
+		key.Namespace = namespace
+
+		objects, err := s.cache.Retrieve(ctx, key)
+		if err != nil {
+			if !isForbidden(err) && !isLoading(err) {
+				s.logger.With("kind", key.Kind, "namespace", namespace).Errorf("warming search: %v", err)
+			}
+			continue
+		}
+
+		for _, object := range objects {
+			s.index.add(object)
+		}
+	}
+}
+
+// resourcePaths maps the API version and kind of objects to the path of
+// their overview pages.
+func (s *searcher) resourcePaths(ctx context.Context) map[CacheKey]string {
+	crds, err := listCustomResourceDefinitions(ctx, s.cache)
+	if err != nil {
+		if !isForbidden(err) && !isLoading(err) {
+			s.logger.Errorf("listing custom resource definitions: %v", err)
+		}
+		crds = nil
+	}
+
+	paths := make(map[CacheKey]string)
+	for resourcePath, key := range navigationResources(crds) {
+		paths[CacheKey{APIVersion: key.APIVersion, Kind: key.Kind}] = resourcePath
+	}
+
+	return paths
+}
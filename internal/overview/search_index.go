@@ -0,0 +1,439 @@
+package overview
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Fields of objects which are searched. Queries can be limited to a field
+// with a qualifier, e.g. image:nginx.
+const (
+	searchFieldName       = "name"
+	searchFieldLabel      = "label"
+	searchFieldLabelValue = "labelValue"
+	searchFieldAnnotation = "annotation"
+	searchFieldImage      = "image"
+	searchFieldOwner      = "owner"
+)
+
+// searchQualifiers maps query qualifiers to the fields they search.
+var searchQualifiers = map[string][]string{
+	"name":       {searchFieldName},
+	"label":      {searchFieldLabel, searchFieldLabelValue},
+	"annotation": {searchFieldAnnotation},
+	"image":      {searchFieldImage},
+	"owner":      {searchFieldOwner},
+}
+
+// Scores for matches. Names which start with a term score
+// searchScoreNamePrefix.
+const (
+	searchScoreName       = 100
+	searchScoreNamePrefix = 80
+)
+
+// searchScores are the scores for matching a term in a field. Labels and
+// annotations score higher when the term is a key=value pair.
+var searchScores = map[string]int{
+	searchFieldLabel:      30,
+	searchFieldLabelValue: 40,
+	searchFieldAnnotation: 20,
+	searchFieldImage:      60,
+	searchFieldOwner:      50,
+}
+
+const searchScorePair = 40
+
+// maxIndexedAnnotationLength is the longest annotation value which is
+// indexed. Longer values are usually documents, such as the last applied
+// configuration, which aren't useful to search.
+const maxIndexedAnnotationLength = 64
+
+// searchIgnoredKinds are kinds which aren't indexed. Events churn, and
+// aren't shown as objects.
+var searchIgnoredKinds = map[string]bool{
+	"Event": true,
+}
+
+// searchTerm is a value of a field of an object, e.g. the key=value pair of
+// a label. Values are lower case, so searches ignore case.
+type searchTerm struct {
+	field string
+	value string
+}
+
+// searchHit is an object which matched a search.
+type searchHit struct {
+	key     CacheKey
+	score   int
+	matches []string
+}
+
+// searchIndex is an inverted index of objects held by the cache, so objects
+// can be found without scanning every object of every kind. It is updated
+// from cache notifications.
+type searchIndex struct {
+	mu    sync.RWMutex
+	terms map[searchTerm]map[CacheKey]bool
+	docs  map[CacheKey][]searchTerm
+
+	// names is the sorted list of indexed names, which is searched for
+	// name prefixes. It is rebuilt when names are added or removed.
+	names      []string
+	namesDirty bool
+}
+
+func newSearchIndex() *searchIndex {
+	return &searchIndex{
+		terms: make(map[searchTerm]map[CacheKey]bool),
+		docs:  make(map[CacheKey][]searchTerm),
+	}
+}
+
+// update updates the index from a cache notification. A delete without a
+// name removes every object of its kind in its namespace, since the cache
+// sends one when it stops caching them.
+func (si *searchIndex) update(notification CacheNotification) {
+	key := notification.CacheKey
+	if searchIgnoredKinds[key.Kind] {
+		return
+	}
+
+	switch notification.Action {
+	case CacheDelete:
+		if key.Name == "" {
+			si.removeAll(key)
+			return
+		}
+		si.remove(key)
+	case CacheStore, CacheUpdate:
+		if notification.Object != nil {
+			si.add(notification.Object)
+		}
+	}
+}
+
+// add indexes an object, replacing it if it was already indexed.
+func (si *searchIndex) add(object *unstructured.Unstructured) {
+	if searchIgnoredKinds[object.GetKind()] {
+		return
+	}
+
+	key := searchKey(object)
+	terms := searchTerms(object)
+
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	si.removeLocked(key)
+
+	for _, term := range terms {
+		set, ok := si.terms[term]
+		if !ok {
+			set = make(map[CacheKey]bool)
+			si.terms[term] = set
+			if term.field == searchFieldName {
+				si.namesDirty = true
+			}
+		}
+		set[key] = true
+	}
+
+	si.docs[key] = terms
+}
+
+// remove removes an object from the index.
+func (si *searchIndex) remove(key CacheKey) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	si.removeLocked(key)
+}
+
+// removeAll removes every object of a kind in a namespace. An empty
+// namespace removes objects in every namespace.
+func (si *searchIndex) removeAll(key CacheKey) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	for docKey := range si.docs {
+		if docKey.APIVersion != key.APIVersion || docKey.Kind != key.Kind {
+			continue
+		}
+		if key.Namespace != "" && docKey.Namespace != key.Namespace {
+			continue
+		}
+		si.removeLocked(docKey)
+	}
+}
+
+// removeLocked removes an object. The index's lock must be held.
+func (si *searchIndex) removeLocked(key CacheKey) {
+	for _, term := range si.docs[key] {
+		set := si.terms[term]
+		delete(set, key)
+		if len(set) == 0 {
+			delete(si.terms, term)
+			if term.field == searchFieldName {
+				si.namesDirty = true
+			}
+		}
+	}
+
+	delete(si.docs, key)
+}
+
+// size returns the number of indexed objects.
+func (si *searchIndex) size() int {
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+
+	return len(si.docs)
+}
+
+// search finds objects which match every whitespace separated term in a
+// query, ordered by score. Objects are limited to a namespace unless it is
+// AllNamespaces; cluster scoped objects are always included.
+func (si *searchIndex) search(query, namespace string) []searchHit {
+	queryTerms := strings.Fields(strings.ToLower(query))
+	if len(queryTerms) == 0 {
+		return nil
+	}
+
+	si.rebuildNames()
+
+	si.mu.RLock()
+	defer si.mu.RUnlock()
+
+	var hits map[CacheKey]*searchHit
+
+	for _, queryTerm := range queryTerms {
+		matched := si.match(queryTerm)
+
+		next := make(map[CacheKey]*searchHit)
+		for key, hit := range matched {
+			if namespace != AllNamespaces && key.Namespace != "" && key.Namespace != namespace {
+				continue
+			}
+
+			if hits != nil {
+				previous, ok := hits[key]
+				if !ok {
+					continue
+				}
+				hit.score += previous.score
+				hit.matches = append(previous.matches, hit.matches...)
+			}
+
+			next[key] = hit
+		}
+
+		hits = next
+	}
+
+	var results []searchHit
+	for _, hit := range hits {
+		results = append(results, *hit)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.score != b.score {
+			return a.score > b.score
+		}
+		if a.key.Kind != b.key.Kind {
+			return a.key.Kind < b.key.Kind
+		}
+		if a.key.Namespace != b.key.Namespace {
+			return a.key.Namespace < b.key.Namespace
+		}
+		return a.key.Name < b.key.Name
+	})
+
+	return results
+}
+
+// match finds objects which match a single query term, keeping the best
+// scoring match for each object. The index's lock must be held.
+func (si *searchIndex) match(queryTerm string) map[CacheKey]*searchHit {
+	fields := []string{
+		searchFieldName,
+		searchFieldLabel,
+		searchFieldLabelValue,
+		searchFieldAnnotation,
+		searchFieldImage,
+		searchFieldOwner,
+	}
+
+	if i := strings.Index(queryTerm, ":"); i > 0 {
+		if qualified, ok := searchQualifiers[queryTerm[:i]]; ok {
+			fields = qualified
+			queryTerm = queryTerm[i+1:]
+		}
+	}
+
+	hits := make(map[CacheKey]*searchHit)
+	if queryTerm == "" {
+		return hits
+	}
+
+	record := func(term searchTerm, score int) {
+		match := fmt.Sprintf("%s %s", term.field, term.value)
+		if term.field == searchFieldLabelValue {
+			match = fmt.Sprintf("label value %s", term.value)
+		}
+
+		for key := range si.terms[term] {
+			if hit, ok := hits[key]; ok && hit.score >= score {
+				continue
+			}
+			hits[key] = &searchHit{key: key, score: score, matches: []string{match}}
+		}
+	}
+
+	for _, field := range fields {
+		if field == searchFieldName {
+			i := sort.SearchStrings(si.names, queryTerm)
+			for ; i < len(si.names) && strings.HasPrefix(si.names[i], queryTerm); i++ {
+				score := searchScoreNamePrefix
+				if si.names[i] == queryTerm {
+					score = searchScoreName
+				}
+				record(searchTerm{field: searchFieldName, value: si.names[i]}, score)
+			}
+			continue
+		}
+
+		score := searchScores[field]
+		if strings.Contains(queryTerm, "=") {
+			score += searchScorePair
+		}
+		record(searchTerm{field: field, value: queryTerm}, score)
+	}
+
+	return hits
+}
+
+// rebuildNames sorts the indexed names if they have changed.
+func (si *searchIndex) rebuildNames() {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+
+	if !si.namesDirty {
+		return
+	}
+
+	names := make([]string, 0, len(si.names))
+	for term := range si.terms {
+		if term.field == searchFieldName {
+			names = append(names, term.value)
+		}
+	}
+	sort.Strings(names)
+
+	si.names = names
+	si.namesDirty = false
+}
+
+// searchKey returns the key for an indexed object.
+func searchKey(object *unstructured.Unstructured) CacheKey {
+	return CacheKey{
+		Namespace:  object.GetNamespace(),
+		APIVersion: object.GetAPIVersion(),
+		Kind:       object.GetKind(),
+		Name:       object.GetName(),
+	}
+}
+
+// searchTerms returns the terms which describe an object: its name, labels,
+// short annotations, container images and owners.
+func searchTerms(object *unstructured.Unstructured) []searchTerm {
+	seen := make(map[searchTerm]bool)
+	var terms []searchTerm
+
+	add := func(field, value string) {
+		term := searchTerm{field: field, value: strings.ToLower(value)}
+		if term.value == "" || seen[term] {
+			return
+		}
+		seen[term] = true
+		terms = append(terms, term)
+	}
+
+	add(searchFieldName, object.GetName())
+
+	for k, v := range object.GetLabels() {
+		add(searchFieldLabel, k)
+		add(searchFieldLabel, k+"="+v)
+		add(searchFieldLabelValue, v)
+	}
+
+	for k, v := range object.GetAnnotations() {
+		add(searchFieldAnnotation, k)
+		if len(v) <= maxIndexedAnnotationLength {
+			add(searchFieldAnnotation, k+"="+v)
+		}
+	}
+
+	for _, image := range containerImages(object) {
+		repository := imageRepository(image)
+		add(searchFieldImage, image)
+		add(searchFieldImage, repository)
+		add(searchFieldImage, repository[strings.LastIndex(repository, "/")+1:])
+	}
+
+	for _, owner := range object.GetOwnerReferences() {
+		add(searchFieldOwner, owner.Name)
+		add(searchFieldOwner, owner.Kind+"/"+owner.Name)
+	}
+
+	return terms
+}
+
+// podSpecPaths are the paths to pod specs in objects which run containers.
+var podSpecPaths = [][]string{
+	{"spec"},
+	{"spec", "template", "spec"},
+	{"spec", "jobTemplate", "spec", "template", "spec"},
+}
+
+// containerImages returns the images of the containers run by an object.
+func containerImages(object *unstructured.Unstructured) []string {
+	var images []string
+
+	for _, specPath := range podSpecPaths {
+		for _, field := range []string{"initContainers", "containers"} {
+			containers, found, err := unstructured.NestedSlice(object.Object, append(specPath, field)...)
+			if err != nil || !found {
+				continue
+			}
+
+			for _, container := range containers {
+				m, ok := container.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if image, ok := m["image"].(string); ok && image != "" {
+					images = append(images, image)
+				}
+			}
+		}
+	}
+
+	return images
+}
+
+// imageRepository returns an image without its tag or digest.
+func imageRepository(image string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	return image
+}
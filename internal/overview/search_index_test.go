@@ -0,0 +1,204 @@
+package overview
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newSearchTestObject(apiVersion, kind, namespace, name string, labels map[string]string, images ...string) *unstructured.Unstructured {
+	u := newUnstructured(apiVersion, kind, namespace, name)
+	if labels != nil {
+		u.SetLabels(labels)
+	}
+
+	var containers []interface{}
+	for _, image := range images {
+		containers = append(containers, map[string]interface{}{"name": "app", "image": image})
+	}
+	if containers != nil {
+		path := []string{"spec", "containers"}
+		if kind == "Deployment" {
+			path = []string{"spec", "template", "spec", "containers"}
+		}
+		unstructured.SetNestedSlice(u.Object, containers, path...)
+	}
+
+	return u
+}
+
+func newSearchTestIndex() *searchIndex {
+	deployment := newSearchTestObject("apps/v1", "Deployment", "default", "web-frontend",
+		map[string]string{"app": "web", "tier": "frontend"}, "gcr.io/acme/nginx:1.15")
+	deployment.SetAnnotations(map[string]string{"owner-team": "payments"})
+
+	pod := newSearchTestObject("v1", "Pod", "default", "web-frontend-abc",
+		map[string]string{"app": "web"}, "nginx@sha256:abc")
+	pod.SetOwnerReferences([]metav1.OwnerReference{{Kind: "ReplicaSet", Name: "web-frontend-123"}})
+
+	index := newSearchIndex()
+	for _, object := range []*unstructured.Unstructured{
+		deployment,
+		pod,
+		newSearchTestObject("v1", "Service", "default", "web", map[string]string{"app": "web"}),
+		newSearchTestObject("apps/v1", "Deployment", "other", "web-frontend", nil, "redis"),
+		newSearchTestObject("v1", "Node", "", "web-node", nil),
+		newSearchTestObject("v1", "Event", "default", "web-frontend.1", nil),
+	} {
+		index.add(object)
+	}
+
+	return index
+}
+
+func hitNames(hits []searchHit) []string {
+	var names []string
+	for _, hit := range hits {
+		names = append(names, hit.key.Kind+":"+hit.key.Namespace+"/"+hit.key.Name)
+	}
+	return names
+}
+
+func Test_searchIndex_search(t *testing.T) {
+	cases := []struct {
+		name      string
+		query     string
+		namespace string
+		expected  []string
+	}{
+		{
+			name:      "name prefix",
+			query:     "web",
+			namespace: "default",
+			expected: []string{
+				"Service:default/web",
+				"Deployment:default/web-frontend",
+				"Node:/web-node",
+				"Pod:default/web-frontend-abc",
+			},
+		},
+		{
+			name:      "name prefix in every namespace",
+			query:     "WEB-FRONTEND",
+			namespace: AllNamespaces,
+			expected: []string{
+				"Deployment:default/web-frontend",
+				"Deployment:other/web-frontend",
+				"Pod:default/web-frontend-abc",
+			},
+		},
+		{
+			name:      "label",
+			query:     "app=web",
+			namespace: "default",
+			expected: []string{
+				"Deployment:default/web-frontend",
+				"Pod:default/web-frontend-abc",
+				"Service:default/web",
+			},
+		},
+		{
+			name:      "label value",
+			query:     "label:frontend",
+			namespace: "default",
+			expected:  []string{"Deployment:default/web-frontend"},
+		},
+		{
+			name:      "image repository",
+			query:     "image:nginx",
+			namespace: "default",
+			expected:  []string{"Deployment:default/web-frontend", "Pod:default/web-frontend-abc"},
+		},
+		{
+			name:      "image in every namespace",
+			query:     "redis",
+			namespace: AllNamespaces,
+			expected:  []string{"Deployment:other/web-frontend"},
+		},
+		{
+			name:      "owner",
+			query:     "owner:replicaset/web-frontend-123",
+			namespace: "default",
+			expected:  []string{"Pod:default/web-frontend-abc"},
+		},
+		{
+			name:      "annotation",
+			query:     "owner-team=payments",
+			namespace: "default",
+			expected:  []string{"Deployment:default/web-frontend"},
+		},
+		{
+			name:      "every term matches",
+			query:     "web tier=frontend",
+			namespace: "default",
+			expected:  []string{"Deployment:default/web-frontend"},
+		},
+		{
+			name:      "no matches",
+			query:     "missing",
+			namespace: "default",
+		},
+		{
+			name:      "empty query",
+			query:     " ",
+			namespace: "default",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			index := newSearchTestIndex()
+
+			got := index.search(tc.query, tc.namespace)
+			assert.Equal(t, tc.expected, hitNames(got))
+		})
+	}
+}
+
+func Test_searchIndex_search_matches(t *testing.T) {
+	index := newSearchTestIndex()
+
+	hits := index.search("web-frontend image:nginx", "default")
+	require.Len(t, hits, 2)
+
+	assert.Equal(t, "web-frontend", hits[0].key.Name)
+	assert.Equal(t, searchScoreName+searchScores[searchFieldImage], hits[0].score)
+	assert.Equal(t, []string{"name web-frontend", "image nginx"}, hits[0].matches)
+}
+
+func Test_searchIndex_update(t *testing.T) {
+	index := newSearchTestIndex()
+	require.Equal(t, 5, index.size())
+
+	updated := newSearchTestObject("v1", "Service", "default", "web", map[string]string{"app": "api"})
+	index.update(CacheNotification{CacheKey: searchKey(updated), Action: CacheUpdate, Object: updated})
+
+	assert.Equal(t,
+		[]string{"Deployment:default/web-frontend", "Pod:default/web-frontend-abc"},
+		hitNames(index.search("app=web", "default")))
+	assert.Equal(t, []string{"Service:default/web"}, hitNames(index.search("app=api", "default")))
+
+	index.update(CacheNotification{CacheKey: searchKey(updated), Action: CacheDelete})
+	assert.Equal(t, []string{"Node:/web-node"}, hitNames(index.search("web-n", "default")))
+	assert.Empty(t, index.search("app=api", "default"))
+
+	// An evicted informer removes every object of its kind in its namespace.
+	index.update(CacheNotification{
+		CacheKey: CacheKey{Namespace: "other", APIVersion: "apps/v1", Kind: "Deployment"},
+		Action:   CacheDelete,
+	})
+	assert.Equal(t,
+		[]string{"Deployment:default/web-frontend", "Pod:default/web-frontend-abc"},
+		hitNames(index.search("web-frontend", AllNamespaces)))
+	assert.Equal(t, 3, index.size())
+}
+
+func Test_imageRepository(t *testing.T) {
+	assert.Equal(t, "nginx", imageRepository("nginx"))
+	assert.Equal(t, "gcr.io/acme/nginx", imageRepository("gcr.io/acme/nginx:1.15"))
+	assert.Equal(t, "localhost:5000/nginx", imageRepository("localhost:5000/nginx"))
+	assert.Equal(t, "nginx", imageRepository("nginx@sha256:abc"))
+}
@@ -0,0 +1,119 @@
+package overview
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twosson/kubeapt/internal/apt"
+	"github.com/twosson/kubeapt/internal/cluster/fake"
+	"github.com/twosson/kubeapt/internal/log"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func newTestSearcher(t *testing.T, stopCh <-chan struct{}) *searcher {
+	objects := []runtime.Object{
+		newSearchTestObject("apps/v1", "Deployment", "default", "web", map[string]string{"app": "web"}, "nginx:1.15"),
+		newSearchTestObject("v1", "Service", "default", "web", map[string]string{"app": "web"}),
+		newSearchTestObject("apps/v1", "Deployment", "other", "web-api", nil, "nginx:1.14"),
+	}
+
+	clusterClient, err := fake.NewClient(newScheme(), resources, objects)
+	require.NoError(t, err)
+
+	restMapper, err := clusterClient.RESTMapper()
+	require.NoError(t, err)
+
+	c := NewInformerCache(stopCh, clusterClient.FakeDynamic, restMapper)
+
+	return newSearcher(c, newSearchIndex(), log.NopLogger())
+}
+
+func resultRefs(results []apt.SearchResult) []string {
+	var refs []string
+	for _, result := range results {
+		refs = append(refs, result.Link.Ref)
+	}
+	return refs
+}
+
+func Test_searcher_search(t *testing.T) {
+	cases := []struct {
+		name     string
+		query    apt.SearchQuery
+		expected []string
+	}{
+		{
+			name:     "warm namespace",
+			query:    apt.SearchQuery{Query: "web", Namespace: "default", Warm: true, Root: "/api/v1/content/overview"},
+			expected: []string{"/content/overview/workloads/deployments/web", "/content/overview/discovery-and-load-balancing/services/web"},
+		},
+		{
+			name:     "limit and cluster root",
+			query:    apt.SearchQuery{Query: "web", Namespace: "default", Limit: 1, Warm: true, Root: "/api/v1/clusters/staging/content/overview"},
+			expected: []string{"/clusters/staging/content/overview/workloads/deployments/web"},
+		},
+		{
+			name:  "warm every namespace",
+			query: apt.SearchQuery{Query: "image:nginx", Namespace: AllNamespaces, Warm: true, Root: "/api/v1/content/overview"},
+			expected: []string{
+				"/content/overview/workloads/deployments/web?namespace=default",
+				"/content/overview/workloads/deployments/web-api?namespace=other",
+			},
+		},
+		{
+			name:  "cold cache",
+			query: apt.SearchQuery{Query: "web", Namespace: "default"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+
+			s := newTestSearcher(t, stopCh)
+
+			results, err := s.search(context.Background(), tc.query)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, resultRefs(results))
+		})
+	}
+}
+
+func Test_searcher_search_uncached(t *testing.T) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	s := newTestSearcher(t, stopCh)
+
+	// Objects which are indexed, but no longer cached, aren't found.
+	s.index.add(newSearchTestObject("apps/v1", "Deployment", "default", "gone", nil))
+
+	results, err := s.search(context.Background(), apt.SearchQuery{Query: "gone", Namespace: "default", Warm: true})
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func Test_searcher_search_result(t *testing.T) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	s := newTestSearcher(t, stopCh)
+
+	results, err := s.search(context.Background(), apt.SearchQuery{Query: "app=web", Namespace: "default", Warm: true})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	assert.Equal(t, apt.SearchResult{
+		Link:       results[0].Link,
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Namespace:  "default",
+		Matches:    []string{"label app=web"},
+		Score:      searchScores[searchFieldLabel] + searchScorePair,
+	}, results[0])
+	assert.Equal(t, "web", results[0].Link.Text)
+}
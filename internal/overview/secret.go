@@ -4,7 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/pkg/errors"
-	"github.com/twosson/kubeapt/internal/content"
+	"github.com/twosson/kubeapt/pkg/content"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/clock"
 	"k8s.io/kubernetes/pkg/apis/core"
@@ -87,18 +87,18 @@ func retrieveSecret(object runtime.Object) (*core.Secret, error) {
 	return rc, nil
 }
 
-func listSecrets(namespace string, c Cache) ([]*core.Secret, error) {
+func listSecrets(ctx context.Context, namespace string, c Cache) ([]*core.Secret, error) {
 	key := CacheKey{
 		Namespace:  namespace,
 		APIVersion: "v1",
 		Kind:       "Secret",
 	}
 
-	return loadSecrets(key, c)
+	return loadSecrets(ctx, key, c)
 }
 
-func loadSecrets(key CacheKey, c Cache) ([]*core.Secret, error) {
-	objects, err := c.Retrieve(key)
+func loadSecrets(ctx context.Context, key CacheKey, c Cache) ([]*core.Secret, error) {
+	objects, err := c.Retrieve(ctx, key)
 	if err != nil {
 		return nil, err
 	}
@@ -4,18 +4,18 @@ import (
 	"context"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"github.com/twosson/kubeapt/internal/content"
+	"github.com/twosson/kubeapt/pkg/content"
 	"k8s.io/apimachinery/pkg/util/clock"
 	"testing"
 	"time"
 )
 
 func TestSecretData_InvalidObject(t *testing.T) {
-	assertViewInvalidObject(t, NewSecretData("prefix", "ns", clock.NewFakeClock(time.Now())))
+	assertViewInvalidObject(t, NewSecretData(testPrefix, "ns", clock.NewFakeClock(time.Now())))
 }
 
 func TestSecretData(t *testing.T) {
-	v := NewSecretData("prefix", "ns", clock.NewFakeClock(time.Now()))
+	v := NewSecretData(testPrefix, "ns", clock.NewFakeClock(time.Now()))
 
 	ctx := context.Background()
 	cache := NewMemoryCache()
@@ -0,0 +1,167 @@
+package overview
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/twosson/kubeapt/pkg/content"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// listSelectors filter the objects shown by list views. Selectors are
+// stored in their canonical form.
+type listSelectors struct {
+	label string
+	field string
+}
+
+type listSelectorsKey struct{}
+
+// withListSelectors returns a context which filters list views.
+func withListSelectors(ctx context.Context, selectors listSelectors) context.Context {
+	return context.WithValue(ctx, listSelectorsKey{}, selectors)
+}
+
+func listSelectorsFromContext(ctx context.Context) listSelectors {
+	selectors, _ := ctx.Value(listSelectorsKey{}).(listSelectors)
+	return selectors
+}
+
+// listSelectorsFromQuery parses the labelSelector and fieldSelector query
+// parameters.
+func listSelectorsFromQuery(query url.Values) (listSelectors, error) {
+	var selectors listSelectors
+
+	if s := strings.TrimSpace(query.Get("labelSelector")); s != "" {
+		selector, err := labels.Parse(s)
+		if err != nil {
+			return listSelectors{}, errors.Wrap(err, "invalid label selector")
+		}
+		selectors.label = selector.String()
+	}
+
+	if s := strings.TrimSpace(query.Get("fieldSelector")); s != "" {
+		selector, err := fields.ParseSelector(s)
+		if err != nil {
+			return listSelectors{}, errors.Wrap(err, "invalid field selector")
+		}
+		selectors.field = selector.String()
+	}
+
+	return selectors, nil
+}
+
+func (s listSelectors) isEmpty() bool {
+	return s.label == "" && s.field == ""
+}
+
+// tableFilters describes the selectors, so they can be shown with a table.
+func (s listSelectors) tableFilters() *content.TableFilters {
+	if s.isEmpty() {
+		return nil
+	}
+
+	return &content.TableFilters{
+		LabelSelector: s.label,
+		FieldSelector: s.field,
+	}
+}
+
+// emptyMessage qualifies a message for an empty list with the selectors.
+func (s listSelectors) emptyMessage(message string) string {
+	if s.isEmpty() {
+		return message
+	}
+
+	var parts []string
+	if s.label != "" {
+		parts = append(parts, fmt.Sprintf("labels %q", s.label))
+	}
+	if s.field != "" {
+		parts = append(parts, fmt.Sprintf("fields %q", s.field))
+	}
+
+	return fmt.Sprintf("%s matching %s", message, strings.Join(parts, " and "))
+}
+
+// setTableFilters shows the selectors with tables in contents.
+func setTableFilters(contents []content.Content, selectors listSelectors) {
+	filters := selectors.tableFilters()
+	if filters == nil {
+		return
+	}
+
+	for _, c := range contents {
+		if tbl, ok := c.(*content.Table); ok {
+			tbl.Filters = filters
+			tbl.EmptyContent = selectors.emptyMessage(tbl.EmptyContent)
+		}
+	}
+}
+
+// cacheKeySelectors parses the selectors in a cache key. Selectors which
+// aren't set select everything.
+func cacheKeySelectors(key CacheKey) (labels.Selector, fields.Selector, error) {
+	labelSelector := labels.Everything()
+	if key.LabelSelector != "" {
+		selector, err := labels.Parse(key.LabelSelector)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "parsing label selector")
+		}
+		labelSelector = selector
+	}
+
+	fieldSelector := fields.Everything()
+	if key.FieldSelector != "" {
+		selector, err := fields.ParseSelector(key.FieldSelector)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "parsing field selector")
+		}
+		fieldSelector = selector
+	}
+
+	return labelSelector, fieldSelector, nil
+}
+
+// matchesFields reports whether an object matches a field selector. Fields
+// are paths into the object, e.g. metadata.name or status.phase.
+func matchesFields(object *unstructured.Unstructured, selector fields.Selector) bool {
+	if selector.Empty() {
+		return true
+	}
+
+	set := fields.Set{}
+	for _, requirement := range selector.Requirements() {
+		path := strings.Split(requirement.Field, ".")
+
+		value, found, err := unstructured.NestedFieldNoCopy(object.Object, path...)
+		if err != nil || !found || value == nil {
+			continue
+		}
+
+		set[requirement.Field] = fmt.Sprintf("%v", value)
+	}
+
+	return selector.Matches(set)
+}
+
+// filterFields returns the objects which match a field selector.
+func filterFields(objects []*unstructured.Unstructured, selector fields.Selector) []*unstructured.Unstructured {
+	if selector.Empty() {
+		return objects
+	}
+
+	var matched []*unstructured.Unstructured
+	for _, object := range objects {
+		if matchesFields(object, selector) {
+			matched = append(matched, object)
+		}
+	}
+
+	return matched
+}
@@ -0,0 +1,104 @@
+package overview
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twosson/kubeapt/pkg/content"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+func Test_listSelectorsFromQuery(t *testing.T) {
+	cases := []struct {
+		name     string
+		query    url.Values
+		expected listSelectors
+		isErr    bool
+	}{
+		{
+			name:  "none",
+			query: url.Values{},
+		},
+		{
+			name: "label and field",
+			query: url.Values{
+				"labelSelector": []string{"app=checkout,tier in (web)"},
+				"fieldSelector": []string{"status.phase=Running"},
+			},
+			expected: listSelectors{label: "app=checkout,tier in (web)", field: "status.phase=Running"},
+		},
+		{
+			name:  "invalid label selector",
+			query: url.Values{"labelSelector": []string{"app in (web"}},
+			isErr: true,
+		},
+		{
+			name:  "invalid field selector",
+			query: url.Values{"fieldSelector": []string{"status.phase"}},
+			isErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := listSelectorsFromQuery(tc.query)
+			if tc.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func Test_listSelectorsFromContext(t *testing.T) {
+	assert.True(t, listSelectorsFromContext(context.Background()).isEmpty())
+
+	selectors := listSelectors{label: "app=checkout"}
+	ctx := withListSelectors(context.Background(), selectors)
+	assert.Equal(t, selectors, listSelectorsFromContext(ctx))
+}
+
+func Test_matchesFields(t *testing.T) {
+	pod := newUnstructured("v1", "Pod", "default", "pod")
+	pod.Object["status"] = map[string]interface{}{"phase": "Running"}
+
+	cases := []struct {
+		selector string
+		expected bool
+	}{
+		{selector: "metadata.name=pod", expected: true},
+		{selector: "metadata.name=pod,status.phase=Running", expected: true},
+		{selector: "status.phase!=Running", expected: false},
+		{selector: "spec.nodeName=node", expected: false},
+		{selector: "spec.nodeName!=node", expected: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.selector, func(t *testing.T) {
+			selector, err := fields.ParseSelector(tc.selector)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, matchesFields(pod, selector))
+		})
+	}
+}
+
+func Test_setTableFilters(t *testing.T) {
+	tbl := content.NewTable("Pods", "Namespace default does not have any pods")
+	contents := []content.Content{&tbl}
+
+	setTableFilters(contents, listSelectors{})
+	assert.Nil(t, tbl.Filters)
+
+	setTableFilters(contents, listSelectors{label: "app=checkout", field: "status.phase=Running"})
+
+	expected := &content.TableFilters{LabelSelector: "app=checkout", FieldSelector: "status.phase=Running"}
+	assert.Equal(t, expected, tbl.Filters)
+	assert.Equal(t, `Namespace default does not have any pods matching labels "app=checkout" and fields "status.phase=Running"`, tbl.EmptyContent)
+}
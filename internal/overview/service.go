@@ -4,7 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/pkg/errors"
-	"github.com/twosson/kubeapt/internal/content"
+	"github.com/twosson/kubeapt/pkg/content"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/clock"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -101,7 +101,7 @@ func (js *ServiceEndpoints) Content(ctx context.Context, object runtime.Object,
 		return nil, err
 	}
 
-	endpoints, err := listEndpoints(ss.GetNamespace(), ss.GetName(), c)
+	endpoints, err := listEndpoints(ctx, ss.GetNamespace(), ss.GetName(), c)
 	if err != nil {
 		return nil, err
 	}
@@ -117,7 +117,7 @@ func (js *ServiceEndpoints) Content(ctx context.Context, object runtime.Object,
 		Kind:       "Pod",
 	}
 
-	pods, err := loadPods(podKey, c, nil)
+	pods, err := loadPods(ctx, podKey, c, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -177,7 +177,7 @@ func retrieveService(object runtime.Object) (*core.Service, error) {
 	return rc, nil
 }
 
-func listEndpoints(namespace string, name string, c Cache) ([]*core.Endpoints, error) {
+func listEndpoints(ctx context.Context, namespace string, name string, c Cache) ([]*core.Endpoints, error) {
 	key := CacheKey{
 		Namespace:  namespace,
 		APIVersion: "v1",
@@ -185,11 +185,11 @@ func listEndpoints(namespace string, name string, c Cache) ([]*core.Endpoints, e
 		Name:       name,
 	}
 
-	return loadEndpoints(key, c)
+	return loadEndpoints(ctx, key, c)
 }
 
-func loadEndpoints(key CacheKey, c Cache) ([]*core.Endpoints, error) {
-	objects, err := c.Retrieve(key)
+func loadEndpoints(ctx context.Context, key CacheKey, c Cache) ([]*core.Endpoints, error) {
+	objects, err := c.Retrieve(ctx, key)
 	if err != nil {
 		return nil, err
 	}
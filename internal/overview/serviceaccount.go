@@ -3,7 +3,7 @@ package overview
 import (
 	"context"
 	"github.com/pkg/errors"
-	"github.com/twosson/kubeapt/internal/content"
+	"github.com/twosson/kubeapt/pkg/content"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/clock"
@@ -11,12 +11,14 @@ import (
 	"k8s.io/kubernetes/pkg/apis/core"
 )
 
-type ServiceAccountSummary struct{}
+type ServiceAccountSummary struct {
+	prefix string
+}
 
 var _ View = (*ServiceAccountSummary)(nil)
 
 func NewServiceAccountSummary(prefix, namespace string, c clock.Clock) View {
-	return &ServiceAccountSummary{}
+	return &ServiceAccountSummary{prefix: prefix}
 }
 
 func (js *ServiceAccountSummary) Content(ctx context.Context, object runtime.Object, c Cache) ([]content.Content, error) {
@@ -30,7 +32,7 @@ func (js *ServiceAccountSummary) Content(ctx context.Context, object runtime.Obj
 	// missingSecrets is the set of all secrets present in the
 	// serviceAccount but not present in the set of existing secrets.
 	missingSecrets := sets.NewString()
-	secrets, err := listSecrets(serviceAccount.GetNamespace(), c)
+	secrets, err := listSecrets(ctx, serviceAccount.GetNamespace(), c)
 	if err != nil {
 		return nil, err
 	}
@@ -61,7 +63,7 @@ func (js *ServiceAccountSummary) Content(ctx context.Context, object runtime.Obj
 		}
 	}
 
-	detail, err := printServiceAccountSummary(serviceAccount, tokens, missingSecrets)
+	detail, err := printServiceAccountSummary(js.prefix, serviceAccount, tokens, missingSecrets)
 	if err != nil {
 		return nil, err
 	}
@@ -3,18 +3,20 @@ package overview
 import (
 	"context"
 	"github.com/pkg/errors"
-	"github.com/twosson/kubeapt/internal/content"
+	"github.com/twosson/kubeapt/pkg/content"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/clock"
 	"k8s.io/kubernetes/pkg/apis/apps"
 )
 
-type StatefulSetSummary struct{}
+type StatefulSetSummary struct {
+	prefix string
+}
 
 var _ View = (*StatefulSetSummary)(nil)
 
 func NewStatefulSetSummary(prefix, namespace string, c clock.Clock) View {
-	return &StatefulSetSummary{}
+	return &StatefulSetSummary{prefix: prefix}
 }
 
 func (js *StatefulSetSummary) Content(ctx context.Context, object runtime.Object, c Cache) ([]content.Content, error) {
@@ -23,12 +25,17 @@ func (js *StatefulSetSummary) Content(ctx context.Context, object runtime.Object
 		return nil, err
 	}
 
-	pods, err := listPods(ss.GetNamespace(), ss.Spec.Selector, ss.GetUID(), c)
+	pods, err := listPods(ctx, ss.GetNamespace(), ss.Spec.Selector, ss.GetUID(), c)
+	if err != nil {
+		return nil, err
+	}
+
+	hpas, err := findManagingHorizontalPodAutoscalers(ctx, c, ss.GetNamespace(), "StatefulSet", ss.GetName())
 	if err != nil {
 		return nil, err
 	}
 
-	detail, err := printStatefulSetSummary(ss, pods)
+	detail, err := printStatefulSetSummary(js.prefix, ss, pods, hpas)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,45 @@
+package overview
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/twosson/kubeapt/pkg/content"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/kubernetes/pkg/apis/storage"
+)
+
+type StorageClassSummary struct{}
+
+var _ View = (*StorageClassSummary)(nil)
+
+func NewStorageClassSummary(prefix, namespace string, c clock.Clock) View {
+	return &StorageClassSummary{}
+}
+
+func (scs *StorageClassSummary) Content(ctx context.Context, object runtime.Object, c Cache) ([]content.Content, error) {
+	storageClass, err := retrieveStorageClass(object)
+	if err != nil {
+		return nil, err
+	}
+
+	detail, err := printStorageClassSummary(storageClass)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := content.NewSummary("Details", []content.Section{detail})
+	return []content.Content{
+		&summary,
+	}, nil
+}
+
+func retrieveStorageClass(object runtime.Object) (*storage.StorageClass, error) {
+	storageClass, ok := object.(*storage.StorageClass)
+	if !ok {
+		return nil, errors.Errorf("expected object to be a Storage Class, it was %T", object)
+	}
+
+	return storageClass, nil
+}
@@ -1,63 +1,172 @@
 package overview
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/twosson/kubeapt/internal/log"
 	"net/http"
+	"strconv"
 	"time"
 )
 
 const (
-	defaultEventTimeout = 5 * time.Second
+	// defaultStreamDebounce is how long a stream waits after a cache
+	// notification before regenerating content. Notifications received
+	// during this window are coalesced.
+	defaultStreamDebounce = 500 * time.Millisecond
+
+	// defaultStreamRefresh is how often a stream regenerates content
+	// without a cache notification, so ages and usage stay current.
+	defaultStreamRefresh = 30 * time.Second
+
+	// minStreamRefresh is the shortest refresh a client can request.
+	minStreamRefresh = 5 * time.Second
 )
 
 type streamFn func(ctx context.Context, w http.ResponseWriter, ch chan []byte)
 
 type contentStreamer struct {
-	generator    generator
-	w            http.ResponseWriter
-	path         string
-	prefix       string
-	namespace    string
-	streamFn     streamFn
-	eventTimeout time.Duration
-	logger       log.Logger
+	generator generator
+	w         http.ResponseWriter
+	path      string
+	prefix    string
+	namespace string
+	streamFn  streamFn
+	// updates is signaled when content might have changed.
+	updates <-chan struct{}
+	// namespaces receives the new namespace when the module's namespace
+	// changes.
+	namespaces <-chan string
+	debounce   time.Duration
+	// refresh is how often content is regenerated without updates. Content
+	// is only regenerated on updates if it is zero.
+	refresh time.Duration
+	logger  log.Logger
+}
+
+// streamRefresh returns the refresh interval requested by a poll query
+// parameter in seconds. Requests for less than minStreamRefresh are raised
+// to it, and invalid requests get defaultStreamRefresh.
+func streamRefresh(poll string) time.Duration {
+	seconds, err := strconv.Atoi(poll)
+	if err != nil || seconds <= 0 {
+		return defaultStreamRefresh
+	}
+
+	refresh := time.Duration(seconds) * time.Second
+	if refresh < minStreamRefresh {
+		return minStreamRefresh
+	}
+	return refresh
 }
 
+// namespaceChangedEvent is sent before a content stream is closed because
+// the namespace changed.
+const namespaceChangedEvent = "namespaceChanged"
+
+type namespaceChangedData struct {
+	Namespace string `json:"namespace"`
+}
+
+// content generates content once, and again after updates are signaled and
+// every refresh interval. Content is only sent if it differs from what was
+// last sent. If the namespace changes to one other than the stream's
+// namespace, the stream sends a namespaceChanged event and closes, so the
+// client can reopen it for the new namespace.
 func (cs contentStreamer) content(ctx context.Context) {
-	ch := make(chan []byte, 1)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
-	timer := time.NewTimer(0)
+	ch := make(chan []byte, 1)
+	changed := make(chan string, 1)
 
 	go func() {
-		isRunning := true
-		for isRunning {
+		// Generate the initial content immediately.
+		timer := time.NewTimer(0)
+		defer timer.Stop()
+		pending := true
+
+		var refresh <-chan time.Time
+		if cs.refresh > 0 {
+			ticker := time.NewTicker(cs.refresh)
+			defer ticker.Stop()
+			refresh = ticker.C
+		}
+
+		var last []byte
+
+		for {
 			select {
 			case <-ctx.Done():
-				isRunning = false
+				return
+			case namespace := <-cs.namespaces:
+				if namespace == cs.namespace {
+					continue
+				}
+				changed <- namespace
+				cancel()
+				return
+			case <-cs.updates:
+				if !pending {
+					timer.Reset(cs.debounce)
+					pending = true
+				}
+			case <-refresh:
+				if !pending {
+					timer.Reset(0)
+					pending = true
+				}
 			case <-timer.C:
+				pending = false
+
 				cResponse, err := cs.generator.Generate(ctx, cs.path, cs.prefix, cs.namespace)
 				if err != nil {
 					cs.logger.Errorf("generate error: %v", err)
+					continue
 				}
 
-				cr := &cResponse
-
-				data, err := json.Marshal(cr)
+				data, err := json.Marshal(&cResponse)
 				if err != nil {
 					cs.logger.Errorf("marshal err: %v", err)
+					continue
 				}
 
-				ch <- data
+				if bytes.Equal(data, last) {
+					continue
+				}
+				last = data
 
-				timer.Reset(cs.eventTimeout)
+				select {
+				case ch <- data:
+				case <-ctx.Done():
+					return
+				}
 			}
 		}
 	}()
 
 	cs.streamFn(ctx, cs.w, ch)
+
+	select {
+	case namespace := <-changed:
+		data, err := json.Marshal(&namespaceChangedData{Namespace: namespace})
+		if err != nil {
+			cs.logger.Errorf("marshal err: %v", err)
+			return
+		}
+		writeStreamEvent(cs.w, namespaceChangedEvent, data)
+	default:
+	}
+}
+
+// writeStreamEvent writes a named server sent event.
+func writeStreamEvent(w http.ResponseWriter, name string, data []byte) {
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, string(data))
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
 }
 
 func stream(ctx context.Context, w http.ResponseWriter, ch chan []byte) {
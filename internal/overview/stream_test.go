@@ -5,11 +5,14 @@ import (
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"github.com/twosson/kubeapt/internal/content"
+	"github.com/twosson/kubeapt/internal/log"
+	"github.com/twosson/kubeapt/pkg/content"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func Test_contentStreamer(t *testing.T) {
@@ -43,6 +46,186 @@ func Test_contentStreamer(t *testing.T) {
 	cancel()
 }
 
+func Test_contentStreamer_updates(t *testing.T) {
+	w := httptest.NewRecorder()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var generated int32
+	g := generatorFunc(func(ctx context.Context, path, prefix, namespace string) (ContentResponse, error) {
+		count := atomic.AddInt32(&generated, 1)
+		// The second generation returns the same content as the first.
+		title := fmt.Sprintf("title %d", count)
+		if count == 2 {
+			title = "title 1"
+		}
+		return ContentResponse{Title: title}, nil
+	})
+
+	updates := make(chan struct{}, 1)
+	received := make(chan string, 3)
+
+	fn := func(ctx context.Context, w http.ResponseWriter, ch chan []byte) {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg := <-ch:
+				received <- string(msg)
+			}
+		}
+	}
+
+	cs := contentStreamer{
+		generator: g,
+		w:         w,
+		path:      "/real/foo",
+		prefix:    "/real",
+		namespace: "default",
+		streamFn:  fn,
+		updates:   updates,
+		logger:    log.NopLogger(),
+	}
+
+	go cs.content(ctx)
+
+	assert.Equal(t, `{"title":"title 1"}`, <-received)
+
+	// Unchanged content is not sent.
+	updates <- struct{}{}
+	for atomic.LoadInt32(&generated) < 2 {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	updates <- struct{}{}
+	assert.Equal(t, `{"title":"title 3"}`, <-received)
+}
+
+func Test_contentStreamer_refresh(t *testing.T) {
+	w := httptest.NewRecorder()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var generated int32
+	g := generatorFunc(func(ctx context.Context, path, prefix, namespace string) (ContentResponse, error) {
+		count := atomic.AddInt32(&generated, 1)
+		return ContentResponse{Title: fmt.Sprintf("title %d", count)}, nil
+	})
+
+	received := make(chan string, 2)
+
+	fn := func(ctx context.Context, w http.ResponseWriter, ch chan []byte) {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg := <-ch:
+				received <- string(msg)
+			}
+		}
+	}
+
+	cs := contentStreamer{
+		generator: g,
+		w:         w,
+		path:      "/real/foo",
+		prefix:    "/real",
+		namespace: "default",
+		streamFn:  fn,
+		refresh:   10 * time.Millisecond,
+		logger:    log.NopLogger(),
+	}
+
+	go cs.content(ctx)
+
+	assert.Equal(t, `{"title":"title 1"}`, <-received)
+
+	// Content is regenerated without updates.
+	select {
+	case msg := <-received:
+		assert.Equal(t, `{"title":"title 2"}`, msg)
+	case <-time.After(time.Second):
+		t.Fatal("content was not refreshed")
+	}
+}
+
+func Test_streamRefresh(t *testing.T) {
+	cases := []struct {
+		poll     string
+		expected time.Duration
+	}{
+		{poll: "", expected: defaultStreamRefresh},
+		{poll: "invalid", expected: defaultStreamRefresh},
+		{poll: "0", expected: defaultStreamRefresh},
+		{poll: "1", expected: minStreamRefresh},
+		{poll: "60", expected: time.Minute},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.poll, func(t *testing.T) {
+			assert.Equal(t, tc.expected, streamRefresh(tc.poll))
+		})
+	}
+}
+
+func Test_contentStreamer_namespaceChanged(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	g := generatorFunc(func(ctx context.Context, path, prefix, namespace string) (ContentResponse, error) {
+		return ContentResponse{Title: namespace}, nil
+	})
+
+	namespaces := make(chan string, 1)
+	received := make(chan string, 1)
+
+	fn := func(ctx context.Context, w http.ResponseWriter, ch chan []byte) {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg := <-ch:
+				received <- string(msg)
+			}
+		}
+	}
+
+	cs := contentStreamer{
+		generator:  g,
+		w:          w,
+		path:       "/real/foo",
+		prefix:     "/real",
+		namespace:  "default",
+		streamFn:   fn,
+		namespaces: namespaces,
+		logger:     log.NopLogger(),
+	}
+
+	done := make(chan bool)
+	go func() {
+		cs.content(context.Background())
+		done <- true
+	}()
+
+	assert.Equal(t, `{"title":"default"}`, <-received)
+
+	// The stream's own namespace doesn't close it.
+	namespaces <- "default"
+	select {
+	case <-done:
+		t.Fatal("stream closed for its own namespace")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	namespaces <- "other"
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for stream to close")
+	}
+
+	assert.Equal(t, "event: namespaceChanged\ndata: {\"namespace\":\"other\"}\n\n", w.Body.String())
+}
+
 func Test_stream(t *testing.T) {
 	w := httptest.NewRecorder()
 	ctx, cancel := context.WithCancel(context.Background())
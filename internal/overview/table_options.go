@@ -0,0 +1,280 @@
+package overview
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/twosson/kubeapt/pkg/content"
+)
+
+// tableOptions sort, filter and paginate the rows of list tables, so large
+// lists aren't sent to the client in full.
+type tableOptions struct {
+	sortBy         string
+	sortDescending bool
+	filter         string
+	offset         int
+	limit          int
+}
+
+type tableOptionsKey struct{}
+
+// withTableOptions returns a context which sorts, filters and paginates
+// list tables.
+func withTableOptions(ctx context.Context, options tableOptions) context.Context {
+	return context.WithValue(ctx, tableOptionsKey{}, options)
+}
+
+func tableOptionsFromContext(ctx context.Context) tableOptions {
+	options, _ := ctx.Value(tableOptionsKey{}).(tableOptions)
+	return options
+}
+
+// tableOptionsFromQuery parses the sortBy, sortDir, filter, offset, limit
+// and continue query parameters. A continue token from a previous page
+// takes the place of the offset.
+func tableOptionsFromQuery(query url.Values) (tableOptions, error) {
+	options := tableOptions{
+		sortBy: strings.TrimSpace(query.Get("sortBy")),
+		filter: strings.TrimSpace(query.Get("filter")),
+	}
+
+	switch dir := query.Get("sortDir"); dir {
+	case "", "asc":
+	case "desc":
+		options.sortDescending = true
+	default:
+		return tableOptions{}, errors.Errorf("invalid sort direction %q", dir)
+	}
+
+	var err error
+
+	if options.offset, err = parseNonNegativeParam(query, "offset"); err != nil {
+		return tableOptions{}, err
+	}
+	if options.limit, err = parseNonNegativeParam(query, "limit"); err != nil {
+		return tableOptions{}, err
+	}
+
+	if token := query.Get("continue"); token != "" {
+		if options.offset, err = decodeContinueToken(token); err != nil {
+			return tableOptions{}, err
+		}
+	}
+
+	return options, nil
+}
+
+func parseNonNegativeParam(query url.Values, name string) (int, error) {
+	s := query.Get(name)
+	if s == "" {
+		return 0, nil
+	}
+
+	i, err := strconv.Atoi(s)
+	if err != nil || i < 0 {
+		return 0, errors.Errorf("%s must be a non-negative integer", name)
+	}
+
+	return i, nil
+}
+
+// encodeContinueToken creates an opaque token for the page starting at an
+// offset.
+func encodeContinueToken(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("offset:%d", offset)))
+}
+
+func decodeContinueToken(token string) (int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, errors.New("invalid continue token")
+	}
+
+	var offset int
+	if _, err := fmt.Sscanf(string(data), "offset:%d", &offset); err != nil || offset < 0 {
+		return 0, errors.New("invalid continue token")
+	}
+
+	return offset, nil
+}
+
+func (o tableOptions) isEmpty() bool {
+	return o == tableOptions{}
+}
+
+// applyTableOptions sorts, filters and paginates tables in contents.
+func applyTableOptions(contents []content.Content, options tableOptions) {
+	if options.isEmpty() {
+		return
+	}
+
+	for _, c := range contents {
+		if tbl, ok := c.(*content.Table); ok {
+			options.apply(tbl)
+		}
+	}
+}
+
+// apply sorts, filters and paginates the rows of a table, and describes
+// what was done in the table's metadata. Tables without the sort column are
+// left in their original order.
+func (o tableOptions) apply(tbl *content.Table) {
+	rows := tbl.Rows
+
+	if o.filter != "" {
+		var filtered []content.TableRow
+		for _, row := range rows {
+			if rowContains(row, o.filter) {
+				filtered = append(filtered, row)
+			}
+		}
+		rows = filtered
+	}
+
+	if accessor, ok := tableColumnAccessor(tbl, o.sortBy); ok {
+		sort.SliceStable(rows, func(i, j int) bool {
+			cmp := compareTableValues(textValue(rows[i][accessor]), textValue(rows[j][accessor]))
+			if o.sortDescending {
+				return cmp > 0
+			}
+			return cmp < 0
+		})
+	}
+
+	metadata := &content.TableMetadata{
+		SortBy:         o.sortBy,
+		SortDescending: o.sortDescending,
+		Filter:         o.filter,
+		Offset:         o.offset,
+		Limit:          o.limit,
+		Total:          len(rows),
+	}
+
+	start := o.offset
+	if start > len(rows) {
+		start = len(rows)
+	}
+	end := len(rows)
+	if o.limit > 0 && start+o.limit < end {
+		end = start + o.limit
+		metadata.Continue = encodeContinueToken(end)
+	}
+
+	tbl.Rows = rows[start:end]
+	tbl.Metadata = metadata
+}
+
+// tableColumnAccessor returns the accessor for a column, matched by name or
+// accessor.
+func tableColumnAccessor(tbl *content.Table, column string) (string, bool) {
+	if column == "" {
+		return "", false
+	}
+
+	for _, c := range tbl.Columns {
+		if strings.EqualFold(c.Name, column) || strings.EqualFold(c.Accessor, column) {
+			return c.Accessor, true
+		}
+	}
+
+	return "", false
+}
+
+// rowContains reports whether any cell in a row contains s, ignoring case.
+func rowContains(row content.TableRow, s string) bool {
+	s = strings.ToLower(s)
+
+	for _, text := range row {
+		if strings.Contains(strings.ToLower(textValue(text)), s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// textValue returns the value shown for text.
+func textValue(text content.Text) string {
+	switch t := text.(type) {
+	case *content.StringText:
+		return string(*t)
+	case *content.TimeText:
+		return string(*t)
+	case *content.LinkText:
+		return t.Text
+	case *content.LabelsText:
+		var pairs []string
+		for k, v := range t.Labels {
+			pairs = append(pairs, fmt.Sprintf("%s=%s", k, v))
+		}
+		sort.Strings(pairs)
+		return strings.Join(pairs, ",")
+	case *content.ListText:
+		return strings.Join(t.List, ",")
+	default:
+		return ""
+	}
+}
+
+// ageRe matches ages printed by duration.ShortHumanDuration.
+var ageRe = regexp.MustCompile(`^(\d+)([smhdy])$`)
+
+var ageUnits = map[string]time.Duration{
+	"s": time.Second,
+	"m": time.Minute,
+	"h": time.Hour,
+	"d": 24 * time.Hour,
+	"y": 365 * 24 * time.Hour,
+}
+
+// compareTableValues compares cell values. Ages and numbers are compared by
+// value, and other values are compared as text, ignoring case.
+func compareTableValues(a, b string) int {
+	if ageA, ok := parseAge(a); ok {
+		if ageB, ok := parseAge(b); ok {
+			return compareFloats(float64(ageA), float64(ageB))
+		}
+	}
+
+	if numA, err := strconv.ParseFloat(a, 64); err == nil {
+		if numB, err := strconv.ParseFloat(b, 64); err == nil {
+			return compareFloats(numA, numB)
+		}
+	}
+
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+func parseAge(s string) (time.Duration, bool) {
+	match := ageRe.FindStringSubmatch(s)
+	if match == nil {
+		return 0, false
+	}
+
+	n, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, false
+	}
+
+	return time.Duration(n) * ageUnits[match[2]], true
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,209 @@
+package overview
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twosson/kubeapt/pkg/content"
+)
+
+func Test_tableOptionsFromQuery(t *testing.T) {
+	cases := []struct {
+		name     string
+		query    url.Values
+		expected tableOptions
+		isErr    bool
+	}{
+		{
+			name:  "none",
+			query: url.Values{},
+		},
+		{
+			name: "all options",
+			query: url.Values{
+				"sortBy":  []string{"Age"},
+				"sortDir": []string{"desc"},
+				"filter":  []string{"web"},
+				"offset":  []string{"20"},
+				"limit":   []string{"10"},
+			},
+			expected: tableOptions{sortBy: "Age", sortDescending: true, filter: "web", offset: 20, limit: 10},
+		},
+		{
+			name:     "continue token",
+			query:    url.Values{"continue": []string{encodeContinueToken(30)}, "limit": []string{"10"}},
+			expected: tableOptions{offset: 30, limit: 10},
+		},
+		{
+			name:  "invalid sort direction",
+			query: url.Values{"sortDir": []string{"up"}},
+			isErr: true,
+		},
+		{
+			name:  "negative limit",
+			query: url.Values{"limit": []string{"-1"}},
+			isErr: true,
+		},
+		{
+			name:  "invalid offset",
+			query: url.Values{"offset": []string{"first"}},
+			isErr: true,
+		},
+		{
+			name:  "invalid continue token",
+			query: url.Values{"continue": []string{"not a token"}},
+			isErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tableOptionsFromQuery(tc.query)
+			if tc.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func Test_tableOptionsFromContext(t *testing.T) {
+	assert.True(t, tableOptionsFromContext(context.Background()).isEmpty())
+
+	options := tableOptions{limit: 10}
+	ctx := withTableOptions(context.Background(), options)
+	assert.Equal(t, options, tableOptionsFromContext(ctx))
+}
+
+func newOptionsTestTable() *content.Table {
+	tbl := content.NewTable("Pods", "")
+	tbl.Columns = []content.TableColumn{
+		{Name: "Name", Accessor: "Name"},
+		{Name: "Restarts", Accessor: "Restarts"},
+		{Name: "Age", Accessor: "Age"},
+	}
+
+	rows := []struct {
+		name, restarts, age string
+	}{
+		{"web-1", "10", "2d"},
+		{"web-2", "2", "5m"},
+		{"db-1", "0", "3h"},
+		{"cache-1", "1", "45s"},
+	}
+
+	for _, row := range rows {
+		tbl.AddRow(content.TableRow{
+			"Name":     content.NewLinkText(row.name, "/pods/"+row.name),
+			"Restarts": content.NewStringText(row.restarts),
+			"Age":      content.NewStringText(row.age),
+		})
+	}
+
+	return &tbl
+}
+
+func rowNames(tbl *content.Table) []string {
+	var names []string
+	for _, row := range tbl.Rows {
+		names = append(names, textValue(row["Name"]))
+	}
+	return names
+}
+
+func Test_tableOptions_apply(t *testing.T) {
+	cases := []struct {
+		name             string
+		options          tableOptions
+		expectedNames    []string
+		expectedMetadata *content.TableMetadata
+	}{
+		{
+			name:             "sort by age",
+			options:          tableOptions{sortBy: "age"},
+			expectedNames:    []string{"cache-1", "web-2", "db-1", "web-1"},
+			expectedMetadata: &content.TableMetadata{SortBy: "age", Total: 4},
+		},
+		{
+			name:             "sort by restarts descending",
+			options:          tableOptions{sortBy: "Restarts", sortDescending: true},
+			expectedNames:    []string{"web-1", "web-2", "cache-1", "db-1"},
+			expectedMetadata: &content.TableMetadata{SortBy: "Restarts", SortDescending: true, Total: 4},
+		},
+		{
+			name:             "sort by name",
+			options:          tableOptions{sortBy: "Name"},
+			expectedNames:    []string{"cache-1", "db-1", "web-1", "web-2"},
+			expectedMetadata: &content.TableMetadata{SortBy: "Name", Total: 4},
+		},
+		{
+			name:             "unknown sort column",
+			options:          tableOptions{sortBy: "Node"},
+			expectedNames:    []string{"web-1", "web-2", "db-1", "cache-1"},
+			expectedMetadata: &content.TableMetadata{SortBy: "Node", Total: 4},
+		},
+		{
+			name:             "filter",
+			options:          tableOptions{filter: "WEB"},
+			expectedNames:    []string{"web-1", "web-2"},
+			expectedMetadata: &content.TableMetadata{Filter: "WEB", Total: 2},
+		},
+		{
+			name:          "first page",
+			options:       tableOptions{sortBy: "Name", limit: 3},
+			expectedNames: []string{"cache-1", "db-1", "web-1"},
+			expectedMetadata: &content.TableMetadata{
+				SortBy:   "Name",
+				Limit:    3,
+				Total:    4,
+				Continue: encodeContinueToken(3),
+			},
+		},
+		{
+			name:             "last page",
+			options:          tableOptions{sortBy: "Name", offset: 3, limit: 3},
+			expectedNames:    []string{"web-2"},
+			expectedMetadata: &content.TableMetadata{SortBy: "Name", Offset: 3, Limit: 3, Total: 4},
+		},
+		{
+			name:             "offset past the end",
+			options:          tableOptions{offset: 10},
+			expectedNames:    nil,
+			expectedMetadata: &content.TableMetadata{Offset: 10, Total: 4},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tbl := newOptionsTestTable()
+
+			applyTableOptions([]content.Content{tbl}, tc.options)
+
+			assert.Equal(t, tc.expectedNames, rowNames(tbl))
+			assert.Equal(t, tc.expectedMetadata, tbl.Metadata)
+		})
+	}
+}
+
+func Test_applyTableOptions_empty(t *testing.T) {
+	tbl := newOptionsTestTable()
+
+	applyTableOptions([]content.Content{tbl}, tableOptions{})
+
+	assert.Len(t, tbl.Rows, 4)
+	assert.Nil(t, tbl.Metadata)
+}
+
+func Test_compareTableValues(t *testing.T) {
+	assert.Equal(t, -1, compareTableValues("59s", "1m"))
+	assert.Equal(t, 1, compareTableValues("2y", "300d"))
+	assert.Equal(t, -1, compareTableValues("9", "10"))
+	assert.Equal(t, 0, compareTableValues("Running", "running"))
+	assert.Equal(t, -1, compareTableValues("<none>", "a"))
+}
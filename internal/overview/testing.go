@@ -6,6 +6,10 @@ import (
 	"testing"
 )
 
+// testPrefix is the path the overview handler is served from in tests.
+// Links start with "/content/overview".
+const testPrefix = "/api/v1/content/overview"
+
 func assertViewInvalidObject(t *testing.T, v View) {
 	ctx := context.Background()
 	_, err := v.Content(ctx, nil, nil)
@@ -12,6 +12,10 @@ var deploymentTransforms = map[string]lookupFunc{
 	"Name": resourceLink("workloads", "deployments"),
 }
 
+var horizontalPodAutoscalerTransforms = map[string]lookupFunc{
+	"Name": resourceLink("workloads", "horizontal-pod-autoscalers"),
+}
+
 var jobTransforms = map[string]lookupFunc{
 	"Name": resourceLink("workloads", "jobs"),
 }
@@ -36,6 +40,10 @@ var ingressTransforms = map[string]lookupFunc{
 	"Name": resourceLink("discovery-and-load-balancing", "ingresses"),
 }
 
+var networkPolicyTransforms = map[string]lookupFunc{
+	"Name": resourceLink("discovery-and-load-balancing", "network-policies"),
+}
+
 var serviceTransforms = map[string]lookupFunc{
 	"Name": resourceLink("discovery-and-load-balancing", "services"),
 }
@@ -63,3 +71,27 @@ var roleTransforms = map[string]lookupFunc{
 var roleBindingTransforms = map[string]lookupFunc{
 	"Name": resourceLink("rbac", "role-bindings"),
 }
+
+var nodeTransforms = map[string]lookupFunc{
+	"Name": resourceLink("cluster", "nodes"),
+}
+
+var namespaceTransforms = map[string]lookupFunc{
+	"Name": resourceLink("cluster", "namespaces"),
+}
+
+var persistentVolumeTransforms = map[string]lookupFunc{
+	"Name": resourceLink("cluster", "persistent-volumes"),
+}
+
+var storageClassTransforms = map[string]lookupFunc{
+	"Name": resourceLink("cluster", "storage-classes"),
+}
+
+var clusterRoleTransforms = map[string]lookupFunc{
+	"Name": resourceLink("cluster", "cluster-roles"),
+}
+
+var clusterRoleBindingTransforms = map[string]lookupFunc{
+	"Name": resourceLink("cluster", "cluster-role-bindings"),
+}
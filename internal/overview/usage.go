@@ -0,0 +1,317 @@
+package overview
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/twosson/kubeapt/pkg/content"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/kubernetes/pkg/apis/core"
+	"k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset/scheme"
+)
+
+// podUsageColumns add the usage of pods, compared with their requests and
+// limits, to pod lists.
+var podUsageColumns = []ResourceColumn{
+	{Name: "CPU Usage", Value: podUsage(core.ResourceCPU), Available: podMetricsAvailable},
+	{Name: "Memory Usage", Value: podUsage(core.ResourceMemory), Available: podMetricsAvailable},
+}
+
+// workloadUsageColumns add the total usage of the pods selected by
+// workloads, compared with their requests and limits, to workload lists.
+var workloadUsageColumns = []ResourceColumn{
+	{Name: "CPU Usage", Value: workloadUsage(core.ResourceCPU), Available: podMetricsAvailable},
+	{Name: "Memory Usage", Value: workloadUsage(core.ResourceMemory), Available: podMetricsAvailable},
+}
+
+func podMetricsAvailable(ctx context.Context) bool {
+	return metricsFromContext(ctx).podMetricsAvailable()
+}
+
+func nodeMetricsAvailable(ctx context.Context) bool {
+	return metricsFromContext(ctx).nodeMetricsAvailable()
+}
+
+// podUsage returns the usage of a resource by a pod.
+func podUsage(name core.ResourceName) func(context.Context, runtime.Object, Cache) content.Text {
+	return func(ctx context.Context, object runtime.Object, c Cache) content.Text {
+		pod, ok := object.(*core.Pod)
+		if !ok {
+			return content.NewStringText("<unknown>")
+		}
+
+		usage, ok := metricsFromContext(ctx).podUsage(pod.Namespace, pod.Name)
+		if !ok {
+			return content.NewStringText("<none>")
+		}
+
+		requests, limits := podRequestsAndLimits(pod)
+		return content.NewStringText(formatUsageOf(name, usage[corev1.ResourceName(name)], requests, limits))
+	}
+}
+
+// workloadUsage returns the total usage of a resource by the pods a
+// workload selects. Requests and limits are totalled for the same pods.
+func workloadUsage(name core.ResourceName) func(context.Context, runtime.Object, Cache) content.Text {
+	return func(ctx context.Context, object runtime.Object, c Cache) content.Text {
+		mobject, ok := object.(metav1.Object)
+		if !ok {
+			return content.NewStringText("<unknown>")
+		}
+
+		selector, err := getSelector(object)
+		if err != nil {
+			return content.NewStringText("<unknown>")
+		}
+
+		pods, err := selectPods(ctx, c, mobject.GetNamespace(), selector)
+		if err != nil {
+			return content.NewStringText("<unknown>")
+		}
+
+		metrics := metricsFromContext(ctx)
+
+		var total resource.Quantity
+		sampled := false
+		requests, limits := core.ResourceList{}, core.ResourceList{}
+		for _, pod := range pods {
+			usage, ok := metrics.podUsage(pod.Namespace, pod.Name)
+			if !ok {
+				continue
+			}
+
+			sampled = true
+			total.Add(usage[corev1.ResourceName(name)])
+
+			podRequests, podLimits := podRequestsAndLimits(pod)
+			addResourceList(requests, podRequests)
+			addResourceList(limits, podLimits)
+		}
+
+		if !sampled {
+			return content.NewStringText("<none>")
+		}
+
+		return content.NewStringText(formatUsageOf(name, total, requests, limits))
+	}
+}
+
+// nodeUsage returns the usage of a resource on a node, compared with the
+// amount which is allocatable.
+func nodeUsage(name core.ResourceName) func(context.Context, runtime.Object, Cache) content.Text {
+	return func(ctx context.Context, object runtime.Object, c Cache) content.Text {
+		node, ok := object.(*core.Node)
+		if !ok {
+			return content.NewStringText("<unknown>")
+		}
+
+		usage, ok := metricsFromContext(ctx).nodeUsage(node.Name)
+		if !ok {
+			return content.NewStringText("<none>")
+		}
+
+		quantity := usage[corev1.ResourceName(name)]
+		text := formatUsage(name, quantity)
+		if allocatable, ok := node.Status.Allocatable[name]; ok {
+			text = fmt.Sprintf("%s (%d%%)", text, usagePercent(quantity, allocatable))
+		}
+
+		return content.NewStringText(text)
+	}
+}
+
+// selectPods returns the pods in a namespace which match a selector. Pods
+// are matched before they are converted, as workload lists match pods for
+// every workload.
+func selectPods(ctx context.Context, c Cache, namespace string, labelSelector *metav1.LabelSelector) ([]*core.Pod, error) {
+	if labelSelector == nil {
+		return nil, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	if selector.Empty() {
+		return nil, nil
+	}
+
+	key := CacheKey{Namespace: namespace, APIVersion: "v1", Kind: "Pod"}
+	objects, err := c.Retrieve(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	var pods []*core.Pod
+	for _, object := range objects {
+		if !selector.Matches(labels.Set(object.GetLabels())) {
+			continue
+		}
+
+		pod := &core.Pod{}
+		if err := scheme.Scheme.Convert(object, pod, runtime.InternalGroupVersioner); err != nil {
+			return nil, err
+		}
+
+		if err := copyObjectMeta(pod, object); err != nil {
+			return nil, err
+		}
+
+		pods = append(pods, pod)
+	}
+
+	return pods, nil
+}
+
+// formatUsage formats the usage of a resource. metrics-server reports CPU in
+// nanocores and memory in kibibytes, so they are rounded to millicores and
+// mebibytes.
+func formatUsage(name core.ResourceName, quantity resource.Quantity) string {
+	switch name {
+	case core.ResourceCPU:
+		return fmt.Sprintf("%dm", quantity.MilliValue())
+	case core.ResourceMemory:
+		return fmt.Sprintf("%dMi", quantity.Value()/(1024*1024))
+	default:
+		return quantity.String()
+	}
+}
+
+// formatUsageOf formats the usage of a resource with the percentages of the
+// request and limit it uses, e.g. "105m (52% of request, 21% of limit)".
+func formatUsageOf(name core.ResourceName, usage resource.Quantity, requests, limits core.ResourceList) string {
+	var compared []string
+	if request, ok := requests[name]; ok && !request.IsZero() {
+		compared = append(compared, fmt.Sprintf("%d%% of request", usagePercent(usage, request)))
+	}
+	if limit, ok := limits[name]; ok && !limit.IsZero() {
+		compared = append(compared, fmt.Sprintf("%d%% of limit", usagePercent(usage, limit)))
+	}
+
+	text := formatUsage(name, usage)
+	if len(compared) > 0 {
+		text = fmt.Sprintf("%s (%s)", text, strings.Join(compared, ", "))
+	}
+
+	return text
+}
+
+func usagePercent(amount, total resource.Quantity) int64 {
+	if total.MilliValue() <= 0 {
+		return 0
+	}
+
+	return int64(float64(amount.MilliValue()) / float64(total.MilliValue()) * 100)
+}
+
+// usageBound is a line drawn on a usage chart, e.g. a pod's request.
+type usageBound struct {
+	name string
+	list core.ResourceList
+}
+
+// usageCharts creates CPU and memory charts from usage samples.
+func usageCharts(samples []usageSample, bounds ...usageBound) []content.Content {
+	return []content.Content{
+		usageChart("CPU Usage", core.ResourceCPU, "cores", samples, bounds, func(q resource.Quantity) float64 {
+			return float64(q.MilliValue()) / 1000
+		}),
+		usageChart("Memory Usage", core.ResourceMemory, "MiB", samples, bounds, func(q resource.Quantity) float64 {
+			return float64(q.Value()) / (1024 * 1024)
+		}),
+	}
+}
+
+func usageChart(title string, name core.ResourceName, unit string, samples []usageSample, bounds []usageBound, value func(resource.Quantity) float64) content.Content {
+	chart := content.NewChart(title, unit, "Usage hasn't been sampled yet")
+
+	usage := content.ChartSeries{Name: "Usage"}
+	for _, sample := range samples {
+		if quantity, ok := sample.usage[corev1.ResourceName(name)]; ok {
+			usage.AddPoint(sample.timestamp, value(quantity))
+		}
+	}
+	chart.AddSeries(usage)
+
+	if len(usage.Points) == 0 {
+		return &chart
+	}
+
+	// Bounds are drawn across the sampled window.
+	for _, bound := range bounds {
+		quantity, ok := bound.list[name]
+		if !ok {
+			continue
+		}
+
+		series := content.ChartSeries{Name: bound.name}
+		for _, point := range usage.Points {
+			series.Points = append(series.Points, content.ChartPoint{Timestamp: point.Timestamp, Value: value(quantity)})
+		}
+		chart.AddSeries(series)
+	}
+
+	return &chart
+}
+
+// PodUsage charts the recent usage of a pod, compared with its requests and
+// limits. It isn't shown if pod usage isn't sampled.
+type PodUsage struct{}
+
+var _ View = (*PodUsage)(nil)
+
+func NewPodUsage(prefix, namespace string, c clock.Clock) View {
+	return &PodUsage{}
+}
+
+func (pu *PodUsage) Content(ctx context.Context, object runtime.Object, c Cache) ([]content.Content, error) {
+	pod, err := retrievePod(object)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := metricsFromContext(ctx)
+	if !metrics.podMetricsAvailable() {
+		return nil, nil
+	}
+
+	requests, limits := podRequestsAndLimits(pod)
+
+	return usageCharts(metrics.podSamples(pod.Namespace, pod.Name),
+		usageBound{name: "Request", list: requests},
+		usageBound{name: "Limit", list: limits},
+	), nil
+}
+
+// NodeUsage charts the recent usage of a node, compared with the amount
+// which is allocatable. It isn't shown if node usage isn't sampled.
+type NodeUsage struct{}
+
+var _ View = (*NodeUsage)(nil)
+
+func NewNodeUsage(prefix, namespace string, c clock.Clock) View {
+	return &NodeUsage{}
+}
+
+func (nu *NodeUsage) Content(ctx context.Context, object runtime.Object, c Cache) ([]content.Content, error) {
+	node, err := retrieveNode(object)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := metricsFromContext(ctx)
+	if !metrics.nodeMetricsAvailable() {
+		return nil, nil
+	}
+
+	return usageCharts(metrics.nodeSamples(node.Name),
+		usageBound{name: "Allocatable", list: node.Status.Allocatable},
+	), nil
+}
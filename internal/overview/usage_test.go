@@ -0,0 +1,195 @@
+package overview
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twosson/kubeapt/pkg/content"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/kubernetes/pkg/apis/core"
+	"k8s.io/kubernetes/pkg/apis/extensions"
+)
+
+func storeUsagePod(t *testing.T, c Cache, name string, labels map[string]string, requests, limits corev1.ResourceList) {
+	pod := &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			Labels:    labels,
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "app", Resources: corev1.ResourceRequirements{Requests: requests, Limits: limits}},
+			},
+		},
+	}
+
+	m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(pod)
+	require.NoError(t, err)
+
+	require.NoError(t, c.Store(&unstructured.Unstructured{Object: m}))
+}
+
+func newUsagePod(name string, requests, limits core.ResourceList) *core.Pod {
+	return &core.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec: core.PodSpec{
+			Containers: []core.Container{
+				{Name: "app", Resources: core.ResourceRequirements{Requests: requests, Limits: limits}},
+			},
+		},
+	}
+}
+
+func Test_formatUsageOf(t *testing.T) {
+	requests := core.ResourceList{
+		core.ResourceCPU:    resource.MustParse("200m"),
+		core.ResourceMemory: resource.MustParse("1Gi"),
+	}
+	limits := core.ResourceList{core.ResourceCPU: resource.MustParse("500m")}
+
+	cases := []struct {
+		name     string
+		resource core.ResourceName
+		usage    string
+		requests core.ResourceList
+		limits   core.ResourceList
+		expected string
+	}{
+		{
+			name:     "cpu in nanocores",
+			resource: core.ResourceCPU,
+			usage:    "105000000n",
+			requests: requests,
+			limits:   limits,
+			expected: "105m (52% of request, 21% of limit)",
+		},
+		{
+			name:     "memory in kibibytes",
+			resource: core.ResourceMemory,
+			usage:    "262144Ki",
+			requests: requests,
+			limits:   limits,
+			expected: "256Mi (25% of request)",
+		},
+		{
+			name:     "no requests or limits",
+			resource: core.ResourceCPU,
+			usage:    "1",
+			expected: "1000m",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := formatUsageOf(tc.resource, resource.MustParse(tc.usage), tc.requests, tc.limits)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func Test_podUsage(t *testing.T) {
+	ctx := withMetrics(context.Background(), newTestMetricsSampler(t))
+
+	pod := newUsagePod("web", core.ResourceList{core.ResourceCPU: resource.MustParse("500m")}, nil)
+	assert.Equal(t, content.NewStringText("250m (50% of request)"), podUsage(core.ResourceCPU)(ctx, pod, NewMemoryCache()))
+	assert.Equal(t, content.NewStringText("512Mi"), podUsage(core.ResourceMemory)(ctx, pod, NewMemoryCache()))
+
+	unsampled := newUsagePod("new", nil, nil)
+	assert.Equal(t, content.NewStringText("<none>"), podUsage(core.ResourceCPU)(ctx, unsampled, NewMemoryCache()))
+}
+
+func Test_workloadUsage(t *testing.T) {
+	ctx := withMetrics(context.Background(), newTestMetricsSampler(t))
+
+	cache := NewMemoryCache()
+	storeUsagePod(t, cache, "web", map[string]string{"app": "web"}, resourceList("500m", "1Gi"), resourceList("1", ""))
+	storeUsagePod(t, cache, "db", map[string]string{"app": "db"}, resourceList("1", ""), nil)
+	storeUsagePod(t, cache, "web-new", map[string]string{"app": "web"}, resourceList("500m", "1Gi"), nil)
+
+	deployment := &extensions.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"},
+		Spec: extensions.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+		},
+	}
+
+	// Only pods which have been sampled are totalled.
+	assert.Equal(t,
+		content.NewStringText("250m (50% of request, 25% of limit)"),
+		workloadUsage(core.ResourceCPU)(ctx, deployment, cache))
+	assert.Equal(t,
+		content.NewStringText("512Mi (50% of request)"),
+		workloadUsage(core.ResourceMemory)(ctx, deployment, cache))
+
+	deployment.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}}
+	assert.Equal(t, content.NewStringText("<none>"), workloadUsage(core.ResourceCPU)(ctx, deployment, cache))
+}
+
+func Test_nodeUsage(t *testing.T) {
+	ctx := withMetrics(context.Background(), newTestMetricsSampler(t))
+
+	assert.Equal(t, content.NewStringText("500m (25%)"), nodeUsage(core.ResourceCPU)(ctx, newTestNode(), NewMemoryCache()))
+	assert.Equal(t, content.NewStringText("1024Mi (25%)"), nodeUsage(core.ResourceMemory)(ctx, newTestNode(), NewMemoryCache()))
+}
+
+func TestPodUsage(t *testing.T) {
+	pod := newUsagePod("web",
+		core.ResourceList{core.ResourceCPU: resource.MustParse("500m")},
+		core.ResourceList{core.ResourceMemory: resource.MustParse("1Gi")},
+	)
+
+	v := NewPodUsage(testPrefix, "ns", clock.NewFakeClock(time.Now()))
+
+	contents, err := v.Content(context.Background(), pod, NewMemoryCache())
+	require.NoError(t, err)
+	assert.Empty(t, contents)
+
+	ctx := withMetrics(context.Background(), newTestMetricsSampler(t))
+	contents, err = v.Content(ctx, pod, NewMemoryCache())
+	require.NoError(t, err)
+
+	point := func(value float64) []content.ChartPoint {
+		return []content.ChartPoint{{Timestamp: testMetricsTime.Unix(), Value: value}}
+	}
+
+	cpu := content.NewChart("CPU Usage", "cores", "Usage hasn't been sampled yet")
+	cpu.AddSeries(content.ChartSeries{Name: "Usage", Points: point(0.25)})
+	cpu.AddSeries(content.ChartSeries{Name: "Request", Points: point(0.5)})
+
+	memory := content.NewChart("Memory Usage", "MiB", "Usage hasn't been sampled yet")
+	memory.AddSeries(content.ChartSeries{Name: "Usage", Points: point(512)})
+	memory.AddSeries(content.ChartSeries{Name: "Limit", Points: point(1024)})
+
+	assert.Equal(t, []content.Content{&cpu, &memory}, contents)
+
+	// Pods which haven't been sampled have empty charts.
+	contents, err = v.Content(ctx, newUsagePod("new", nil, nil), NewMemoryCache())
+	require.NoError(t, err)
+	require.Len(t, contents, 2)
+	assert.True(t, contents[0].IsEmpty())
+}
+
+func TestNodeUsage(t *testing.T) {
+	v := NewNodeUsage(testPrefix, "ns", clock.NewFakeClock(time.Now()))
+
+	ctx := withMetrics(context.Background(), newTestMetricsSampler(t))
+	contents, err := v.Content(ctx, newTestNode(), NewMemoryCache())
+	require.NoError(t, err)
+	require.Len(t, contents, 2)
+
+	cpu, ok := contents[0].(*content.Chart)
+	require.True(t, ok)
+	require.Len(t, cpu.Series, 2)
+	assert.Equal(t, "Allocatable", cpu.Series[1].Name)
+	assert.Equal(t, 2.0, cpu.Series[1].Points[0].Value)
+}
@@ -2,13 +2,13 @@ package overview
 
 import (
 	"fmt"
-	"github.com/twosson/kubeapt/internal/content"
+	"github.com/twosson/kubeapt/pkg/content"
 	"k8s.io/kubernetes/pkg/apis/core"
 	"strconv"
 	"strings"
 )
 
-func summarizeVolume(volume core.Volume) content.Section {
+func summarizeVolume(prefix string, volume core.Volume) content.Section {
 	section := content.NewSection()
 	section.Title = volume.Name
 
@@ -34,7 +34,7 @@ func summarizeVolume(volume core.Volume) content.Section {
 	case volume.VolumeSource.Glusterfs != nil:
 		summarizeGlusterfsVolumeSource(&section, volume.VolumeSource.Glusterfs)
 	case volume.VolumeSource.PersistentVolumeClaim != nil:
-		summarizePersistentVolumeClaimVolumeSource(&section, volume.VolumeSource.PersistentVolumeClaim)
+		summarizePersistentVolumeClaimVolumeSource(prefix, &section, volume.VolumeSource.PersistentVolumeClaim)
 	case volume.VolumeSource.RBD != nil:
 		summarizeRBDVolumeSource(&section, volume.VolumeSource.RBD)
 	case volume.VolumeSource.Quobyte != nil:
@@ -190,9 +190,9 @@ func summarizeGlusterfsVolumeSource(section *content.Section, glusterfs *core.Gl
 	section.AddText("ReadOnly", fmt.Sprintf("%v", glusterfs.ReadOnly))
 }
 
-func summarizePersistentVolumeClaimVolumeSource(section *content.Section, claim *core.PersistentVolumeClaimVolumeSource) {
+func summarizePersistentVolumeClaimVolumeSource(prefix string, section *content.Section, claim *core.PersistentVolumeClaimVolumeSource) {
 	section.AddText("Type", "PersistentVolumeClaim")
-	section.AddLink("Claim Name", claim.ClaimName, gvkPath("v1", "PersistentVolumeClaim", claim.ClaimName))
+	section.AddLink("Claim Name", claim.ClaimName, gvkPath(prefix, "v1", "PersistentVolumeClaim", claim.ClaimName))
 	section.AddText("ReadOnly", fmt.Sprintf("%t", claim.ReadOnly))
 }
 
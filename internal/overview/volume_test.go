@@ -2,7 +2,7 @@ package overview
 
 import (
 	"github.com/stretchr/testify/assert"
-	"github.com/twosson/kubeapt/internal/content"
+	"github.com/twosson/kubeapt/pkg/content"
 	"k8s.io/kubernetes/pkg/apis/core"
 	"testing"
 )
@@ -14,11 +14,11 @@ func Test_summarizePersistentVolumeClaimVolumeSource(t *testing.T) {
 
 	section := &content.Section{}
 
-	summarizePersistentVolumeClaimVolumeSource(section, claim)
+	summarizePersistentVolumeClaimVolumeSource("/api/v1/clusters/staging/content/overview", section, claim)
 
 	expected := &content.Section{}
 	expected.AddText("Type", "PersistentVolumeClaim")
-	expected.AddLink("Claim Name", "my-claim", "/content/overview/config-and-storage/persistent-volume-claims/my-claim")
+	expected.AddLink("Claim Name", "my-claim", "/clusters/staging/content/overview/config-and-storage/persistent-volume-claims/my-claim")
 	expected.AddText("ReadOnly", "false")
 
 	assert.Equal(t, expected, section)
@@ -4,7 +4,8 @@ import (
 	"context"
 	"fmt"
 	"github.com/pkg/errors"
-	"github.com/twosson/kubeapt/internal/content"
+	"github.com/twosson/kubeapt/internal/cluster"
+	"github.com/twosson/kubeapt/pkg/content"
 	"k8s.io/api/extensions/v1beta1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -14,6 +15,7 @@ import (
 	k8stypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/clock"
 	"k8s.io/kubernetes/pkg/apis/apps"
+	"k8s.io/kubernetes/pkg/apis/autoscaling"
 	"k8s.io/kubernetes/pkg/apis/core"
 	"k8s.io/kubernetes/pkg/apis/extensions"
 	"sort"
@@ -79,6 +81,10 @@ func (wid *workloadInspectorView) Content(ctx context.Context, object runtime.Ob
 		if err := wid.visitDaemonSet(ctx, v, c, dag.Nodes, dag.Edges, visited); err != nil {
 			return nil, err
 		}
+	case (*autoscaling.HorizontalPodAutoscaler):
+		if err := wid.visitHorizontalPodAutoscaler(ctx, v, c, dag.Nodes, dag.Edges, visited); err != nil {
+			return nil, err
+		}
 	default:
 	}
 
@@ -106,7 +112,7 @@ func (wid *workloadInspectorView) visitPod(ctx context.Context, pod *core.Pod, c
 	// Node is added by visitPodGroup, we will only explore the edges
 
 	// Handle back-edges
-	services, err := findServicesForPod(pod, c)
+	services, err := findServicesForPod(ctx, pod, c)
 	if err != nil {
 		return errors.Wrapf(err, "finding services referencing pod: %v", pod.Name)
 	}
@@ -116,7 +122,7 @@ func (wid *workloadInspectorView) visitPod(ctx context.Context, pod *core.Pod, c
 		}
 	}
 
-	replicaSets, err := findReplicaSetsForPod(pod, c)
+	replicaSets, err := findReplicaSetsForPod(ctx, pod, c)
 	if err != nil {
 		return errors.Wrapf(err, "finding replicaSets referencing pod: %v", pod.Name)
 	}
@@ -126,7 +132,7 @@ func (wid *workloadInspectorView) visitPod(ctx context.Context, pod *core.Pod, c
 		}
 	}
 
-	deployments, err := findDeploymentsForPod(pod, c)
+	deployments, err := findDeploymentsForPod(ctx, pod, c)
 	if err != nil {
 		return errors.Wrapf(err, "finding deployments referencing pod: %v", pod.Name)
 	}
@@ -136,7 +142,7 @@ func (wid *workloadInspectorView) visitPod(ctx context.Context, pod *core.Pod, c
 		}
 	}
 
-	s, err := findStatefulSetForPod(pod, c)
+	s, err := findStatefulSetForPod(ctx, pod, c)
 	if err != nil {
 		return errors.Wrapf(err, "finding deployments referencing pod: %v", pod.Name)
 	}
@@ -146,7 +152,7 @@ func (wid *workloadInspectorView) visitPod(ctx context.Context, pod *core.Pod, c
 		}
 	}
 
-	rc, err := findReplicationControllerForPod(pod, c)
+	rc, err := findReplicationControllerForPod(ctx, pod, c)
 	if err != nil {
 		return errors.Wrapf(err, "finding replicationControllers referencing pod: %v", pod.Name)
 	}
@@ -156,7 +162,7 @@ func (wid *workloadInspectorView) visitPod(ctx context.Context, pod *core.Pod, c
 		}
 	}
 
-	ds, err := findDaemonSetForPod(pod, c)
+	ds, err := findDaemonSetForPod(ctx, pod, c)
 	if err != nil {
 		return errors.Wrapf(err, "finding daemonSet referencing pod: %v", pod.Name)
 	}
@@ -238,7 +244,7 @@ func (wid *workloadInspectorView) visitDeployment(ctx context.Context, deploymen
 	nodes[uid] = node
 
 	// Handle edges
-	rsList, err := listReplicaSets(deployment, c)
+	rsList, err := listReplicaSets(ctx, deployment, c)
 	if err != nil {
 		return errors.Wrapf(err, "fetching replicasets for deployment %v", deployment.Name)
 	}
@@ -250,6 +256,17 @@ func (wid *workloadInspectorView) visitDeployment(ctx context.Context, deploymen
 		edges.Add(uid, content.Edge{Type: content.EdgeTypeExplicit, Node: string(rs.UID)})
 	}
 
+	// Handle back-edges
+	hpas, err := findManagingHorizontalPodAutoscalers(ctx, c, deployment.Namespace, "Deployment", deployment.Name)
+	if err != nil {
+		return errors.Wrapf(err, "finding horizontal pod autoscalers for deployment %v", deployment.Name)
+	}
+	for _, hpa := range hpas {
+		if err := wid.visitHorizontalPodAutoscaler(ctx, hpa, c, nodes, edges, visited); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -276,7 +293,7 @@ func (wid *workloadInspectorView) visitReplicaSet(ctx context.Context, rs *exten
 	nodes[uid] = node
 
 	// Handle edges
-	pods, err := listPods(rs.GetNamespace(), rs.Spec.Selector, rs.UID, c)
+	pods, err := listPods(ctx, rs.GetNamespace(), rs.Spec.Selector, rs.UID, c)
 	if err != nil {
 		return errors.Wrapf(err, "fetching pods for replicaset %v", rs.Name)
 	}
@@ -288,7 +305,7 @@ func (wid *workloadInspectorView) visitReplicaSet(ctx context.Context, rs *exten
 	}
 
 	// Handle back-edges
-	d, err := findDeploymentForReplicaSet(rs, c)
+	d, err := findDeploymentForReplicaSet(ctx, rs, c)
 	if err != nil {
 		return errors.Wrapf(err, "finding deployment for replicaset %v", rs.Name)
 	}
@@ -322,7 +339,7 @@ func (wid *workloadInspectorView) visitService(ctx context.Context, svc *core.Se
 	nodes[uid] = node
 
 	// Handle edges
-	pods, err := findPodsForService(svc, c)
+	pods, err := findPodsForService(ctx, svc, c)
 	if err != nil {
 		return errors.Wrapf(err, "fetching pods for service %v", svc.Name)
 	}
@@ -334,7 +351,7 @@ func (wid *workloadInspectorView) visitService(ctx context.Context, svc *core.Se
 	}
 
 	// Reverse-lookup ingresses that reference the service
-	ingresses, err := findIngressesForService(svc, c)
+	ingresses, err := findIngressesForService(ctx, svc, c)
 	if err != nil {
 		return errors.Wrapf(err, "reverse-lookup ingresses for service %v", svc.Name)
 	}
@@ -357,7 +374,7 @@ func (wid *workloadInspectorView) visitIngress(ctx context.Context, ingress *v1b
 	}
 	visited[key] = true
 
-	statusList, err := statusForIngress(ingress, c)
+	statusList, err := statusForIngress(ctx, ingress, c)
 	if err != nil {
 		return errors.Wrapf(err, "determining status for ingress %v", ingress.Name)
 	}
@@ -378,7 +395,7 @@ func (wid *workloadInspectorView) visitIngress(ctx context.Context, ingress *v1b
 	if err != nil {
 		return errors.Wrapf(err, "listing backends for ingress %v", ingress.Name)
 	}
-	services, err := loadServices(serviceNames(backends), ingress.Namespace, c)
+	services, err := loadServices(ctx, serviceNames(backends), ingress.Namespace, c)
 	if err != nil {
 		return errors.Wrapf(err, "loading backends for ingress %v", ingress.Name)
 	}
@@ -415,7 +432,7 @@ func (wid *workloadInspectorView) visitStatefulSet(ctx context.Context, s *apps.
 	nodes[uid] = node
 
 	// Handle edges
-	pods, err := listPods(s.GetNamespace(), s.Spec.Selector, s.UID, c)
+	pods, err := listPods(ctx, s.GetNamespace(), s.Spec.Selector, s.UID, c)
 	if err != nil {
 		return errors.Wrapf(err, "fetching pods for statefulset %v", s.Name)
 	}
@@ -426,6 +443,17 @@ func (wid *workloadInspectorView) visitStatefulSet(ctx context.Context, s *apps.
 		return err
 	}
 
+	// Handle back-edges
+	hpas, err := findManagingHorizontalPodAutoscalers(ctx, c, s.Namespace, "StatefulSet", s.Name)
+	if err != nil {
+		return errors.Wrapf(err, "finding horizontal pod autoscalers for statefulset %v", s.Name)
+	}
+	for _, hpa := range hpas {
+		if err := wid.visitHorizontalPodAutoscaler(ctx, hpa, c, nodes, edges, visited); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -456,7 +484,7 @@ func (wid *workloadInspectorView) visitReplicationController(ctx context.Context
 	if err != nil {
 		return errors.Wrapf(err, "fetching selector for replicationcontroller: %v", rc.Name)
 	}
-	pods, err := listPods(rc.GetNamespace(), selector, rc.UID, c)
+	pods, err := listPods(ctx, rc.GetNamespace(), selector, rc.UID, c)
 	if err != nil {
 		return errors.Wrapf(err, "fetching pods for replicationcontroller %v", rc.Name)
 	}
@@ -493,7 +521,7 @@ func (wid *workloadInspectorView) visitDaemonSet(ctx context.Context, ds *extens
 	nodes[uid] = node
 
 	// Handle edges
-	pods, err := listPods(ds.GetNamespace(), ds.Spec.Selector, ds.UID, c)
+	pods, err := listPods(ctx, ds.GetNamespace(), ds.Spec.Selector, ds.UID, c)
 	if err != nil {
 		return errors.Wrapf(err, "fetching pods for daemonset %v", ds.Name)
 	}
@@ -507,6 +535,50 @@ func (wid *workloadInspectorView) visitDaemonSet(ctx context.Context, ds *extens
 	return nil
 }
 
+func (wid *workloadInspectorView) visitHorizontalPodAutoscaler(ctx context.Context, hpa *autoscaling.HorizontalPodAutoscaler, c Cache, nodes content.Nodes, edges content.AdjList, visited visitSet) error {
+	if hpa == nil {
+		return errors.New("nil horizontalpodautoscaler")
+	}
+
+	key := visitKeyForObject(hpa)
+	if visited[key] {
+		return nil
+	}
+	visited[key] = true
+
+	node := &content.Node{
+		Name:       hpa.Name,
+		APIVersion: hpa.APIVersion,
+		Kind:       hpa.Kind,
+		Status:     statusForHorizontalPodAutoscaler(hpa),
+		IsNetwork:  false,
+		Views:      []content.Content{},
+	}
+	uid := string(hpa.UID)
+	nodes[uid] = node
+
+	// Handle edges
+	target, err := findScaleTarget(ctx, hpa, c)
+	if err != nil {
+		return errors.Wrapf(err, "finding scale target for horizontalpodautoscaler %v", hpa.Name)
+	}
+
+	switch t := target.(type) {
+	case *extensions.Deployment:
+		if err := wid.visitDeployment(ctx, t, c, nodes, edges, visited); err != nil {
+			return err
+		}
+		edges.Add(uid, content.Edge{Type: content.EdgeTypeExplicit, Node: string(t.UID)})
+	case *apps.StatefulSet:
+		if err := wid.visitStatefulSet(ctx, t, c, nodes, edges, visited); err != nil {
+			return err
+		}
+		edges.Add(uid, content.Edge{Type: content.EdgeTypeExplicit, Node: string(t.UID)})
+	}
+
+	return nil
+}
+
 func listIngressPaths(ingress *v1beta1.Ingress, c Cache) ([]v1beta1.HTTPIngressPath, error) {
 	if ingress == nil {
 		return nil, errors.New("nil ingress")
@@ -557,7 +629,7 @@ func listIngressBackends(ingress *v1beta1.Ingress, c Cache) ([]v1beta1.IngressBa
 	return backends, nil
 }
 
-func loadServices(serviceNames []string, namespace string, c Cache) ([]*core.Service, error) {
+func loadServices(ctx context.Context, serviceNames []string, namespace string, c Cache) ([]*core.Service, error) {
 	var services []*core.Service
 	for _, backend := range serviceNames {
 		key := CacheKey{
@@ -566,7 +638,7 @@ func loadServices(serviceNames []string, namespace string, c Cache) ([]*core.Ser
 			Kind:       "Service",
 			Name:       backend,
 		}
-		ul, err := c.Retrieve(key)
+		ul, err := c.Retrieve(ctx, key)
 		if err != nil {
 			return nil, errors.Wrapf(err, "retrieving service backend: %v", backend)
 		}
@@ -585,8 +657,8 @@ func loadServices(serviceNames []string, namespace string, c Cache) ([]*core.Ser
 	return services, nil
 }
 
-func loadService(name string, namespace string, c Cache) (*core.Service, error) {
-	services, err := loadServices([]string{name}, namespace, c)
+func loadService(ctx context.Context, name string, namespace string, c Cache) (*core.Service, error) {
+	services, err := loadServices(ctx, []string{name}, namespace, c)
 	if err != nil {
 		return nil, err
 	}
@@ -597,7 +669,7 @@ func loadService(name string, namespace string, c Cache) (*core.Service, error)
 }
 
 // Reverse-lookup ingresses that point to a service
-func findIngressesForService(svc *core.Service, c Cache) ([]*v1beta1.Ingress, error) {
+func findIngressesForService(ctx context.Context, svc *core.Service, c Cache) ([]*v1beta1.Ingress, error) {
 	var results []*v1beta1.Ingress
 	if svc == nil {
 		return nil, errors.New("nil service")
@@ -611,7 +683,7 @@ func findIngressesForService(svc *core.Service, c Cache) ([]*v1beta1.Ingress, er
 		APIVersion: "extensions/v1beta1",
 		Kind:       "Ingress",
 	}
-	ul, err := c.Retrieve(key)
+	ul, err := c.Retrieve(ctx, key)
 	if err != nil {
 		return nil, errors.Wrap(err, "retrieving ingresses")
 	}
@@ -637,7 +709,7 @@ func findIngressesForService(svc *core.Service, c Cache) ([]*v1beta1.Ingress, er
 	return results, nil
 }
 
-func findPodsForService(svc *core.Service, c Cache) ([]*core.Pod, error) {
+func findPodsForService(ctx context.Context, svc *core.Service, c Cache) ([]*core.Pod, error) {
 	if svc == nil {
 		return nil, errors.New("nil service")
 	}
@@ -654,16 +726,28 @@ func findPodsForService(svc *core.Service, c Cache) ([]*core.Pod, error) {
 	if err != nil {
 		return nil, errors.Wrapf(err, "creating pod selector for service: %v", svc.Name)
 	}
-	pods, err := loadPods(key, c, selector)
+	pods, err := loadPods(ctx, key, c, nil)
 	if err != nil {
 		return nil, errors.Wrapf(err, "fetching pods for service: %v", svc.Name)
 	}
 
-	return pods, nil
+	// Services without a selector don't select any pods
+	var results []*core.Pod
+	for _, pod := range pods {
+		matches, err := selectorMatchesPod(selector, pod, false)
+		if err != nil {
+			return nil, errors.Wrapf(err, "matching pods for service: %v", svc.Name)
+		}
+		if matches {
+			results = append(results, pod)
+		}
+	}
+
+	return results, nil
 }
 
 // Reverse-lookup services that point to a pod
-func findServicesForPod(pod *core.Pod, c Cache) ([]*core.Service, error) {
+func findServicesForPod(ctx context.Context, pod *core.Pod, c Cache) ([]*core.Service, error) {
 	var results []*core.Service
 	if pod == nil {
 		return nil, errors.New("nil pod")
@@ -677,7 +761,7 @@ func findServicesForPod(pod *core.Pod, c Cache) ([]*core.Service, error) {
 		APIVersion: "v1",
 		Kind:       "Service",
 	}
-	ul, err := c.Retrieve(key)
+	ul, err := c.Retrieve(ctx, key)
 	if err != nil {
 		return nil, errors.Wrap(err, "retrieving services")
 	}
@@ -694,12 +778,12 @@ func findServicesForPod(pod *core.Pod, c Cache) ([]*core.Service, error) {
 		if err != nil {
 			return nil, errors.Wrapf(err, "creating pod selector for service: %v", svc.Name)
 		}
-		selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+		// Services without a selector don't select any pods
+		matches, err := selectorMatchesPod(labelSelector, pod, false)
 		if err != nil {
-			return nil, errors.Wrap(err, "invalid selector")
+			return nil, err
 		}
-
-		if selector.Empty() || !selector.Matches(labels.Set(pod.Labels)) {
+		if !matches {
 			continue
 		}
 		results = append(results, svc)
@@ -707,8 +791,15 @@ func findServicesForPod(pod *core.Pod, c Cache) ([]*core.Service, error) {
 	return results, nil
 }
 
+// selectorMatchesPod returns true if a label selector matches a pod's labels.
+// Whether an empty selector matches every pod or none depends on what is
+// selecting, so the caller decides with matchEmpty.
+func selectorMatchesPod(labelSelector *metav1.LabelSelector, pod *core.Pod, matchEmpty bool) (bool, error) {
+	return cluster.SelectorMatches(labelSelector, pod.Labels, matchEmpty)
+}
+
 // Reverse-lookup replicasets that point to a pod
-func findReplicaSetsForPod(pod *core.Pod, c Cache) ([]*extensions.ReplicaSet, error) {
+func findReplicaSetsForPod(ctx context.Context, pod *core.Pod, c Cache) ([]*extensions.ReplicaSet, error) {
 	var results []*extensions.ReplicaSet
 	if pod == nil {
 		return nil, errors.New("nil pod")
@@ -722,7 +813,7 @@ func findReplicaSetsForPod(pod *core.Pod, c Cache) ([]*extensions.ReplicaSet, er
 		APIVersion: "apps/v1",
 		Kind:       "ReplicaSet",
 	}
-	ul, err := c.Retrieve(key)
+	ul, err := c.Retrieve(ctx, key)
 	if err != nil {
 		return nil, errors.Wrap(err, "retrieving replicaSets")
 	}
@@ -753,7 +844,7 @@ func findReplicaSetsForPod(pod *core.Pod, c Cache) ([]*extensions.ReplicaSet, er
 }
 
 // Reverse-lookup deployments that point to a pod
-func findDeploymentsForPod(pod *core.Pod, c Cache) ([]*extensions.Deployment, error) {
+func findDeploymentsForPod(ctx context.Context, pod *core.Pod, c Cache) ([]*extensions.Deployment, error) {
 	var results []*extensions.Deployment
 	if pod == nil {
 		return nil, errors.New("nil pod")
@@ -767,7 +858,7 @@ func findDeploymentsForPod(pod *core.Pod, c Cache) ([]*extensions.Deployment, er
 		APIVersion: "apps/v1",
 		Kind:       "Deployment",
 	}
-	ul, err := c.Retrieve(key)
+	ul, err := c.Retrieve(ctx, key)
 	if err != nil {
 		return nil, errors.Wrap(err, "retrieving deployments")
 	}
@@ -797,7 +888,7 @@ func findDeploymentsForPod(pod *core.Pod, c Cache) ([]*extensions.Deployment, er
 	return results, nil
 }
 
-func findDeploymentForReplicaSet(rs *extensions.ReplicaSet, c Cache) (*extensions.Deployment, error) {
+func findDeploymentForReplicaSet(ctx context.Context, rs *extensions.ReplicaSet, c Cache) (*extensions.Deployment, error) {
 	if rs == nil {
 		return nil, errors.New("nil replicaset")
 	}
@@ -823,7 +914,7 @@ func findDeploymentForReplicaSet(rs *extensions.ReplicaSet, c Cache) (*extension
 		return nil, nil
 	}
 
-	ul, err := c.Retrieve(key)
+	ul, err := c.Retrieve(ctx, key)
 	if err != nil {
 		return nil, errors.Wrapf(err, "retrieving deployment: %v", key)
 	}
@@ -841,7 +932,7 @@ func findDeploymentForReplicaSet(rs *extensions.ReplicaSet, c Cache) (*extension
 	return nil, nil
 }
 
-func findStatefulSetForPod(pod *core.Pod, c Cache) (*apps.StatefulSet, error) {
+func findStatefulSetForPod(ctx context.Context, pod *core.Pod, c Cache) (*apps.StatefulSet, error) {
 	if pod == nil {
 		return nil, errors.New("nil pod")
 	}
@@ -867,7 +958,7 @@ func findStatefulSetForPod(pod *core.Pod, c Cache) (*apps.StatefulSet, error) {
 		return nil, nil
 	}
 
-	ul, err := c.Retrieve(key)
+	ul, err := c.Retrieve(ctx, key)
 	if err != nil {
 		return nil, errors.Wrapf(err, "retrieving statefulset: %v", key)
 	}
@@ -885,7 +976,7 @@ func findStatefulSetForPod(pod *core.Pod, c Cache) (*apps.StatefulSet, error) {
 	return nil, nil
 }
 
-func findReplicationControllerForPod(pod *core.Pod, c Cache) (*core.ReplicationController, error) {
+func findReplicationControllerForPod(ctx context.Context, pod *core.Pod, c Cache) (*core.ReplicationController, error) {
 	if pod == nil {
 		return nil, errors.New("nil pod")
 	}
@@ -911,7 +1002,7 @@ func findReplicationControllerForPod(pod *core.Pod, c Cache) (*core.ReplicationC
 		return nil, nil
 	}
 
-	ul, err := c.Retrieve(key)
+	ul, err := c.Retrieve(ctx, key)
 	if err != nil {
 		return nil, errors.Wrapf(err, "retrieving replicationcontroller: %v", key)
 	}
@@ -929,7 +1020,7 @@ func findReplicationControllerForPod(pod *core.Pod, c Cache) (*core.ReplicationC
 	return nil, nil
 }
 
-func findDaemonSetForPod(pod *core.Pod, c Cache) (*extensions.DaemonSet, error) {
+func findDaemonSetForPod(ctx context.Context, pod *core.Pod, c Cache) (*extensions.DaemonSet, error) {
 	if pod == nil {
 		return nil, errors.New("nil pod")
 	}
@@ -955,7 +1046,7 @@ func findDaemonSetForPod(pod *core.Pod, c Cache) (*extensions.DaemonSet, error)
 		return nil, nil
 	}
 
-	ul, err := c.Retrieve(key)
+	ul, err := c.Retrieve(ctx, key)
 	if err != nil {
 		return nil, errors.Wrapf(err, "retrieving daemonset: %v", key)
 	}
@@ -1,11 +1,13 @@
 package overview
 
 import (
-	"github.com/twosson/kubeapt/internal/content"
+	"context"
+	"github.com/twosson/kubeapt/pkg/content"
 	"k8s.io/api/extensions/v1beta1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/kubernetes/pkg/apis/apps"
+	"k8s.io/kubernetes/pkg/apis/autoscaling"
 	"k8s.io/kubernetes/pkg/apis/core"
 	"k8s.io/kubernetes/pkg/apis/extensions"
 	"strconv"
@@ -78,7 +80,7 @@ func statusForService(svc *core.Service) content.NodeStatus {
 	return content.NodeStatusOK
 }
 
-func statusForIngress(ingress *v1beta1.Ingress, c Cache) (ResourceStatusList, error) {
+func statusForIngress(ctx context.Context, ingress *v1beta1.Ingress, c Cache) (ResourceStatusList, error) {
 	if ingress == nil {
 		return nil, nil
 	}
@@ -98,7 +100,7 @@ func statusForIngress(ingress *v1beta1.Ingress, c Cache) (ResourceStatusList, er
 		if b.ServiceName == "" {
 			continue
 		}
-		svc, err := loadService(b.ServiceName, ingress.Namespace, c)
+		svc, err := loadService(ctx, b.ServiceName, ingress.Namespace, c)
 		if err != nil {
 			return nil, err
 		}
@@ -143,7 +145,7 @@ func statusForIngress(ingress *v1beta1.Ingress, c Cache) (ResourceStatusList, er
 			Kind:       "Secret",
 			Name:       tls.SecretName,
 		}
-		secrets, err := loadSecrets(key, c)
+		secrets, err := loadSecrets(ctx, key, c)
 		if err != nil {
 			// Special case - we assume if there was an error it was an access error
 			// (the user may not be allowed to see secrets) - and will skip validating TLS.
@@ -205,6 +207,21 @@ func statusForDaemonSet(ds *extensions.DaemonSet) content.NodeStatus {
 	return content.NodeStatusOK
 }
 
+// statusForHorizontalPodAutoscaler warns when an autoscaler isn't able to
+// scale its target.
+func statusForHorizontalPodAutoscaler(hpa *autoscaling.HorizontalPodAutoscaler) content.NodeStatus {
+	for _, condition := range hpa.Status.Conditions {
+		switch condition.Type {
+		case autoscaling.AbleToScale, autoscaling.ScalingActive:
+			if condition.Status == core.ConditionFalse {
+				return content.NodeStatusWarning
+			}
+		}
+	}
+
+	return content.NodeStatusOK
+}
+
 // tlsHostMap returns a map whose keys are the defined TLS hosts for an ingress.
 func tlsHostMap(ingress *v1beta1.Ingress) map[string]bool {
 	if ingress == nil {
@@ -1,6 +1,7 @@
 package overview
 
 import (
+	"context"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"k8s.io/api/extensions/v1beta1"
@@ -389,7 +390,7 @@ spec:
 
 			switch v := objects[0].(type) {
 			case *v1beta1.Ingress:
-				actual, err := statusForIngress(v, c)
+				actual, err := statusForIngress(context.Background(), v, c)
 				require.NoError(t, err)
 				if err != nil {
 					return
@@ -3,7 +3,7 @@ package overview
 import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"github.com/twosson/kubeapt/internal/content"
+	"github.com/twosson/kubeapt/pkg/content"
 	metav1beta1 "k8s.io/apimachinery/pkg/apis/meta/v1beta1"
 	"testing"
 )
@@ -100,7 +100,7 @@ func Test_printCronJobTable(t *testing.T) {
 		},
 	}
 
-	contentTable, err := printContentTable("Title", "default", "/prefix", "", tbl, cronJobTransforms)
+	contentTable, err := printContentTable("Title", "default", testPrefix, "", tbl, cronJobTransforms)
 	require.NoError(t, err)
 
 	expected := content.NewTable("Title", "")
@@ -239,7 +239,7 @@ func Test_printDeploymentTable(t *testing.T) {
 		},
 	}
 
-	contentTable, err := printContentTable("Title", "default", "/prefix", "", tbl, deploymentTransforms)
+	contentTable, err := printContentTable("Title", "default", testPrefix, "", tbl, deploymentTransforms)
 	require.NoError(t, err)
 
 	expected := content.NewTable("Title", "")
@@ -0,0 +1,173 @@
+package overview
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"github.com/twosson/kubeapt/pkg/content"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/clock"
+)
+
+// yamlSection is added to every resource. It shows the object's manifest.
+var yamlSection = ContentSection{
+	Views: []ViewFactory{
+		NewYAMLView,
+	},
+	Title: "YAML",
+}
+
+// lastAppliedAnnotation is set by kubectl apply. It duplicates the manifest.
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+type fullManifestKey struct{}
+
+// withFullManifest returns a context which controls whether manifests are
+// shown as stored by the cluster, or with generated fields stripped.
+func withFullManifest(ctx context.Context, full bool) context.Context {
+	return context.WithValue(ctx, fullManifestKey{}, full)
+}
+
+func fullManifestFromContext(ctx context.Context) bool {
+	full, _ := ctx.Value(fullManifestKey{}).(bool)
+	return full
+}
+
+// YAMLView shows an object as YAML. Unless the full manifest is requested,
+// fields which are managed by the cluster are removed, so the manifest can
+// be copied and applied elsewhere.
+type YAMLView struct{}
+
+var _ View = (*YAMLView)(nil)
+
+// NewYAMLView creates an instance of YAMLView.
+func NewYAMLView(prefix, namespace string, c clock.Clock) View {
+	return &YAMLView{}
+}
+
+// Content renders the live object from the cache as YAML. The object the view
+// is passed has been converted to an internal type, so it isn't used directly.
+func (v *YAMLView) Content(ctx context.Context, object runtime.Object, c Cache) ([]content.Content, error) {
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		return nil, errors.Wrap(err, "accessing object metadata")
+	}
+
+	typeAccessor, err := meta.TypeAccessor(object)
+	if err != nil {
+		return nil, errors.Wrap(err, "accessing object type")
+	}
+
+	key := CacheKey{
+		Namespace:  accessor.GetNamespace(),
+		APIVersion: typeAccessor.GetAPIVersion(),
+		Kind:       typeAccessor.GetKind(),
+		Name:       accessor.GetName(),
+	}
+
+	objects, err := c.Retrieve(ctx, key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "retrieving %s %s", key.Kind, key.Name)
+	}
+
+	if len(objects) != 1 {
+		return nil, errors.Errorf("expected exactly one %s named %s", key.Kind, key.Name)
+	}
+
+	data, err := manifestYAML(objects[0], !fullManifestFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	code := content.NewCode("Manifest", "yaml", data)
+
+	return []content.Content{&code}, nil
+}
+
+// manifestYAML marshals an object to YAML, optionally removing the fields
+// which are managed by the cluster. Secrets are always redacted.
+func manifestYAML(object *unstructured.Unstructured, strip bool) (string, error) {
+	if strip {
+		object = stripManifest(object)
+	}
+
+	if isSecret(object) {
+		object = redactSecret(object)
+	}
+
+	data, err := yaml.Marshal(object.Object)
+	if err != nil {
+		return "", errors.Wrap(err, "marshaling object to YAML")
+	}
+
+	return string(data), nil
+}
+
+// stripManifest returns a copy of an object without status and generated
+// metadata.
+func stripManifest(object *unstructured.Unstructured) *unstructured.Unstructured {
+	stripped := object.DeepCopy()
+
+	unstructured.RemoveNestedField(stripped.Object, "status")
+
+	// The resource version is kept so applying an edited manifest can't
+	// overwrite changes made since it was shown.
+	for _, field := range []string{"managedFields", "creationTimestamp", "generation", "selfLink", "uid"} {
+		unstructured.RemoveNestedField(stripped.Object, "metadata", field)
+	}
+
+	annotations := stripped.GetAnnotations()
+	if _, ok := annotations[lastAppliedAnnotation]; ok {
+		delete(annotations, lastAppliedAnnotation)
+		if len(annotations) == 0 {
+			unstructured.RemoveNestedField(stripped.Object, "metadata", "annotations")
+		} else {
+			stripped.SetAnnotations(annotations)
+		}
+	}
+
+	return stripped
+}
+
+func isSecret(object *unstructured.Unstructured) bool {
+	return object.GetAPIVersion() == "v1" && object.GetKind() == "Secret"
+}
+
+// redactSecret returns a copy of a secret with its values replaced by their
+// size, as in the secret's data view. The last applied configuration
+// annotation contains the values too, so it is redacted as well.
+func redactSecret(object *unstructured.Unstructured) *unstructured.Unstructured {
+	redacted := object.DeepCopy()
+
+	if data, ok := redacted.Object["data"].(map[string]interface{}); ok {
+		for key, value := range data {
+			encoded, _ := value.(string)
+			decoded, err := base64.StdEncoding.DecodeString(encoded)
+			if err != nil {
+				data[key] = "<redacted>"
+				continue
+			}
+			data[key] = fmt.Sprintf("%d bytes", len(decoded))
+		}
+	}
+
+	if stringData, ok := redacted.Object["stringData"].(map[string]interface{}); ok {
+		for key, value := range stringData {
+			text, _ := value.(string)
+			stringData[key] = fmt.Sprintf("%d bytes", len(text))
+		}
+	}
+
+	annotations := redacted.GetAnnotations()
+	if _, ok := annotations[lastAppliedAnnotation]; ok {
+		annotations[lastAppliedAnnotation] = "<redacted>"
+		redacted.SetAnnotations(annotations)
+	}
+
+	return redacted
+}
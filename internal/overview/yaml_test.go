@@ -0,0 +1,130 @@
+package overview
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twosson/kubeapt/pkg/content"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/kubernetes/pkg/apis/core"
+)
+
+func newYAMLTestCache(t *testing.T) *MemoryCache {
+	c := NewMemoryCache()
+
+	cm := newUnstructured("v1", "ConfigMap", "default", "cm")
+	cm.Object["data"] = map[string]interface{}{"key": "value"}
+	cm.Object["status"] = map[string]interface{}{"phase": "Active"}
+	cm.SetUID("uid")
+	cm.SetResourceVersion("1")
+	cm.SetAnnotations(map[string]string{lastAppliedAnnotation: "{}"})
+	require.NoError(t, c.Store(cm))
+
+	return c
+}
+
+func TestYAMLView(t *testing.T) {
+	object := &core.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cm"},
+	}
+
+	cases := []struct {
+		name     string
+		full     bool
+		expected string
+	}{
+		{
+			name: "stripped",
+			expected: `apiVersion: v1
+data:
+  key: value
+kind: ConfigMap
+metadata:
+  name: cm
+  namespace: default
+  resourceVersion: "1"
+`,
+		},
+		{
+			name: "full",
+			full: true,
+			expected: `apiVersion: v1
+data:
+  key: value
+kind: ConfigMap
+metadata:
+  annotations:
+    kubectl.kubernetes.io/last-applied-configuration: '{}'
+  name: cm
+  namespace: default
+  resourceVersion: "1"
+  uid: uid
+status:
+  phase: Active
+`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newYAMLTestCache(t)
+			ctx := withFullManifest(context.Background(), tc.full)
+
+			v := NewYAMLView(testPrefix, "default", clock.NewFakeClock(metav1.Now().Time))
+			got, err := v.Content(ctx, object, c)
+			require.NoError(t, err)
+
+			expected := content.NewCode("Manifest", "yaml", tc.expected)
+			assert.Equal(t, []content.Content{&expected}, got)
+		})
+	}
+}
+
+func TestYAMLView_missing_object(t *testing.T) {
+	object := &core.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "missing"},
+	}
+
+	v := NewYAMLView(testPrefix, "default", clock.NewFakeClock(metav1.Now().Time))
+	_, err := v.Content(context.Background(), object, newYAMLTestCache(t))
+	require.Error(t, err)
+}
+
+func TestYAMLView_secret(t *testing.T) {
+	object := &core.Secret{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "secret"},
+	}
+
+	c := NewMemoryCache()
+	secret := newUnstructured("v1", "Secret", "default", "secret")
+	secret.Object["data"] = map[string]interface{}{"password": "c2VjcmV0"}
+	secret.Object["stringData"] = map[string]interface{}{"token": "abc"}
+	secret.SetAnnotations(map[string]string{lastAppliedAnnotation: `{"data":{"password":"c2VjcmV0"}}`})
+	require.NoError(t, c.Store(secret))
+
+	expected := `apiVersion: v1
+data:
+  password: 6 bytes
+kind: Secret
+metadata:
+  annotations:
+    kubectl.kubernetes.io/last-applied-configuration: <redacted>
+  name: secret
+  namespace: default
+stringData:
+  token: 3 bytes
+`
+
+	v := NewYAMLView(testPrefix, "default", clock.NewFakeClock(metav1.Now().Time))
+	got, err := v.Content(withFullManifest(context.Background(), true), object, c)
+	require.NoError(t, err)
+
+	code := content.NewCode("Manifest", "yaml", expected)
+	assert.Equal(t, []content.Content{&code}, got)
+}
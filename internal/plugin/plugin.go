@@ -0,0 +1,339 @@
+// Package plugin loads module plugins. Plugins are binaries in the plugin
+// directory which serve the protocol in pkg/plugin over gRPC.
+package plugin
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/twosson/kubeapt/internal/apt"
+	"github.com/twosson/kubeapt/internal/log"
+	sdk "github.com/twosson/kubeapt/pkg/plugin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultStartTimeout is how long a plugin has to write its handshake.
+	defaultStartTimeout = 10 * time.Second
+	// defaultCallTimeout is how long a plugin has to respond to a call.
+	defaultCallTimeout = 30 * time.Second
+	// stopTimeout is how long a plugin has to exit after it is stopped.
+	stopTimeout = 5 * time.Second
+)
+
+// Discover returns the paths of the executables in a plugin directory in
+// name order. A missing directory doesn't contain any plugins.
+func Discover(dir string) ([]string, error) {
+	if dir == "" {
+		return nil, nil
+	}
+
+	fileInfos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "reading plugin directory %s", dir)
+	}
+
+	var paths []string
+	for _, fi := range fileInfos {
+		if !fi.Mode().IsRegular() || fi.Mode().Perm()&0111 == 0 {
+			continue
+		}
+
+		paths = append(paths, filepath.Join(dir, fi.Name()))
+	}
+
+	sort.Strings(paths)
+
+	return paths, nil
+}
+
+// Module is a module served by a plugin process. It implements
+// module.Module.
+type Module struct {
+	path        string
+	name        string
+	contentPath string
+	cmd         *exec.Cmd
+	stdin       io.WriteCloser
+	conn        *grpc.ClientConn
+	callTimeout time.Duration
+	logger      log.Logger
+
+	mu        sync.Mutex
+	namespace string
+}
+
+// Load starts the plugin at path and connects to it.
+func Load(path string, logger log.Logger) (*Module, error) {
+	return load(exec.Command(path), defaultStartTimeout, logger)
+}
+
+func load(cmd *exec.Cmd, startTimeout time.Duration, logger log.Logger) (*Module, error) {
+	if cmd.Env == nil {
+		cmd.Env = os.Environ()
+	}
+	cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", sdk.MagicCookieKey, sdk.MagicCookieValue))
+	cmd.Stderr = os.Stderr
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, errors.Wrap(err, "creating plugin stdin")
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, errors.Wrap(err, "creating plugin stdout")
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, errors.Wrapf(err, "starting plugin %s", cmd.Path)
+	}
+
+	m := &Module{
+		path:        cmd.Path,
+		cmd:         cmd,
+		stdin:       stdin,
+		callTimeout: defaultCallTimeout,
+		logger:      logger,
+	}
+
+	if err := m.connect(stdout, startTimeout); err != nil {
+		m.kill()
+		return nil, errors.Wrapf(err, "connecting to plugin %s", cmd.Path)
+	}
+
+	return m, nil
+}
+
+// connect reads the plugin's handshake, connects to the address in the
+// handshake, and retrieves the module's metadata.
+func (m *Module) connect(stdout io.Reader, timeout time.Duration) error {
+	reader := bufio.NewReader(stdout)
+
+	lineCh := make(chan string, 1)
+	errCh := make(chan error, 1)
+	go func() {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			errCh <- errors.Wrap(err, "reading handshake")
+			return
+		}
+		lineCh <- line
+	}()
+
+	var line string
+	select {
+	case line = <-lineCh:
+	case err := <-errCh:
+		return err
+	case <-time.After(timeout):
+		return errors.New("timed out waiting for handshake")
+	}
+
+	// Anything else the plugin writes to stdout is logged.
+	go func() {
+		scanner := bufio.NewScanner(reader)
+		for scanner.Scan() {
+			m.logger.Infof("%s", scanner.Text())
+		}
+	}()
+
+	handshake, err := sdk.ParseHandshake(line)
+	if err != nil {
+		return err
+	}
+
+	if handshake.ProtocolVersion != sdk.ProtocolVersion {
+		return errors.Errorf("plugin protocol version %d is not supported, expected %d",
+			handshake.ProtocolVersion, sdk.ProtocolVersion)
+	}
+
+	if handshake.Network != "tcp" {
+		return errors.Errorf("plugin network %q is not supported", handshake.Network)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, handshake.Address,
+		grpc.WithInsecure(),
+		grpc.WithBlock(),
+		grpc.WithDefaultCallOptions(grpc.CallContentSubtype(sdk.CodecName)))
+	if err != nil {
+		return errors.Wrapf(err, "dialing %s", handshake.Address)
+	}
+	m.conn = conn
+
+	var metadata sdk.MetadataResponse
+	if err := m.invoke(sdk.MethodMetadata, &sdk.Empty{}, &metadata); err != nil {
+		return errors.Wrap(err, "retrieving metadata")
+	}
+
+	if metadata.Name == "" || !strings.HasPrefix(metadata.ContentPath, "/") {
+		return errors.Errorf("plugin returned invalid metadata: name %q, content path %q",
+			metadata.Name, metadata.ContentPath)
+	}
+
+	m.name = metadata.Name
+	m.contentPath = metadata.ContentPath
+
+	return nil
+}
+
+func (m *Module) invoke(method string, req, resp interface{}) error {
+	return m.invokeContext(context.Background(), method, req, resp)
+}
+
+func (m *Module) invokeContext(ctx context.Context, method string, req, resp interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, m.callTimeout)
+	defer cancel()
+
+	return m.conn.Invoke(ctx, method, req, resp)
+}
+
+// Name is the name of the module.
+func (m *Module) Name() string {
+	return m.name
+}
+
+// ContentPath is the path to the module's content.
+func (m *Module) ContentPath() string {
+	return m.contentPath
+}
+
+// Handler returns a HTTP handler which requests content from the plugin.
+// Content is requested for the namespace query parameter, or the current
+// namespace. Requests for content which doesn't exist respond with not
+// found.
+func (m *Module) Handler(root string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := &sdk.ContentRequest{
+			Path:      strings.TrimPrefix(r.URL.Path, root),
+			Prefix:    apt.LinkRoot(root),
+			Namespace: r.URL.Query().Get("namespace"),
+			Query:     r.URL.Query(),
+		}
+		if req.Path == "" {
+			req.Path = "/"
+		}
+		if req.Namespace == "" {
+			req.Namespace = m.currentNamespace()
+		}
+
+		logger := m.logger.With("path", req.Path, "namespace", req.Namespace)
+
+		var resp json.RawMessage
+		if err := m.invokeContext(r.Context(), sdk.MethodContent, req, &resp); err != nil {
+			if status.Code(err) == codes.NotFound {
+				respondWithError(w, http.StatusNotFound, status.Convert(err).Message(), logger)
+				return
+			}
+
+			logger.Errorf("generating content: %v", err)
+			respondWithError(w, http.StatusInternalServerError, status.Convert(err).Message(), logger)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		if _, err := w.Write(resp); err != nil {
+			logger.Errorf("writing response: %v", err)
+		}
+	})
+}
+
+// Navigation returns navigation entries for the module.
+func (m *Module) Navigation(root string) (*apt.Navigation, error) {
+	var nav apt.Navigation
+	if err := m.invoke(sdk.MethodNavigation, &sdk.NavigationRequest{Root: root}, &nav); err != nil {
+		return nil, errors.Wrapf(err, "retrieving navigation from plugin %s", m.name)
+	}
+
+	return &nav, nil
+}
+
+// SetNamespace sets the current namespace.
+func (m *Module) SetNamespace(namespace string) error {
+	if err := m.invoke(sdk.MethodSetNamespace, &sdk.NamespaceRequest{Namespace: namespace}, &sdk.Empty{}); err != nil {
+		return errors.Wrapf(err, "setting namespace for plugin %s", m.name)
+	}
+
+	m.mu.Lock()
+	m.namespace = namespace
+	m.mu.Unlock()
+
+	return nil
+}
+
+func (m *Module) currentNamespace() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.namespace
+}
+
+// Start starts the module.
+func (m *Module) Start() error {
+	if err := m.invoke(sdk.MethodStart, &sdk.Empty{}, &sdk.Empty{}); err != nil {
+		return errors.Wrapf(err, "starting plugin %s", m.name)
+	}
+
+	return nil
+}
+
+// Stop stops the module and waits for the plugin process to exit. The
+// process is killed if it doesn't exit in time.
+func (m *Module) Stop() {
+	if err := m.invoke(sdk.MethodStop, &sdk.Empty{}, &sdk.Empty{}); err != nil {
+		m.logger.Errorf("stopping plugin: %v", err)
+	}
+
+	m.kill()
+}
+
+// kill closes the connection to the plugin and waits for the process to
+// exit. Closing stdin tells the plugin to exit.
+func (m *Module) kill() {
+	if m.conn != nil {
+		m.conn.Close()
+	}
+	m.stdin.Close()
+
+	done := make(chan struct{})
+	go func() {
+		m.cmd.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(stopTimeout):
+		m.logger.Errorf("plugin %s did not exit, killing it", m.path)
+		if err := m.cmd.Process.Kill(); err != nil {
+			m.logger.Errorf("killing plugin: %v", err)
+		}
+		<-done
+	}
+}
+
+func respondWithError(w http.ResponseWriter, code int, message string, logger log.Logger) {
+	if err := apt.RespondWithError(w, code, message); err != nil {
+		logger.Errorf("%v", err)
+	}
+}
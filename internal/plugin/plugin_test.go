@@ -0,0 +1,165 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twosson/kubeapt/internal/log"
+	sdk "github.com/twosson/kubeapt/pkg/plugin"
+	"github.com/twosson/kubeapt/pkg/plugin/sample"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// testPluginEnv makes the test binary serve the sample plugin instead of
+// running tests, so tests can start it as a plugin process.
+const testPluginEnv = "KUBEAPT_TEST_PLUGIN"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(testPluginEnv) == "sample" {
+		if err := sdk.Serve(sample.New()); err != nil {
+			fmt.Fprintf(os.Stderr, "serving sample plugin: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	os.Exit(m.Run())
+}
+
+func loadSamplePlugin(t *testing.T) *Module {
+	cmd := exec.Command(os.Args[0])
+	cmd.Env = append(os.Environ(), testPluginEnv+"=sample")
+
+	m, err := load(cmd, 10*time.Second, log.NopLogger())
+	require.NoError(t, err)
+
+	return m
+}
+
+func TestDiscover(t *testing.T) {
+	dir, err := ioutil.TempDir("", "plugins")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "b-plugin"), nil, 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "a-plugin"), nil, 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "README"), nil, 0644))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "directory"), 0755))
+
+	paths, err := Discover(dir)
+	require.NoError(t, err)
+
+	expected := []string{
+		filepath.Join(dir, "a-plugin"),
+		filepath.Join(dir, "b-plugin"),
+	}
+	assert.Equal(t, expected, paths)
+}
+
+func TestDiscover_missingDirectory(t *testing.T) {
+	paths, err := Discover(filepath.Join(os.TempDir(), "kubeapt-missing-plugins"))
+	require.NoError(t, err)
+	assert.Empty(t, paths)
+}
+
+func TestModule(t *testing.T) {
+	m := loadSamplePlugin(t)
+
+	assert.Equal(t, "sample", m.Name())
+	assert.Equal(t, "/sample", m.ContentPath())
+
+	require.NoError(t, m.Start())
+
+	nav, err := m.Navigation("/content/sample")
+	require.NoError(t, err)
+	assert.Equal(t, "Sample", nav.Title)
+	assert.Equal(t, "/content/sample", nav.Path)
+	require.Len(t, nav.Children, 2)
+	assert.Equal(t, "/content/sample/alpha", nav.Children[0].Path)
+
+	m.Stop()
+	assert.NotNil(t, m.cmd.ProcessState)
+}
+
+func TestModule_Handler(t *testing.T) {
+	m := loadSamplePlugin(t)
+	defer m.Stop()
+
+	require.NoError(t, m.SetNamespace("apps"))
+
+	handler := m.Handler("/api/v1/content/sample")
+
+	cases := []struct {
+		name         string
+		path         string
+		expectedCode int
+		expected     string
+	}{
+		{
+			name:         "list",
+			path:         "/api/v1/content/sample/",
+			expectedCode: http.StatusOK,
+			expected: `{"title":"Sample","views":[{"contents":[{
+				"type":"table",
+				"title":"Items",
+				"columns":[{"name":"Name","accessor":"Name"},{"name":"Namespace","accessor":"Namespace"}],
+				"rows":[
+					{"Name":{"type":"link","text":"alpha","ref":"/content/sample/alpha"},"Namespace":{"type":"string","text":"apps"}},
+					{"Name":{"type":"link","text":"beta","ref":"/content/sample/beta"},"Namespace":{"type":"string","text":"apps"}}
+				],
+				"empty_content":"There are no items"
+			}]}]}`,
+		},
+		{
+			name:         "item in namespace",
+			path:         "/api/v1/content/sample/beta?namespace=default",
+			expectedCode: http.StatusOK,
+			expected: `{"title":"beta","views":[{"contents":[{
+				"type":"summary",
+				"title":"Details",
+				"sections":[{"title":"","items":[
+					{"type":"text","label":"Name","data":{"value":"beta"}},
+					{"type":"text","label":"Namespace","data":{"value":"default"}}
+				]}]
+			}]}]}`,
+		},
+		{
+			name:         "missing",
+			path:         "/api/v1/content/sample/gamma",
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			require.Equal(t, tc.expectedCode, w.Code, w.Body.String())
+			if tc.expected != "" {
+				assert.JSONEq(t, tc.expected, w.Body.String())
+				return
+			}
+
+			var er errorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &er))
+			assert.Equal(t, tc.expectedCode, er.Error.Code)
+		})
+	}
+}
+
+func TestLoad_notAPlugin(t *testing.T) {
+	cmd := exec.Command(os.Args[0], "-test.run", "^$")
+
+	_, err := load(cmd, 10*time.Second, log.NopLogger())
+	require.Error(t, err)
+}
@@ -0,0 +1,61 @@
+package content
+
+import "time"
+
+var _ Content = (*Chart)(nil)
+
+// Chart is a line chart of values sampled over a short window. Every series
+// is drawn against the same axes, so they share a unit.
+type Chart struct {
+	Type         string        `json:"type"`
+	Title        string        `json:"title,omitempty"`
+	Unit         string        `json:"unit,omitempty"`
+	Series       []ChartSeries `json:"series"`
+	EmptyContent string        `json:"empty_content,omitempty"`
+}
+
+// ChartSeries is a named line in a chart.
+type ChartSeries struct {
+	Name   string       `json:"name"`
+	Points []ChartPoint `json:"points"`
+}
+
+// ChartPoint is a value sampled at a time. Timestamp is in seconds since
+// the Unix epoch.
+type ChartPoint struct {
+	Timestamp int64   `json:"timestamp"`
+	Value     float64 `json:"value"`
+}
+
+// NewChart creates an instance of Chart. emptyContent is shown until a value
+// has been sampled.
+func NewChart(title, unit, emptyContent string) Chart {
+	return Chart{
+		Type:         "chart",
+		Title:        title,
+		Unit:         unit,
+		Series:       []ChartSeries{},
+		EmptyContent: emptyContent,
+	}
+}
+
+// IsEmpty returns true if no series has any points.
+func (c *Chart) IsEmpty() bool {
+	for _, series := range c.Series {
+		if len(series.Points) > 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// AddSeries adds a series to the chart.
+func (c *Chart) AddSeries(series ChartSeries) {
+	c.Series = append(c.Series, series)
+}
+
+// AddPoint adds a value sampled at a time to a series.
+func (s *ChartSeries) AddPoint(t time.Time, value float64) {
+	s.Points = append(s.Points, ChartPoint{Timestamp: t.Unix(), Value: value})
+}
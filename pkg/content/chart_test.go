@@ -0,0 +1,30 @@
+package content
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestChart(t *testing.T) {
+	chart := NewChart("CPU Usage", "cores", "Usage hasn't been sampled yet")
+	require.True(t, chart.IsEmpty())
+
+	series := ChartSeries{Name: "Usage"}
+	chart.AddSeries(series)
+	require.True(t, chart.IsEmpty())
+
+	series.AddPoint(time.Unix(1542708000, 0), 0.25)
+	series.AddPoint(time.Unix(1542708030, 0), 0.5)
+	chart.Series[0] = series
+	assert.False(t, chart.IsEmpty())
+
+	data, err := json.Marshal(&chart)
+	require.NoError(t, err)
+
+	expected := `{"type":"chart","title":"CPU Usage","unit":"cores","empty_content":"Usage hasn't been sampled yet",
+		"series":[{"name":"Usage","points":[{"timestamp":1542708000,"value":0.25},{"timestamp":1542708030,"value":0.5}]}]}`
+	assert.JSONEq(t, expected, string(data))
+}
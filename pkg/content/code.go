@@ -0,0 +1,27 @@
+package content
+
+var _ Content = (*Code)(nil)
+
+// Code is a block of source, e.g. an object manifest.
+type Code struct {
+	Type     string `json:"type"`
+	Title    string `json:"title,omitempty"`
+	Language string `json:"language"`
+	Data     string `json:"data"`
+}
+
+// NewCode creates an instance of Code. language is a hint for syntax
+// highlighting, e.g. yaml.
+func NewCode(title, language, data string) Code {
+	return Code{
+		Type:     "code",
+		Title:    title,
+		Language: language,
+		Data:     data,
+	}
+}
+
+// IsEmpty returns true if there is no code.
+func (c *Code) IsEmpty() bool {
+	return c.Data == ""
+}
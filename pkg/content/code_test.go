@@ -0,0 +1,22 @@
+package content
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCode(t *testing.T) {
+	code := NewCode("Manifest", "yaml", "")
+	require.True(t, code.IsEmpty())
+
+	code.Data = "kind: Pod\n"
+	assert.False(t, code.IsEmpty())
+
+	data, err := json.Marshal(&code)
+	require.NoError(t, err)
+
+	expected := `{"type":"code","title":"Manifest","language":"yaml","data":"kind: Pod\n"}`
+	assert.JSONEq(t, expected, string(data))
+}
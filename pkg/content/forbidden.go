@@ -0,0 +1,25 @@
+package content
+
+var _ Content = (*Forbidden)(nil)
+
+// Forbidden is shown in place of content the user isn't allowed to see.
+type Forbidden struct {
+	Type    string `json:"type"`
+	Title   string `json:"title,omitempty"`
+	Message string `json:"message"`
+}
+
+// NewForbidden creates an instance of Forbidden. message explains what the
+// user isn't allowed to do.
+func NewForbidden(title, message string) Forbidden {
+	return Forbidden{
+		Type:    "forbidden",
+		Title:   title,
+		Message: message,
+	}
+}
+
+// IsEmpty returns false. Forbidden content is always shown.
+func (f *Forbidden) IsEmpty() bool {
+	return false
+}
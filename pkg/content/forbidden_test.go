@@ -0,0 +1,19 @@
+package content
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestForbidden(t *testing.T) {
+	forbidden := NewForbidden("Pods", "unable to list pods")
+	assert.False(t, forbidden.IsEmpty())
+
+	data, err := json.Marshal(&forbidden)
+	require.NoError(t, err)
+
+	expected := `{"type":"forbidden","title":"Pods","message":"unable to list pods"}`
+	assert.JSONEq(t, expected, string(data))
+}
@@ -0,0 +1,25 @@
+package content
+
+var _ Content = (*Loading)(nil)
+
+// Loading is shown in place of content which is still being retrieved.
+type Loading struct {
+	Type    string `json:"type"`
+	Title   string `json:"title,omitempty"`
+	Message string `json:"message"`
+}
+
+// NewLoading creates an instance of Loading. message explains what is
+// still being retrieved.
+func NewLoading(title, message string) Loading {
+	return Loading{
+		Type:    "loading",
+		Title:   title,
+		Message: message,
+	}
+}
+
+// IsEmpty returns false. Loading content is always shown.
+func (l *Loading) IsEmpty() bool {
+	return false
+}
@@ -0,0 +1,19 @@
+package content
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestLoading(t *testing.T) {
+	loading := NewLoading("Pods", "pods are still loading")
+	assert.False(t, loading.IsEmpty())
+
+	data, err := json.Marshal(&loading)
+	require.NoError(t, err)
+
+	expected := `{"type":"loading","title":"Pods","message":"pods are still loading"}`
+	assert.JSONEq(t, expected, string(data))
+}
@@ -0,0 +1,43 @@
+package content
+
+var _ Content = (*Logs)(nil)
+
+// Logs is the log output of a container.
+type Logs struct {
+	Type       string    `json:"type"`
+	Title      string    `json:"title,omitempty"`
+	Pod        string    `json:"pod"`
+	Container  string    `json:"container"`
+	Containers []string  `json:"containers,omitempty"`
+	Lines      []LogLine `json:"lines"`
+}
+
+// LogLine is a single line of log output. Timestamp is only set if
+// timestamps were requested.
+type LogLine struct {
+	Timestamp string `json:"timestamp,omitempty"`
+	Message   string `json:"message"`
+}
+
+// NewLogs creates an instance of Logs for a container in a pod. containers
+// are all the containers in the pod, so a client can switch between them.
+func NewLogs(pod, container string, containers []string) Logs {
+	return Logs{
+		Type:       "logs",
+		Title:      "Logs",
+		Pod:        pod,
+		Container:  container,
+		Containers: containers,
+		Lines:      []LogLine{},
+	}
+}
+
+// IsEmpty returns true if there are no log lines.
+func (l *Logs) IsEmpty() bool {
+	return len(l.Lines) == 0
+}
+
+// AddLine adds a line to the logs.
+func (l *Logs) AddLine(line LogLine) {
+	l.Lines = append(l.Lines, line)
+}
@@ -0,0 +1,22 @@
+package content
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestLogs(t *testing.T) {
+	logs := NewLogs("pod", "app", []string{"app", "sidecar"})
+	require.True(t, logs.IsEmpty())
+
+	logs.AddLine(LogLine{Timestamp: "2018-11-08T17:55:45Z", Message: "started"})
+	assert.False(t, logs.IsEmpty())
+
+	data, err := json.Marshal(&logs)
+	require.NoError(t, err)
+
+	expected := `{"type":"logs","title":"Logs","pod":"pod","container":"app","containers":["app","sidecar"],"lines":[{"timestamp":"2018-11-08T17:55:45Z","message":"started"}]}`
+	assert.JSONEq(t, expected, string(data))
+}
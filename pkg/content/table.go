@@ -0,0 +1,65 @@
+package content
+
+var _ Content = (*Table)(nil)
+
+type Table struct {
+	Type         string         `json:"type,omitempty"`
+	Title        string         `json:"title,omitempty"`
+	Columns      []TableColumn  `json:"columns,omitempty"`
+	Rows         []TableRow     `json:"rows,omitempty"`
+	EmptyContent string         `json:"empty_content,omitempty"`
+	Filters      *TableFilters  `json:"filters,omitempty"`
+	Metadata     *TableMetadata `json:"metadata,omitempty"`
+}
+
+// TableFilters are the filters applied to the objects listed in a table.
+type TableFilters struct {
+	LabelSelector string `json:"labelSelector,omitempty"`
+	FieldSelector string `json:"fieldSelector,omitempty"`
+}
+
+// TableMetadata describes how the rows of a table were sorted, filtered and
+// paginated. Total is the number of rows which matched the filter, before
+// the rows were paginated. Continue is set if there are more rows, and is
+// used to request the next page.
+type TableMetadata struct {
+	SortBy         string `json:"sortBy,omitempty"`
+	SortDescending bool   `json:"sortDescending,omitempty"`
+	Filter         string `json:"filter,omitempty"`
+	Offset         int    `json:"offset"`
+	Limit          int    `json:"limit,omitempty"`
+	Total          int    `json:"total"`
+	Continue       string `json:"continue,omitempty"`
+}
+
+func NewTable(title, emptyContent string) Table {
+	return Table{
+		Type:         "table",
+		Title:        title,
+		EmptyContent: emptyContent,
+	}
+}
+
+func (t *Table) IsEmpty() bool {
+	return len(t.Rows) == 0
+}
+
+func (t *Table) ColumnNames() []string {
+	var names []string
+	for _, col := range t.Columns {
+		names = append(names, col.Name)
+	}
+
+	return names
+}
+
+func (t *Table) AddRow(row TableRow) {
+	t.Rows = append(t.Rows, row)
+}
+
+type TableColumn struct {
+	Name     string `json:"name,omitempty"`
+	Accessor string `json:"accessor,omitempty"`
+}
+
+type TableRow map[string]Text
@@ -0,0 +1,84 @@
+// Package plugin is the SDK for apt module plugins. A plugin is a separate
+// binary which is discovered in the plugin directory. apt starts the binary
+// and talks to it over gRPC. The protocol mirrors module.Module: apt asks
+// the plugin for its name, content path and navigation, and requests
+// content for paths below the content path.
+//
+// A plugin implements Module and calls Serve from its main function:
+//
+//	func main() {
+//		if err := plugin.Serve(newModule()); err != nil {
+//			log.Fatal(err)
+//		}
+//	}
+package plugin
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/twosson/kubeapt/pkg/content"
+)
+
+// Navigation is a navigation entry for a module. Children are shown below
+// the entry in the dashboard's navigation.
+type Navigation struct {
+	Title    string        `json:"title,omitempty"`
+	Path     string        `json:"path,omitempty"`
+	Children []*Navigation `json:"children,omitempty"`
+}
+
+// Content is content shown in a view. Content is encoded as JSON, and the
+// encoding must contain a type field the dashboard can render, e.g. table.
+// The content package has the types the dashboard renders.
+type Content = content.Content
+
+// ErrNotFound is returned by Module.Content when there is no content for a
+// path. apt responds to the request with a not found status.
+var ErrNotFound = errors.New("content not found")
+
+// Module is a module implemented by a plugin.
+type Module interface {
+	// Name is the name of the module.
+	Name() string
+	// ContentPath is the path of the module's content, e.g. /cost.
+	ContentPath() string
+	// Navigation returns the navigation entries for the module. Paths in
+	// the entries must start with root.
+	Navigation(root string) (*Navigation, error)
+	// Content generates content for a path relative to the content path.
+	Content(ctx context.Context, req ContentRequest) (ContentResponse, error)
+	// SetNamespace sets the namespace content is generated for.
+	SetNamespace(namespace string) error
+	// Start starts the module.
+	Start() error
+	// Stop stops the module. The plugin process is stopped afterwards.
+	Stop()
+}
+
+// ContentRequest is a request for content.
+type ContentRequest struct {
+	// Path is the requested path relative to the module's content path.
+	Path string `json:"path"`
+	// Prefix is the path links to the module's content start with, e.g.
+	// /content/cost. It is the root the module's navigation is created for.
+	Prefix string `json:"prefix"`
+	// Namespace is the namespace content is requested for. It is the
+	// namespace in the request, or the namespace last passed to
+	// SetNamespace.
+	Namespace string `json:"namespace"`
+	// Query holds the request's query parameters.
+	Query map[string][]string `json:"query,omitempty"`
+}
+
+// ContentResponse is the content for a path. It has the same shape as the
+// content served by in-tree modules.
+type ContentResponse struct {
+	Title string `json:"title,omitempty"`
+	Views []View `json:"views,omitempty"`
+}
+
+// View is a titled group of content.
+type View struct {
+	Title    string    `json:"title,omitempty"`
+	Contents []Content `json:"contents,omitempty"`
+}
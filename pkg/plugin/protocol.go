@@ -0,0 +1,157 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/status"
+)
+
+// ProtocolVersion is the version of the plugin protocol. apt won't load
+// plugins which speak a different version.
+const ProtocolVersion = 1
+
+// CodecName is the gRPC content subtype used by the protocol. Messages are
+// encoded as JSON, so content can be passed to the dashboard unchanged.
+const CodecName = "json"
+
+// ServiceName is the name of the gRPC service implemented by plugins.
+const ServiceName = "kubeapt.plugin.Module"
+
+// Methods of the plugin service.
+const (
+	MethodMetadata     = "/" + ServiceName + "/Metadata"
+	MethodNavigation   = "/" + ServiceName + "/Navigation"
+	MethodContent      = "/" + ServiceName + "/Content"
+	MethodSetNamespace = "/" + ServiceName + "/SetNamespace"
+	MethodStart        = "/" + ServiceName + "/Start"
+	MethodStop         = "/" + ServiceName + "/Stop"
+)
+
+// Empty is a message without fields.
+type Empty struct{}
+
+// MetadataResponse describes a module.
+type MetadataResponse struct {
+	Name        string `json:"name"`
+	ContentPath string `json:"contentPath"`
+}
+
+// NavigationRequest is a request for a module's navigation.
+type NavigationRequest struct {
+	Root string `json:"root"`
+}
+
+// NamespaceRequest sets the namespace of a module.
+type NamespaceRequest struct {
+	Namespace string `json:"namespace"`
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return CodecName
+}
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
+
+// server serves a Module over gRPC.
+type server struct {
+	module Module
+	stopFn func()
+}
+
+// serviceDesc describes the plugin service. It is written by hand, since
+// messages are encoded as JSON rather than protocol buffers.
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*interface{})(nil),
+	Methods: []grpc.MethodDesc{
+		unaryMethod("Metadata", func() interface{} { return &Empty{} }, func(s *server, ctx context.Context, req interface{}) (interface{}, error) {
+			return &MetadataResponse{
+				Name:        s.module.Name(),
+				ContentPath: s.module.ContentPath(),
+			}, nil
+		}),
+		unaryMethod("Navigation", func() interface{} { return &NavigationRequest{} }, func(s *server, ctx context.Context, req interface{}) (interface{}, error) {
+			return s.module.Navigation(req.(*NavigationRequest).Root)
+		}),
+		unaryMethod("Content", func() interface{} { return &ContentRequest{} }, func(s *server, ctx context.Context, req interface{}) (interface{}, error) {
+			cr, err := s.module.Content(ctx, *req.(*ContentRequest))
+			if err != nil {
+				return nil, err
+			}
+			return &cr, nil
+		}),
+		unaryMethod("SetNamespace", func() interface{} { return &NamespaceRequest{} }, func(s *server, ctx context.Context, req interface{}) (interface{}, error) {
+			return &Empty{}, s.module.SetNamespace(req.(*NamespaceRequest).Namespace)
+		}),
+		unaryMethod("Start", func() interface{} { return &Empty{} }, func(s *server, ctx context.Context, req interface{}) (interface{}, error) {
+			return &Empty{}, s.module.Start()
+		}),
+		unaryMethod("Stop", func() interface{} { return &Empty{} }, func(s *server, ctx context.Context, req interface{}) (interface{}, error) {
+			s.module.Stop()
+			if s.stopFn != nil {
+				go s.stopFn()
+			}
+			return &Empty{}, nil
+		}),
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+type methodFn func(s *server, ctx context.Context, req interface{}) (interface{}, error)
+
+// unaryMethod creates a method description which decodes a request created
+// by newReq and passes it to fn.
+func unaryMethod(name string, newReq func() interface{}, fn methodFn) grpc.MethodDesc {
+	return grpc.MethodDesc{
+		MethodName: name,
+		Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+			req := newReq()
+			if err := dec(req); err != nil {
+				return nil, err
+			}
+
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				resp, err := fn(srv.(*server), ctx, req)
+				return resp, toStatusError(err)
+			}
+
+			if interceptor == nil {
+				return handler(ctx, req)
+			}
+
+			info := &grpc.UnaryServerInfo{
+				Server:     srv,
+				FullMethod: "/" + ServiceName + "/" + name,
+			}
+			return interceptor(ctx, req, info, handler)
+		},
+	}
+}
+
+// toStatusError converts errors returned by a module to gRPC status errors.
+func toStatusError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Cause(err) == ErrNotFound:
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		return status.Error(codes.Unknown, err.Error())
+	}
+}
@@ -0,0 +1,131 @@
+// Package sample is a sample plugin module. It lists a fixed set of items
+// and shows a summary for each item. It is used to test the plugin protocol
+// and is a starting point for new plugins.
+package sample
+
+import (
+	"context"
+	"github.com/twosson/kubeapt/pkg/content"
+	"github.com/twosson/kubeapt/pkg/plugin"
+	"path"
+	"strings"
+	"sync"
+)
+
+// Module is the sample module.
+type Module struct {
+	items []string
+
+	mu        sync.Mutex
+	namespace string
+}
+
+var _ plugin.Module = (*Module)(nil)
+
+// New creates an instance of Module.
+func New() *Module {
+	return &Module{
+		items:     []string{"alpha", "beta"},
+		namespace: "default",
+	}
+}
+
+// Name is the name of the module.
+func (m *Module) Name() string {
+	return "sample"
+}
+
+// ContentPath is the path to the module's content.
+func (m *Module) ContentPath() string {
+	return "/sample"
+}
+
+// Navigation returns navigation entries for the module.
+func (m *Module) Navigation(root string) (*plugin.Navigation, error) {
+	nav := &plugin.Navigation{
+		Title: "Sample",
+		Path:  root,
+	}
+
+	for _, item := range m.items {
+		nav.Children = append(nav.Children, &plugin.Navigation{
+			Title: item,
+			Path:  path.Join(root, item),
+		})
+	}
+
+	return nav, nil
+}
+
+// Content lists the items, or shows the summary for an item.
+func (m *Module) Content(ctx context.Context, req plugin.ContentRequest) (plugin.ContentResponse, error) {
+	namespace := req.Namespace
+
+	name := strings.Trim(req.Path, "/")
+	if name == "" {
+		table := content.NewTable("Items", "There are no items")
+		table.Columns = []content.TableColumn{
+			{Name: "Name", Accessor: "Name"},
+			{Name: "Namespace", Accessor: "Namespace"},
+		}
+		for _, item := range m.items {
+			table.AddRow(content.TableRow{
+				"Name":      content.NewLinkText(item, path.Join(req.Prefix, item)),
+				"Namespace": content.NewStringText(namespace),
+			})
+		}
+
+		return plugin.ContentResponse{
+			Title: "Sample",
+			Views: []plugin.View{
+				{Contents: []plugin.Content{&table}},
+			},
+		}, nil
+	}
+
+	for _, item := range m.items {
+		if item != name {
+			continue
+		}
+
+		section := content.NewSection()
+		section.AddText("Name", item)
+		section.AddText("Namespace", namespace)
+		summary := content.NewSummary("Details", []content.Section{section})
+
+		return plugin.ContentResponse{
+			Title: item,
+			Views: []plugin.View{
+				{Contents: []plugin.Content{&summary}},
+			},
+		}, nil
+	}
+
+	return plugin.ContentResponse{}, plugin.ErrNotFound
+}
+
+// SetNamespace sets the current namespace.
+func (m *Module) SetNamespace(namespace string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.namespace = namespace
+	return nil
+}
+
+// Namespace returns the current namespace.
+func (m *Module) Namespace() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.namespace
+}
+
+// Start doesn't do anything.
+func (m *Module) Start() error {
+	return nil
+}
+
+// Stop doesn't do anything.
+func (m *Module) Stop() {
+}
@@ -0,0 +1,91 @@
+package plugin
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Handshake values. apt sets the magic cookie in the plugin's environment,
+// so a plugin can tell it was started by apt rather than by a user.
+const (
+	MagicCookieKey   = "KUBEAPT_PLUGIN_MAGIC_COOKIE"
+	MagicCookieValue = "d6e3f0c1b2a94f6e8c7d5b4a39281706"
+)
+
+// Handshake is the line a plugin writes to stdout once it is serving. It
+// contains the protocol version, network and address separated by "|".
+type Handshake struct {
+	ProtocolVersion int
+	Network         string
+	Address         string
+}
+
+// String formats the handshake line.
+func (h Handshake) String() string {
+	return fmt.Sprintf("%d|%s|%s", h.ProtocolVersion, h.Network, h.Address)
+}
+
+// ParseHandshake parses a handshake line written by a plugin.
+func ParseHandshake(line string) (Handshake, error) {
+	parts := strings.Split(strings.TrimSpace(line), "|")
+	if len(parts) != 3 {
+		return Handshake{}, errors.Errorf("invalid handshake %q", line)
+	}
+
+	version, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return Handshake{}, errors.Errorf("invalid protocol version %q", parts[0])
+	}
+
+	return Handshake{
+		ProtocolVersion: version,
+		Network:         parts[1],
+		Address:         parts[2],
+	}, nil
+}
+
+// Serve serves a module until apt stops it. The plugin also stops when its
+// stdin is closed, which happens when apt exits.
+func Serve(m Module) error {
+	if os.Getenv(MagicCookieKey) != MagicCookieValue {
+		return errors.New("this binary is an apt plugin and is started by apt")
+	}
+
+	return serve(m, os.Stdin, os.Stdout)
+}
+
+func serve(m Module, stdin io.Reader, stdout io.Writer) error {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return errors.Wrap(err, "creating listener")
+	}
+
+	grpcServer := grpc.NewServer()
+	grpcServer.RegisterService(&serviceDesc, &server{
+		module: m,
+		stopFn: grpcServer.GracefulStop,
+	})
+
+	go func() {
+		io.Copy(ioutil.Discard, stdin)
+		grpcServer.Stop()
+	}()
+
+	handshake := Handshake{
+		ProtocolVersion: ProtocolVersion,
+		Network:         listener.Addr().Network(),
+		Address:         listener.Addr().String(),
+	}
+	if _, err := fmt.Fprintln(stdout, handshake.String()); err != nil {
+		return errors.Wrap(err, "writing handshake")
+	}
+
+	return grpcServer.Serve(listener)
+}
@@ -0,0 +1,30 @@
+package plugin
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParseHandshake(t *testing.T) {
+	handshake := Handshake{ProtocolVersion: 1, Network: "tcp", Address: "127.0.0.1:1234"}
+
+	got, err := ParseHandshake(handshake.String() + "\n")
+	require.NoError(t, err)
+	assert.Equal(t, handshake, got)
+}
+
+func TestParseHandshake_invalid(t *testing.T) {
+	cases := []string{
+		"",
+		"hello",
+		"one|tcp|127.0.0.1:1234",
+	}
+
+	for _, line := range cases {
+		t.Run(line, func(t *testing.T) {
+			_, err := ParseHandshake(line)
+			assert.Error(t, err)
+		})
+	}
+}
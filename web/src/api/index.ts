@@ -13,7 +13,9 @@ export function getAPIBase() {
   return window.API_BASE || process.env.API_BASE
 }
 
-export const POLL_WAIT: number = 5
+// POLL_WAIT is how often, in seconds, streamed content is refreshed between
+// cluster changes, so ages and usage stay current.
+export const POLL_WAIT: number = 30
 
 interface BuildRequestParams {
   endpoint: string;